@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"atlassian-cli/internal/audit"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/output"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewAuditCmd creates the audit command with subcommands
+func NewAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit log operations",
+		Long:  `Inspect the local audit log of commands that modified JIRA, Confluence or credentials`,
+	}
+
+	cmd.AddCommand(newLogCmd())
+
+	return cmd
+}
+
+// newLogCmd creates the audit log command
+func newLogCmd() *cobra.Command {
+	var (
+		since   string
+		until   string
+		command string
+		status  string
+		target  string
+		limit   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show audit log entries",
+		Long: `Show audit log entries, optionally filtered by date, command and status.
+
+Dates accept YYYY-MM-DD, RFC3339 timestamps, or a duration relative to now (e.g. 24h).
+
+Examples:
+  # Show the last 50 audited commands
+  atlassian-cli audit log
+
+  # Failed issue updates in the last week
+  atlassian-cli audit log --command "issue update" --status failure --since 168h
+
+  # Everything that touched a page
+  atlassian-cli audit log --target 123456 --since 2024-01-01`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := audit.Filter{
+				Command: command,
+				Status:  status,
+				Target:  target,
+				Limit:   limit,
+			}
+
+			var err error
+			if filter.Since, err = parseTime(since); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			if filter.Until, err = parseTime(until); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+
+			dir := cmdutil.GetViperFromCmd(cmd).GetString("audit_dir")
+			if dir == "" {
+				if dir, err = audit.DefaultDir(); err != nil {
+					return err
+				}
+			}
+
+			events, err := audit.Query(dir, filter)
+			if err != nil {
+				return fmt.Errorf("failed to query audit log: %w", err)
+			}
+
+			return outputEvents(cmd, events)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only entries at or after this date")
+	cmd.Flags().StringVar(&until, "until", "", "Only entries before this date")
+	cmd.Flags().StringVar(&command, "command", "", "Only entries for commands starting with this (e.g. \"issue\")")
+	cmd.Flags().StringVar(&status, "status", "", "Only entries with this outcome (success, failure)")
+	cmd.Flags().StringVar(&target, "target", "", "Only entries for this issue key or page ID")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of entries (most recent)")
+
+	return cmd
+}
+
+// parseTime parses an absolute date or a duration relative to now
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (YYYY-MM-DD), timestamp (RFC3339) or duration", value)
+}
+
+// outputEvents outputs audit events in the configured format
+func outputEvents(cmd *cobra.Command, events []audit.Event) error {
+	table := output.NewTable("Time", "Command", "Target", "Status", "Server", "Error")
+	table.Empty = "No audit entries found"
+
+	for _, event := range events {
+		status := "ok"
+		if !event.Success {
+			status = "failed"
+		}
+
+		table.AddRow(event.Timestamp, event.Command, event.Target, status, event.Server, event.Error)
+	}
+
+	return cmdutil.WriteOutput(cmd, events, table)
+}
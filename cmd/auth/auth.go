@@ -2,12 +2,13 @@ package auth
 
 import (
 	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/types"
-	"context"
 	"fmt"
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -21,10 +22,13 @@ func NewAuthCmd(tokenManager auth.TokenManager) *cobra.Command {
 	}
 
 	// Add subcommands
-	cmd.AddCommand(newLoginCmd(tokenManager))
-	cmd.AddCommand(newLogoutCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newLoginCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newLogoutCmd(tokenManager)))
 	cmd.AddCommand(newStatusCmd(tokenManager))
 	cmd.AddCommand(newValidateCmd(tokenManager))
+	cmd.AddCommand(newPassphraseCmd(tokenManager))
+	cmd.AddCommand(newUnlockCmd(tokenManager))
+	cmd.AddCommand(newLockCmd(tokenManager))
 
 	return cmd
 }
@@ -51,8 +55,9 @@ Example:
 			if err := validateAuthFlags(serverURL, email, token); err != nil {
 				return err
 			}
+			cmdutil.SetAuditTarget(cmd, serverURL)
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Validate credentials against the Atlassian API before storing
 			userInfo, err := tokenManager.Validate(ctx, serverURL, email, token)
@@ -106,8 +111,9 @@ func newLogoutCmd(tokenManager auth.TokenManager) *cobra.Command {
 			if serverURL == "" {
 				return fmt.Errorf("server URL is required")
 			}
+			cmdutil.SetAuditTarget(cmd, serverURL)
 
-			if err := tokenManager.Delete(context.Background(), serverURL); err != nil {
+			if err := tokenManager.Delete(cmd.Context(), serverURL); err != nil {
 				return fmt.Errorf("failed to delete credentials: %w", err)
 			}
 
@@ -135,7 +141,7 @@ func newStatusCmd(tokenManager auth.TokenManager) *cobra.Command {
 				return fmt.Errorf("server URL is required")
 			}
 
-			creds, err := tokenManager.Get(context.Background(), serverURL)
+			creds, err := tokenManager.Get(cmd.Context(), serverURL)
 			if err != nil {
 				fmt.Fprintf(cmd.OutOrStdout(), "Not authenticated for %s\n", serverURL)
 				return nil
@@ -165,7 +171,7 @@ func newValidateCmd(tokenManager auth.TokenManager) *cobra.Command {
 				return fmt.Errorf("server URL is required")
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 
 			// Retrieve stored credentials
 			creds, err := tokenManager.Get(ctx, serverURL)
@@ -198,6 +204,182 @@ func newValidateCmd(tokenManager auth.TokenManager) *cobra.Command {
 	return cmd
 }
 
+// newPassphraseCmd creates the passphrase command with subcommands
+func newPassphraseCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "passphrase",
+		Short: "Manage passphrase protection of the credentials file",
+		Long: `Protect the encrypted credentials file with a passphrase.
+
+By default the credentials file is encrypted with a key derived from machine data,
+so anyone with access to the file on the same host can decrypt it. In passphrase
+mode the file key is wrapped with a key derived from your passphrase using scrypt.
+
+Passphrase mode applies to encrypted file storage only. Set
+ATLASSIAN_CREDENTIAL_STORE=file to use it on systems with an OS keychain.`,
+	}
+
+	cmd.AddCommand(cmdutil.MarkAudited(newPassphraseSetCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newPassphraseRemoveCmd(tokenManager)))
+
+	return cmd
+}
+
+// newPassphraseSetCmd creates the passphrase set command
+func newPassphraseSetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set or change the credentials passphrase",
+		Long: `Set a passphrase on the credentials file, or change the existing one.
+
+Changing the passphrase re-wraps the file key; stored credentials are not re-entered.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, err := passphraseManager(tokenManager)
+			if err != nil {
+				return err
+			}
+
+			enabled, err := pm.PassphraseEnabled()
+			if err != nil {
+				return err
+			}
+
+			var current []byte
+			if enabled {
+				if current, err = auth.TerminalPassphrase("Current passphrase: "); err != nil {
+					return err
+				}
+			}
+
+			passphrase, err := auth.ReadNewPassphrase()
+			if err != nil {
+				return err
+			}
+
+			if err := pm.ChangePassphrase(current, passphrase); err != nil {
+				return fmt.Errorf("failed to set passphrase: %w", err)
+			}
+
+			if enabled {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Passphrase changed\n")
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Credentials file is now passphrase protected\n")
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newPassphraseRemoveCmd creates the passphrase remove command
+func newPassphraseRemoveCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove passphrase protection",
+		Long:  `Revert the credentials file to machine-key encryption`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, err := passphraseManager(tokenManager)
+			if err != nil {
+				return err
+			}
+
+			enabled, err := pm.PassphraseEnabled()
+			if err != nil {
+				return err
+			}
+			if !enabled {
+				fmt.Fprintf(cmd.OutOrStdout(), "Credentials file is not passphrase protected\n")
+				return nil
+			}
+
+			current, err := auth.TerminalPassphrase("Current passphrase: ")
+			if err != nil {
+				return err
+			}
+
+			if err := pm.ChangePassphrase(current, nil); err != nil {
+				return fmt.Errorf("failed to remove passphrase: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Passphrase protection removed\n")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// newUnlockCmd creates the unlock command
+func newUnlockCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Unlock the credentials file for a period of time",
+		Long: `Unlock a passphrase-protected credentials file so subsequent commands
+do not prompt for the passphrase until the unlock period expires.
+
+Example:
+  atlassian-cli auth unlock --timeout 1h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, err := passphraseManager(tokenManager)
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := auth.TerminalPassphrase("Credentials passphrase: ")
+			if err != nil {
+				return err
+			}
+
+			if err := pm.Unlock(passphrase, timeout); err != nil {
+				return fmt.Errorf("failed to unlock credentials: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Credentials unlocked for %s\n", timeout)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", auth.DefaultUnlockTimeout, "How long the credentials stay unlocked")
+
+	return cmd
+}
+
+// newLockCmd creates the lock command
+func newLockCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Lock the credentials file",
+		Long:  `Discard any cached unlock so the passphrase is required on the next command`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, err := passphraseManager(tokenManager)
+			if err != nil {
+				return err
+			}
+
+			if err := pm.Lock(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Credentials locked\n")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// passphraseManager returns the token manager's passphrase support, if any
+func passphraseManager(tokenManager auth.TokenManager) (auth.PassphraseManager, error) {
+	pm, ok := tokenManager.(auth.PassphraseManager)
+	if !ok {
+		return nil, fmt.Errorf("passphrase protection is only available with encrypted file storage (set ATLASSIAN_CREDENTIAL_STORE=file)")
+	}
+	return pm, nil
+}
+
 // validateAuthFlags validates authentication flags
 func validateAuthFlags(serverURL, email, token string) error {
 	if serverURL == "" {
@@ -2,9 +2,12 @@ package auth
 
 import (
 	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/types"
 	"bytes"
 	"context"
+	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +33,16 @@ func TestNewAuthCmd(t *testing.T) {
 	assert.Contains(t, commandNames, "status")
 }
 
+func TestCredentialChangesAreAudited(t *testing.T) {
+	cmd := NewAuthCmd(auth.NewMemoryTokenManager())
+
+	for _, path := range [][]string{{"login"}, {"logout"}, {"passphrase", "set"}, {"passphrase", "remove"}} {
+		sub, _, err := cmd.Find(path)
+		require.NoError(t, err)
+		assert.True(t, cmdutil.IsAudited(sub), "%v is audited", path)
+	}
+}
+
 func TestAuthStatusCommand(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -169,3 +182,53 @@ func TestValidateAuthFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestPassphraseCommandsRequireEncryptedFile(t *testing.T) {
+	for _, args := range [][]string{{"lock"}, {"unlock"}, {"passphrase", "set"}} {
+		cmd := NewAuthCmd(auth.NewMemoryTokenManager())
+		cmd.SetArgs(args)
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "encrypted file storage")
+	}
+}
+
+func TestPassphraseLifecycle(t *testing.T) {
+	t.Setenv(auth.PassphraseEnvVar, "test-passphrase")
+
+	manager, err := auth.NewEncryptedFileTokenManager(filepath.Join(t.TempDir(), "credentials.enc"))
+	require.NoError(t, err)
+
+	run := func(args ...string) string {
+		cmd := NewAuthCmd(manager)
+		cmd.SetArgs(args)
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	// A new passphrase is always typed, never taken from the environment
+	cmd := NewAuthCmd(manager)
+	cmd.SetArgs([]string{"passphrase", "set"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.ErrorIs(t, cmd.Execute(), auth.ErrNoTerminal)
+
+	require.NoError(t, manager.ChangePassphrase(nil, []byte("test-passphrase")))
+	enabled, err := manager.PassphraseEnabled()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	assert.Contains(t, run("unlock", "--timeout", "1m"), "unlocked for 1m0s")
+	assert.Contains(t, run("lock"), "Credentials locked")
+	assert.Contains(t, run("passphrase", "remove"), "protection removed")
+}
@@ -0,0 +1,355 @@
+package blog
+
+import (
+	"atlassian-cli/cmd/page"
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// blogColumns are the columns available to the blog list table
+var blogColumns = output.ColumnSet[types.Page]{
+	Columns: []output.ColumnSpec[types.Page]{
+		{Name: "id", Header: "ID", Value: func(p types.Page) interface{} { return p.ID }},
+		{Name: "title", Header: "Title", MaxWidth: 50, Value: func(p types.Page) interface{} { return p.Title }},
+		{Name: "space", Header: "Space", Value: func(p types.Page) interface{} { return p.SpaceKey }},
+		{Name: "published", Header: "Published", Value: func(p types.Page) interface{} { return page.PublishDate(p) }},
+		{Name: "version", Header: "Version", Value: func(p types.Page) interface{} { return p.Version }},
+		{Name: "updated", Header: "Updated", Wide: true, Kind: output.KindTime, Value: func(p types.Page) interface{} { return p.Updated }},
+	},
+}
+
+// NewBlogCmd creates the blog command with subcommands. Blog posts are pages
+// of type blogpost, so the commands share the page commands' flags and output.
+func NewBlogCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "blog",
+		Aliases: []string{"blogs"},
+		Short:   "Confluence blog post operations",
+		Long:    `Publish, read, update and list Confluence blog posts`,
+	}
+
+	cmd.AddCommand(cmdutil.MarkAudited(newBlogCreateCmd(tokenManager)))
+	cmd.AddCommand(newBlogGetCmd(tokenManager))
+	cmd.AddCommand(newBlogListCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newBlogUpdateCmd(tokenManager)))
+
+	return cmd
+}
+
+func newBlogCreateCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey    string
+		title       string
+		content     page.ContentFlags
+		publishDate string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Publish a blog post",
+		Long: `Publish a blog post in a space. The post is dated today unless --publish-date
+gives another day, for example to backdate an announcement.
+
+Examples:
+  # Publish release notes written in Markdown
+  atlassian-cli blog create --space ENG --title "Release 4.2" --content-file release-4.2.md
+
+  # Date the post to the day of the release
+  atlassian-cli blog create --space ENG --title "Release 4.1" --content-file release-4.1.md --publish-date 2026-10-05`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := content.Resolve(cmd)
+			if err != nil {
+				return err
+			}
+			req := &types.CreateBlogPostRequest{Title: title}
+			if body != nil {
+				req.Content = *body
+			}
+			if publishDate != "" {
+				date, err := time.ParseInLocation("2006-01-02", publishDate, time.Local)
+				if err != nil {
+					return clierr.New(clierr.KindValidation, "invalid --publish-date %q: use YYYY-MM-DD", publishDate)
+				}
+				req.PublishDate = &date
+			}
+
+			req.SpaceKey, err = config.ResolveSpace(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			post, err := client.CreateBlogPost(cmd.Context(), req)
+			if err != nil {
+				return fmt.Errorf("failed to create blog post: %w", err)
+			}
+			cmdutil.SetAuditTarget(cmd, post.ID)
+
+			return page.OutputPage(cmd, post)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+	cmd.Flags().StringVar(&title, "title", "", "Blog post title (required)")
+	page.AddContentFlags(cmd, &content, "Blog post content in storage format, or Markdown with --format markdown")
+	cmd.Flags().StringVar(&publishDate, "publish-date", "", "Day to publish the post under, as YYYY-MM-DD (default today)")
+
+	cmd.MarkFlagRequired("title")
+
+	return cmd
+}
+
+func newBlogGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "get <blog-post-id>",
+		Short: "Get a blog post by ID",
+		Long: `Retrieve a blog post by its ID. --format markdown prints the body as Markdown,
+as for page get.
+
+Examples:
+  atlassian-cli blog get 123456
+  atlassian-cli blog get 123456 --format markdown > post.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != page.FormatStorage && format != page.FormatMarkdown {
+				return clierr.New(clierr.KindValidation, "invalid --format %q: must be storage or markdown", format)
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			post, err := getBlogPost(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+			return page.OutputPageBody(cmd, post, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", page.FormatStorage, "Blog post body format: storage or markdown")
+
+	return cmd
+}
+
+func newBlogListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey   string
+		title      string
+		since      string
+		until      string
+		maxResults int
+		cursor     string
+		all        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List blog posts",
+		Long: `List blog posts, newest first. --since and --until take a date (YYYY-MM-DD) or a
+duration before now (168h).
+
+Examples:
+  # Blog posts in the default space
+  atlassian-cli blog list
+
+  # Last week's posts
+  atlassian-cli blog list --space ENG --since 168h
+
+  # Posts published in September
+  atlassian-cli blog list --space ENG --since 2026-09-01 --until 2026-10-01`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := &types.BlogPostListOptions{
+				Title:      title,
+				MaxResults: maxResults,
+				Cursor:     cursor,
+			}
+			var err error
+			if opts.Since, err = parseBlogDate("since", since); err != nil {
+				return err
+			}
+			if opts.Until, err = parseBlogDate("until", until); err != nil {
+				return err
+			}
+
+			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if spaceKey != "" || cfg.DefaultConfluenceSpace != "" {
+				opts.SpaceKey, err = config.ResolveSpace(cmd)
+				if err != nil {
+					return err
+				}
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			// List posts, following the next cursor with --all
+			var response *types.PageListResponse
+			offset := 0
+			posts, streamed, err := cmdutil.FetchPages(cmd, 0, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Page], error) {
+				if response != nil {
+					opts.Cursor = strconv.Itoa(start)
+				}
+				page, err := client.ListBlogPosts(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Page]{}, fmt.Errorf("failed to list blog posts: %w", err)
+				}
+				if response == nil {
+					offset = page.StartAt
+				}
+				response = page
+				return cmdutil.CursorPage(page.Pages, page.NextCursor), nil
+			})
+			if err != nil || streamed {
+				return err
+			}
+
+			response.Pages = posts
+			response.StartAt = offset
+			return outputBlogList(cmd, response)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+	cmd.Flags().StringVar(&title, "title", "", "Filter by title")
+	cmd.Flags().StringVar(&since, "since", "", "Only posts published on or after this date")
+	cmd.Flags().StringVar(&until, "until", "", "Only posts published before this date")
+	cmd.Flags().IntVar(&maxResults, "max-results", 25, "Maximum number of results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for pagination")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, blogColumns.Names())
+
+	return cmd
+}
+
+func newBlogUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		title         string
+		content       page.ContentFlags
+		message       string
+		expectVersion int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <blog-post-id>",
+		Short: "Update a blog post",
+		Long: `Change the title or content of a blog post. The publish date is kept.
+
+Examples:
+  atlassian-cli blog update 123456 --content-file release-4.2.md --message "Add upgrade notes"
+
+  # Update only if the post is still at version 3
+  atlassian-cli blog update 123456 --title "Release 4.2.1" --expect-version 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if expectVersion < 0 {
+				return clierr.New(clierr.KindValidation, "--expect-version must not be negative")
+			}
+			body, err := content.Resolve(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			if _, err := getBlogPost(cmd.Context(), client, args[0]); err != nil {
+				return err
+			}
+
+			req := &types.UpdatePageRequest{Content: body, Message: message, ExpectedVersion: expectVersion}
+			if title != "" {
+				req.Title = &title
+			}
+			post, err := client.UpdatePage(cmd.Context(), args[0], req)
+			if err != nil {
+				return fmt.Errorf("failed to update blog post: %w", err)
+			}
+
+			return page.OutputPage(cmd, post)
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "New blog post title")
+	page.AddContentFlags(cmd, &content, "New blog post content in storage format, or Markdown with --format markdown")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Version message shown in the post's history")
+	cmd.Flags().IntVar(&expectVersion, "expect-version", 0, "Fail with a conflict unless the post is still at this version")
+
+	return cmd
+}
+
+// getBlogPost gets a blog post, refusing content of other types
+func getBlogPost(ctx context.Context, client confluence.ConfluenceClient, id string) (*types.Page, error) {
+	post, err := client.GetPage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog post: %w", err)
+	}
+	if post.Type != confluence.ContentBlogPost {
+		return nil, clierr.New(clierr.KindValidation, "%s is a %s, not a blog post; use the page commands", id, post.Type)
+	}
+	return post, nil
+}
+
+// parseBlogDate parses the value of a --since or --until flag: a date or a
+// duration before now
+func parseBlogDate(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, clierr.New(clierr.KindValidation, "invalid --%s %q: use a date (YYYY-MM-DD) or a duration (168h)", flag, value)
+}
+
+// outputBlogList writes a page of blog posts
+func outputBlogList(cmd *cobra.Command, response *types.PageListResponse) error {
+	table, err := cmdutil.BuildTable(cmd, blogColumns, response.Pages)
+	if err != nil {
+		return err
+	}
+	table.Empty = "No blog posts found"
+
+	if len(response.Pages) > 0 {
+		table.Footer = fmt.Sprintf("Showing %d-%d of %d blog posts",
+			response.StartAt+1,
+			response.StartAt+len(response.Pages),
+			response.Total)
+
+		if response.NextCursor != "" {
+			table.Footer += fmt.Sprintf("\n\nNext cursor: %s\nUse --cursor \"%s\" to fetch the next page",
+				response.NextCursor, response.NextCursor)
+		}
+	}
+
+	return cmdutil.WriteList(cmd, response, response.Pages, table)
+}
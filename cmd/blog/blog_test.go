@@ -0,0 +1,91 @@
+package blog
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlogTestCmd creates a command writing format output to a buffer
+func newBlogTestCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	v := viper.New()
+	v.Set("output", format)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), cmdutil.ViperKey, v))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+// blogClient serves pages and blog posts by ID
+type blogClient struct {
+	confluence.ConfluenceClient
+	pages map[string]types.Page
+}
+
+func (c *blogClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page, ok := c.pages[id]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "page %s not found", id)
+	}
+	return &page, nil
+}
+
+func TestGetBlogPost_RefusesPages(t *testing.T) {
+	client := &blogClient{pages: map[string]types.Page{
+		"1": {ID: "1", Title: "Release 4.2", Type: "blogpost"},
+		"2": {ID: "2", Title: "Runbooks", Type: "page"},
+	}}
+
+	post, err := getBlogPost(context.Background(), client, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Release 4.2", post.Title)
+
+	_, err = getBlogPost(context.Background(), client, "2")
+	require.Error(t, err)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+
+	_, err = getBlogPost(context.Background(), client, "3")
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+}
+
+func TestParseBlogDate(t *testing.T) {
+	date, err := parseBlogDate("since", "2026-09-01")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), date)
+
+	date, err = parseBlogDate("since", "168h")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-168*time.Hour), date, time.Minute)
+
+	date, err = parseBlogDate("until", "")
+	require.NoError(t, err)
+	assert.True(t, date.IsZero())
+
+	_, err = parseBlogDate("until", "next week")
+	assert.EqualError(t, err, `invalid --until "next week": use a date (YYYY-MM-DD) or a duration (168h)`)
+}
+
+func TestOutputBlogList(t *testing.T) {
+	published := time.Date(2026, 10, 5, 9, 0, 0, 0, time.Local)
+	cmd, out := newBlogTestCmd("table")
+
+	err := outputBlogList(cmd, &types.PageListResponse{
+		Pages: []types.Page{{ID: "1", Title: "Release 4.1", SpaceKey: "ENG", Version: 2, Published: &published}},
+		Total: 1,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "2026-10-05")
+	assert.Contains(t, out.String(), "Showing 1-1 of 1 blog posts")
+}
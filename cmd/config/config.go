@@ -2,8 +2,10 @@ package config
 
 import (
 	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/output"
 	"atlassian-cli/internal/types"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -35,7 +37,7 @@ func newSetCmd() *cobra.Command {
 Available keys:
   default_jira_project      - Default JIRA project key
   default_confluence_space  - Default Confluence space key
-  output                    - Default output format (json, table, yaml)
+  output                    - Default output format (table, json, yaml, csv, tsv, markdown, ndjson)
   timeout                   - Request timeout (e.g., 30s, 1m)
 
 Examples:
@@ -69,8 +71,8 @@ Examples:
 			case "default_confluence_space":
 				cfg.DefaultConfluenceSpace = value
 			case "output":
-				if value != "json" && value != "table" && value != "yaml" {
-					return fmt.Errorf("invalid output format: %s (must be json, table, or yaml)", value)
+				if !output.IsValidFormat(value) {
+					return fmt.Errorf("invalid output format: %s (must be one of %s)", value, strings.Join(output.Formats, ", "))
 				}
 				cfg.Output = value
 			case "api_endpoint":
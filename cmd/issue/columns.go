@@ -0,0 +1,62 @@
+package issue
+
+import (
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"strings"
+)
+
+// issueColumns are the columns available to issue list and search tables
+var issueColumns = output.ColumnSet[types.Issue]{
+	Columns: []output.ColumnSpec[types.Issue]{
+		{Name: "key", Header: "Key", Value: func(i types.Issue) interface{} { return i.Key }},
+		{Name: "summary", Header: "Summary", MaxWidth: 50, Value: func(i types.Issue) interface{} { return i.Summary }},
+		{Name: "status", Header: "Status", Value: func(i types.Issue) interface{} { return i.Status }, Style: statusStyle},
+		{Name: "type", Header: "Type", Value: func(i types.Issue) interface{} { return i.IssueType }},
+		{Name: "assignee", Header: "Assignee", MaxWidth: 20, Value: func(i types.Issue) interface{} { return i.Assignee }},
+		{Name: "priority", Header: "Priority", Wide: true, Kind: output.KindPriority, Value: func(i types.Issue) interface{} { return i.Priority }},
+		{Name: "reporter", Header: "Reporter", MaxWidth: 20, Wide: true, Value: func(i types.Issue) interface{} { return i.Reporter }},
+		{Name: "project", Header: "Project", Wide: true, Value: func(i types.Issue) interface{} { return i.Project }},
+		{Name: "labels", Header: "Labels", MaxWidth: 30, Wide: true, Value: func(i types.Issue) interface{} { return i.Labels }},
+		{Name: "components", Header: "Components", MaxWidth: 30, Wide: true, Value: func(i types.Issue) interface{} { return i.Components }},
+		{Name: "created", Header: "Created", Wide: true, Kind: output.KindTime, Value: func(i types.Issue) interface{} { return i.Created }},
+		{Name: "updated", Header: "Updated", Wide: true, Kind: output.KindTime, Value: func(i types.Issue) interface{} { return i.Updated }},
+		{Name: "id", Header: "ID", Wide: true, Value: func(i types.Issue) interface{} { return i.ID }},
+	},
+	// Any customfield_NNNNN ID can be selected as a column
+	Dynamic: func(name string) (output.ColumnSpec[types.Issue], bool) {
+		if !strings.HasPrefix(name, "customfield_") {
+			return output.ColumnSpec[types.Issue]{}, false
+		}
+		return output.ColumnSpec[types.Issue]{
+			Name:     name,
+			Header:   name,
+			MaxWidth: 30,
+			Value:    func(i types.Issue) interface{} { return i.CustomFields[name] },
+		}, true
+	},
+}
+
+// statusStyle colors an issue's status by its JIRA status category, guessing the
+// category from common status names when the API did not return one
+func statusStyle(issue types.Issue) string {
+	category := issue.StatusCategory
+	if category == "" {
+		switch strings.ToLower(issue.Status) {
+		case "to do", "open", "backlog", "new", "reopened":
+			category = "new"
+		case "done", "closed", "resolved", "complete", "completed":
+			category = "done"
+		default:
+			category = "indeterminate"
+		}
+	}
+
+	switch category {
+	case "new":
+		return "blue"
+	case "done":
+		return "green"
+	}
+	return "yellow"
+}
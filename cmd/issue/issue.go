@@ -4,11 +4,10 @@ import (
 	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/auth"
 	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/output"
 	"atlassian-cli/internal/types"
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -22,10 +21,10 @@ func NewIssueCmd(tokenManager auth.TokenManager) *cobra.Command {
 	}
 
 	// Add subcommands
-	cmd.AddCommand(newCreateCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newCreateCmd(tokenManager)))
 	cmd.AddCommand(newGetCmd(tokenManager))
 	cmd.AddCommand(newListCmd(tokenManager))
-	cmd.AddCommand(newUpdateCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newUpdateCmd(tokenManager)))
 	cmd.AddCommand(newSearchCmd(tokenManager))
 
 	return cmd
@@ -69,14 +68,14 @@ Examples:
 			}
 
 			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			// Get JIRA client from factory
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
@@ -94,10 +93,11 @@ Examples:
 			}
 
 			// Create the issue
-			issue, err := client.CreateIssue(context.Background(), req)
+			issue, err := client.CreateIssue(cmd.Context(), req)
 			if err != nil {
 				return fmt.Errorf("failed to create issue: %w", err)
 			}
+			cmdutil.SetAuditTarget(cmd, issue.Key)
 
 			// Output result
 			return outputIssue(cmd, issue)
@@ -135,20 +135,20 @@ func newGetCmd(tokenManager auth.TokenManager) *cobra.Command {
 			}
 
 			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			// Get JIRA client from factory
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
 
 			// Get the issue
-			issue, err := client.GetIssue(context.Background(), issueKey)
+			issue, err := client.GetIssue(cmd.Context(), issueKey)
 			if err != nil {
 				return fmt.Errorf("failed to get issue: %w", err)
 			}
@@ -171,6 +171,7 @@ func newListCmd(tokenManager auth.TokenManager) *cobra.Command {
 		maxResults int
 		startAt    int
 		jql        string
+		all        bool
 	)
 
 	cmd := &cobra.Command{
@@ -204,14 +205,14 @@ Examples:
 			}
 
 			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			// Get JIRA client from factory
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
@@ -227,13 +228,24 @@ Examples:
 				JQL:        jql,
 			}
 
-			// List issues
-			response, err := client.ListIssues(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to list issues: %w", err)
+			// List issues, following pagination with --all
+			var response *types.IssueListResponse
+			issues, streamed, err := cmdutil.FetchPages(cmd, startAt, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Issue], error) {
+				opts.StartAt = start
+				page, err := client.ListIssues(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Issue]{}, fmt.Errorf("failed to list issues: %w", err)
+				}
+				response = page
+				return cmdutil.OffsetPage(page.Issues, page.StartAt, page.Total), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
 			// Output result
+			response.Issues = issues
+			response.StartAt = startAt
 			return outputIssueList(cmd, response)
 		},
 	}
@@ -245,6 +257,8 @@ Examples:
 	cmd.Flags().IntVar(&maxResults, "max-results", 50, "Maximum number of results")
 	cmd.Flags().IntVar(&startAt, "start-at", 0, "Starting index for pagination")
 	cmd.Flags().StringVar(&jql, "jql", "", "Custom JQL query")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, issueColumns.Names())
 
 	return cmd
 }
@@ -275,14 +289,14 @@ func newUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
 			}
 
 			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			// Get JIRA client from factory
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
@@ -310,7 +324,7 @@ func newUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
 			}
 
 			// Update the issue
-			issue, err := client.UpdateIssue(context.Background(), issueKey, req)
+			issue, err := client.UpdateIssue(cmd.Context(), issueKey, req)
 			if err != nil {
 				return fmt.Errorf("failed to update issue: %w", err)
 			}
@@ -332,78 +346,41 @@ func newUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
 
 // outputIssue outputs a single issue in the configured format
 func outputIssue(cmd *cobra.Command, issue *types.Issue) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(issue)
-	case "yaml":
-		// TODO: Implement YAML output
-		return fmt.Errorf("YAML output not yet implemented")
-	default: // table
-		fmt.Fprintf(cmd.OutOrStdout(), "Key:         %s\n", issue.Key)
-		fmt.Fprintf(cmd.OutOrStdout(), "Summary:     %s\n", issue.Summary)
-		fmt.Fprintf(cmd.OutOrStdout(), "Status:      %s\n", issue.Status)
-		fmt.Fprintf(cmd.OutOrStdout(), "Type:        %s\n", issue.IssueType)
-		fmt.Fprintf(cmd.OutOrStdout(), "Priority:    %s\n", issue.Priority)
-		fmt.Fprintf(cmd.OutOrStdout(), "Assignee:    %s\n", issue.Assignee)
-		fmt.Fprintf(cmd.OutOrStdout(), "Reporter:    %s\n", issue.Reporter)
-		fmt.Fprintf(cmd.OutOrStdout(), "Project:     %s\n", issue.Project)
-		if len(issue.Labels) > 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "Labels:      %s\n", strings.Join(issue.Labels, ", "))
-		}
-		if len(issue.Components) > 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "Components:  %s\n", strings.Join(issue.Components, ", "))
-		}
-		fmt.Fprintf(cmd.OutOrStdout(), "Created:     %s\n", issue.Created.Format("2006-01-02 15:04:05"))
-		fmt.Fprintf(cmd.OutOrStdout(), "Updated:     %s\n", issue.Updated.Format("2006-01-02 15:04:05"))
+	record := output.NewRecord().
+		Field("Key", issue.Key).
+		Field("Summary", issue.Summary).
+		Field("Status", issue.Status).WithStyle(statusStyle(*issue)).
+		Field("Type", issue.IssueType).
+		Field("Priority", issue.Priority).WithKind(output.KindPriority).
+		Field("Assignee", issue.Assignee).
+		Field("Reporter", issue.Reporter).
+		Field("Project", issue.Project)
+	if len(issue.Labels) > 0 {
+		record.Field("Labels", output.FormatValue(issue.Labels))
 	}
+	if len(issue.Components) > 0 {
+		record.Field("Components", output.FormatValue(issue.Components))
+	}
+	record.Field("Created", output.FormatValue(issue.Created)).WithKind(output.KindTime).
+		Field("Updated", output.FormatValue(issue.Updated)).WithKind(output.KindTime)
 
-	return nil
+	return cmdutil.WriteOutput(cmd, issue, record)
 }
 
 // outputIssueList outputs a list of issues in the configured format
 func outputIssueList(cmd *cobra.Command, response *types.IssueListResponse) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(response)
-	case "yaml":
-		// TODO: Implement YAML output
-		return fmt.Errorf("YAML output not yet implemented")
-	default: // table
-		if len(response.Issues) == 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "No issues found\n")
-			return nil
-		}
-
-		// Print header
-		fmt.Fprintf(cmd.OutOrStdout(), "%-12s %-50s %-15s %-10s %-15s\n",
-			"KEY", "SUMMARY", "STATUS", "TYPE", "ASSIGNEE")
-		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 102))
-
-		// Print issues
-		for _, issue := range response.Issues {
-			summary := issue.Summary
-			if len(summary) > 47 {
-				summary = summary[:47] + "..."
-			}
-
-			fmt.Fprintf(cmd.OutOrStdout(), "%-12s %-50s %-15s %-10s %-15s\n",
-				issue.Key, summary, issue.Status, issue.IssueType, issue.Assignee)
-		}
+	table, err := cmdutil.BuildTable(cmd, issueColumns, response.Issues)
+	if err != nil {
+		return err
+	}
+	table.Empty = "No issues found"
 
-		// Print summary
-		fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d-%d of %d issues\n",
+	if len(response.Issues) > 0 {
+		table.Footer = fmt.Sprintf("Showing %d-%d of %d issues",
 			response.StartAt+1,
 			response.StartAt+len(response.Issues),
 			response.Total)
 	}
 
-	return nil
+	return cmdutil.WriteList(cmd, response, response.Issues, table)
 }
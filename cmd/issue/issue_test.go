@@ -2,11 +2,15 @@ package issue
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/types"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -198,3 +202,108 @@ func findCommand(parent *cobra.Command, name string) *cobra.Command {
 	}
 	return nil
 }
+
+func newOutputTestCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	v := viper.New()
+	v.Set("output", format)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), cmdutil.ViperKey, v))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestOutputIssueListFormats(t *testing.T) {
+	response := &types.IssueListResponse{
+		Issues: []types.Issue{
+			{Key: "DEMO-1", Summary: "Fix login, again", Status: "To Do", IssueType: "Bug", Assignee: "Ada"},
+		},
+		Total: 1,
+	}
+
+	tests := []struct {
+		format   string
+		contains []string
+	}{
+		{"table", []string{"KEY", "DEMO-1", "Showing 1-1 of 1 issues"}},
+		{"csv", []string{"Key,Summary,Status,Type,Assignee\n", "DEMO-1,\"Fix login, again\",To Do,Bug,Ada\n"}},
+		{"tsv", []string{"Key\tSummary\tStatus\tType\tAssignee\n"}},
+		{"markdown", []string{"| Key | Summary | Status | Type | Assignee |\n", "| DEMO-1 | Fix login, again | To Do | Bug | Ada |\n"}},
+		{"yaml", []string{"issues:\n", "  - id: \"\"\n", "    key: DEMO-1\n", "    issueType: Bug\n", "total: 1\n"}},
+		{"json", []string{"\"issueType\": \"Bug\""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cmd, out := newOutputTestCmd(tt.format)
+			assert.NoError(t, outputIssueList(cmd, response))
+			for _, want := range tt.contains {
+				assert.Contains(t, out.String(), want)
+			}
+			if tt.format != "table" {
+				assert.NotContains(t, out.String(), "Showing")
+			}
+		})
+	}
+}
+
+func TestOutputIssueListTemplateAndQuery(t *testing.T) {
+	response := &types.IssueListResponse{
+		Issues: []types.Issue{{Key: "DEMO-1", Summary: "First"}, {Key: "DEMO-2", Summary: "Second"}},
+		Total:  2,
+	}
+
+	cmd, out := newOutputTestCmd("table")
+	cmdutil.GetViperFromCmd(cmd).Set("template", "{{.Key}} {{.Summary}}")
+	assert.NoError(t, outputIssueList(cmd, response))
+	assert.Equal(t, "DEMO-1 First\nDEMO-2 Second\n", out.String())
+
+	cmd, out = newOutputTestCmd("table")
+	cmdutil.GetViperFromCmd(cmd).Set("query", ".issues[].key")
+	assert.NoError(t, outputIssueList(cmd, response))
+	assert.Equal(t, "DEMO-1\nDEMO-2\n", out.String())
+}
+
+func TestOutputIssueListColumnsAndSort(t *testing.T) {
+	response := &types.IssueListResponse{
+		Issues: []types.Issue{
+			{Key: "DEMO-2", Priority: "Low", CustomFields: map[string]interface{}{"customfield_100": "Beta"}},
+			{Key: "DEMO-1", Priority: "High", CustomFields: map[string]interface{}{"customfield_100": "Alpha"}},
+		},
+		Total: 2,
+	}
+
+	cmd, out := newOutputTestCmd("csv")
+	cmdutil.AddTableFlags(cmd, issueColumns.Names())
+	cmd.Flags().Set("columns", "key,priority,customfield_100")
+	cmd.Flags().Set("sort-by", "key")
+
+	assert.NoError(t, outputIssueList(cmd, response))
+	assert.Equal(t, "Key,Priority,customfield_100\nDEMO-1,High,Alpha\nDEMO-2,Low,Beta\n", out.String())
+
+	cmd, _ = newOutputTestCmd("table")
+	cmdutil.AddTableFlags(cmd, issueColumns.Names())
+	cmd.Flags().Set("columns", "key,bogus")
+	err := outputIssueList(cmd, response)
+	assert.ErrorContains(t, err, `unknown column "bogus"`)
+}
+
+func TestStatusStyle(t *testing.T) {
+	tests := []struct {
+		issue types.Issue
+		want  string
+	}{
+		{types.Issue{Status: "Backlog", StatusCategory: "new"}, "blue"},
+		{types.Issue{Status: "In Review", StatusCategory: "indeterminate"}, "yellow"},
+		{types.Issue{Status: "Shipped", StatusCategory: "done"}, "green"},
+		// Without a category the status name decides
+		{types.Issue{Status: "To Do"}, "blue"},
+		{types.Issue{Status: "Closed"}, "green"},
+		{types.Issue{Status: "In Progress"}, "yellow"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, statusStyle(tt.issue), tt.issue.Status)
+	}
+}
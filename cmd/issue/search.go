@@ -21,6 +21,7 @@ func newSearchCmd(tokenManager auth.TokenManager) *cobra.Command {
 		status    string
 		issueType string
 		limit     int
+		all       bool
 	)
 
 	cmd := &cobra.Command{
@@ -45,14 +46,14 @@ Examples:
 			}
 
 			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			// Get JIRA client from factory
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
@@ -75,16 +76,25 @@ Examples:
 				StartAt:    0,
 			}
 
-			response, err := client.SearchIssues(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to search issues: %w", err)
+			var response *types.IssueSearchResponse
+			issues, streamed, err := cmdutil.FetchPages(cmd, 0, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Issue], error) {
+				opts.StartAt = start
+				page, err := client.SearchIssues(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Issue]{}, fmt.Errorf("failed to search issues: %w", err)
+				}
+				response = page
+				return cmdutil.OffsetPage(page.Issues, page.StartAt, page.Total), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
 			// Convert SearchResponse to ListResponse for output
 			listResponse := &types.IssueListResponse{
-				Issues:     response.Issues,
+				Issues:     issues,
 				Total:      response.Total,
-				StartAt:    response.StartAt,
+				StartAt:    0,
 				MaxResults: response.MaxResults,
 			}
 
@@ -99,6 +109,8 @@ Examples:
 	cmd.Flags().StringVar(&status, "status", "", "filter by status")
 	cmd.Flags().StringVar(&issueType, "type", "", "filter by issue type")
 	cmd.Flags().IntVar(&limit, "limit", 50, "maximum results to return")
+	cmd.Flags().BoolVar(&all, "all", false, "fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, issueColumns.Names())
 
 	return cmd
 }
@@ -0,0 +1,470 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// attachmentFile is an attachment with the local file it was uploaded from or
+// downloaded to
+type attachmentFile struct {
+	types.Attachment
+	Action string `json:"action"`
+	Path   string `json:"path,omitempty"`
+}
+
+// attachmentOperation reports the attachments a command uploaded, downloaded or
+// removed
+type attachmentOperation struct {
+	Action      string              `json:"action"`
+	DryRun      bool                `json:"dryRun,omitempty"`
+	Attachments []attachmentFile    `json:"attachments"`
+	Result      *cmdutil.BulkResult `json:"result,omitempty"`
+}
+
+// attachmentColumns are the columns available to the attachment list table
+var attachmentColumns = output.ColumnSet[types.Attachment]{
+	Columns: []output.ColumnSpec[types.Attachment]{
+		{Name: "id", Header: "ID", Value: func(a types.Attachment) interface{} { return a.ID }},
+		{Name: "file", Header: "File", MaxWidth: 50, Value: func(a types.Attachment) interface{} { return a.Title }},
+		{Name: "type", Header: "Media Type", MaxWidth: 30, Value: func(a types.Attachment) interface{} { return a.MediaType }},
+		{Name: "size", Header: "Size", Value: func(a types.Attachment) interface{} { return a.FileSize }},
+		{Name: "version", Header: "Version", Value: func(a types.Attachment) interface{} { return a.Version }},
+		{Name: "page", Header: "Page ID", Wide: true, Value: func(a types.Attachment) interface{} { return a.PageID }},
+	},
+}
+
+func newAttachmentCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attachment",
+		Aliases: []string{"attachments"},
+		Short:   "Manage the attachments of Confluence pages",
+	}
+
+	cmd.AddCommand(cmdutil.MarkAudited(newAttachmentAddCmd(tokenManager)))
+	cmd.AddCommand(newAttachmentListCmd(tokenManager))
+	cmd.AddCommand(newAttachmentGetCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newAttachmentRmCmd(tokenManager)))
+
+	return cmd
+}
+
+func newAttachmentAddCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "add <page-id> <file>...",
+		Short: "Attach files to a page",
+		Long: `Upload files as attachments of a page. A file with the same name as an existing
+attachment is added as a new version of it.
+
+Files are streamed, so large files are not read into memory. The media type is
+detected from the file extension, or from the content when the extension is
+unknown. Use - to read a single file from stdin, naming it with --name.
+
+Examples:
+  # Attach a diagram and a log
+  atlassian-cli page attachment add 123456 diagram.png build.log
+
+  # Upload a new version of an attachment under another name
+  atlassian-cli page attachment add 123456 ./out/report-final.pdf --name report.pdf
+
+  # Attach the output of a command
+  kubectl get pods | atlassian-cli page attachment add 123456 - --name pods.txt`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pageID, files := args[0], args[1:]
+			if name != "" && len(files) > 1 {
+				return clierr.New(clierr.KindValidation, "--name can only be used with a single file")
+			}
+			for _, file := range files {
+				if file == "-" && name == "" {
+					return clierr.New(clierr.KindValidation, "--name is required when reading from stdin")
+				}
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, pageID)
+
+			operation := attachmentOperation{Action: "add"}
+			operation.Result = cmdutil.RunBulk(cmd.Context(), files, func(ctx context.Context, file string) error {
+				attachment, err := uploadFile(ctx, cmd, client, pageID, file, name)
+				if err != nil {
+					return err
+				}
+				action := "added"
+				if attachment.Version > 1 {
+					action = "updated"
+				}
+				operation.Attachments = append(operation.Attachments, attachmentFile{Attachment: *attachment, Action: action, Path: file})
+				return nil
+			})
+
+			if err := outputAttachmentOperation(cmd, operation); err != nil {
+				return err
+			}
+			return operation.Result.Err()
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Attachment file name (defaults to the file's base name)")
+
+	return cmd
+}
+
+// uploadFile uploads a local file, or stdin for "-", as an attachment of a page
+func uploadFile(ctx context.Context, cmd *cobra.Command, client confluence.ConfluenceClient, pageID, file, name string) (*types.Attachment, error) {
+	if name == "" {
+		name = filepath.Base(file)
+	}
+	if file == "-" {
+		return client.UploadAttachment(ctx, pageID, name, cmd.InOrStdin())
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "cannot read %s", file)
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.IsDir() {
+		return nil, clierr.New(clierr.KindValidation, "%s is a directory", file)
+	}
+	return client.UploadAttachment(ctx, pageID, name, f)
+}
+
+func newAttachmentListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <page-id>",
+		Short: "List the attachments of a page",
+		Long: `List the current attachments of a page with their media type, size in bytes
+and version.
+
+Examples:
+  # List attachments
+  atlassian-cli page attachment list 123456
+
+  # Largest files first
+  atlassian-cli page attachment list 123456 --sort-by -size`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			attachments, err := client.ListAttachments(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if attachments == nil {
+				attachments = []types.Attachment{}
+			}
+
+			table, err := cmdutil.BuildTable(cmd, attachmentColumns, attachments)
+			if err != nil {
+				return err
+			}
+			table.Empty = "No attachments found"
+			return cmdutil.WriteList(cmd, attachments, attachments, table)
+		},
+	}
+
+	cmdutil.AddTableFlags(cmd, attachmentColumns.Names())
+
+	return cmd
+}
+
+func newAttachmentGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		outDir   string
+		spaceKey string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get [<page-id> [<file-name>...]]",
+		Short: "Download attachments",
+		Long: `Download attachments of a page to --out, which defaults to the current
+directory. Without file names every attachment of the page is downloaded.
+
+With --space, every attachment of every page in the space is downloaded, each
+page's files to a directory named after the page ID.
+
+Use --out - to write a single attachment to stdout. Existing files are
+overwritten.
+
+Examples:
+  # Download one attachment
+  atlassian-cli page attachment get 123456 diagram.png
+
+  # Download all attachments of a page
+  atlassian-cli page attachment get 123456 --out ./files
+
+  # Download all attachments in a space
+  atlassian-cli page attachment get --space OPS --out ./ops-files
+
+  # Pipe an attachment into another command
+  atlassian-cli page attachment get 123456 data.csv --out - | head`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if spaceKey != "" && len(args) > 0 {
+				return clierr.New(clierr.KindValidation, "give either a page ID or --space, not both")
+			}
+			if spaceKey == "" && len(args) == 0 {
+				return clierr.New(clierr.KindValidation, "a page ID or --space is required")
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			var attachments []types.Attachment
+			if spaceKey != "" {
+				attachments, err = spaceAttachments(cmd.Context(), client, spaceKey)
+			} else {
+				attachments, err = pageAttachments(cmd.Context(), client, args[0], args[1:])
+			}
+			if err != nil {
+				return err
+			}
+
+			if outDir == "-" {
+				if len(attachments) != 1 {
+					return clierr.New(clierr.KindValidation, "--out - writes a single attachment, but %d match", len(attachments))
+				}
+				return client.DownloadAttachment(cmd.Context(), &attachments[0], cmd.OutOrStdout())
+			}
+			return downloadAttachments(cmd, client, attachments, outDir, spaceKey != "")
+		},
+	}
+
+	cmd.Flags().StringVar(&outDir, "out", ".", "Directory to download to, or - for stdout")
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Download the attachments of every page in this space")
+
+	return cmd
+}
+
+// pageAttachments returns the named attachments of a page, or all of them when
+// no names are given
+func pageAttachments(ctx context.Context, client confluence.ConfluenceClient, pageID string, names []string) ([]types.Attachment, error) {
+	if len(names) == 0 {
+		return client.ListAttachments(ctx, pageID)
+	}
+
+	attachments := make([]types.Attachment, 0, len(names))
+	for _, name := range names {
+		attachment, err := client.GetAttachment(ctx, pageID, name)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, nil
+}
+
+// spaceAttachments returns the attachments of every page in a space
+func spaceAttachments(ctx context.Context, client confluence.ConfluenceClient, spaceKey string) ([]types.Attachment, error) {
+	roots, err := client.ListRootPages(ctx, spaceKey)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := confluence.FetchTree(ctx, client, roots, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []types.Attachment
+	var listErr error
+	confluence.Walk(tree, func(node *confluence.PageNode, depth int) {
+		if listErr != nil {
+			return
+		}
+		var pageAttachments []types.Attachment
+		pageAttachments, listErr = client.ListAttachments(ctx, node.ID)
+		attachments = append(attachments, pageAttachments...)
+	})
+	if listErr != nil {
+		return nil, listErr
+	}
+	return attachments, nil
+}
+
+// downloadAttachments writes attachments to files in dir, below a directory per
+// page when byPage is set
+func downloadAttachments(cmd *cobra.Command, client confluence.ConfluenceClient, attachments []types.Attachment, dir string, byPage bool) error {
+	files := make([]string, len(attachments))
+	byFile := make(map[string]*types.Attachment, len(attachments))
+	for i := range attachments {
+		attachment := &attachments[i]
+		file := filepath.Join(dir, attachmentFileName(attachment.Title))
+		if byPage {
+			file = filepath.Join(dir, attachment.PageID, attachmentFileName(attachment.Title))
+		}
+		files[i] = file
+		byFile[file] = attachment
+	}
+
+	operation := attachmentOperation{Action: "download"}
+	operation.Result = cmdutil.RunBulk(cmd.Context(), files, func(ctx context.Context, file string) error {
+		attachment := byFile[file]
+		if err := downloadFile(ctx, client, attachment, file); err != nil {
+			return err
+		}
+		operation.Attachments = append(operation.Attachments, attachmentFile{Attachment: *attachment, Action: "downloaded", Path: file})
+		return nil
+	})
+
+	if err := outputAttachmentOperation(cmd, operation); err != nil {
+		return err
+	}
+	return operation.Result.Err()
+}
+
+// downloadFile streams an attachment to a file, removing the partial file if
+// the download fails
+func downloadFile(ctx context.Context, client confluence.ConfluenceClient, attachment *types.Attachment, file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot create directory for %s", file)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+
+	err = client.DownloadAttachment(ctx, attachment, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = clierr.Wrap(clierr.KindGeneral, closeErr, "cannot write %s", file)
+	}
+	if err != nil {
+		os.Remove(file)
+		return err
+	}
+	return nil
+}
+
+// attachmentFileName makes an attachment's name safe to use as a local file name
+func attachmentFileName(name string) string {
+	name = strings.NewReplacer("/", "-", "\\", "-").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+func newAttachmentRmCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <page-id> <file-name>...",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove attachments from a page",
+		Long: `Move attachments of a page to the space trash, from where they can be restored
+in Confluence until the trash is purged.
+
+Examples:
+  # Remove an attachment after confirming
+  atlassian-cli page attachment rm 123456 old-diagram.png
+
+  # Remove several without prompting
+  atlassian-cli page attachment rm 123456 a.log b.log --yes`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			attachments, err := pageAttachments(cmd.Context(), client, args[0], args[1:])
+			if err != nil {
+				return err
+			}
+
+			operation := attachmentOperation{Action: "remove", DryRun: cmdutil.IsDryRun(cmd)}
+			if operation.DryRun {
+				for _, attachment := range attachments {
+					operation.Attachments = append(operation.Attachments, attachmentFile{Attachment: attachment, Action: "remove"})
+				}
+				return outputAttachmentOperation(cmd, operation)
+			}
+
+			if err := cmdutil.Confirm(cmd, fmt.Sprintf("Remove %s from page %s?", describeAttachments(attachments), args[0])); err != nil {
+				return err
+			}
+
+			ids := make([]string, len(attachments))
+			byID := make(map[string]types.Attachment, len(attachments))
+			for i, attachment := range attachments {
+				ids[i] = attachment.ID
+				byID[attachment.ID] = attachment
+			}
+			operation.Result = cmdutil.RunBulk(cmd.Context(), ids, func(ctx context.Context, id string) error {
+				if err := client.DeleteAttachment(ctx, id); err != nil {
+					return err
+				}
+				operation.Attachments = append(operation.Attachments, attachmentFile{Attachment: byID[id], Action: "removed"})
+				return nil
+			})
+
+			if err := outputAttachmentOperation(cmd, operation); err != nil {
+				return err
+			}
+			return operation.Result.Err()
+		},
+	}
+
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// describeAttachments names a single attachment, or counts several
+func describeAttachments(attachments []types.Attachment) string {
+	if len(attachments) == 1 {
+		return fmt.Sprintf("%q", attachments[0].Title)
+	}
+	return fmt.Sprintf("%d attachments", len(attachments))
+}
+
+// outputAttachmentOperation lists the attachments an operation handled, with a
+// summary of any failures
+func outputAttachmentOperation(cmd *cobra.Command, operation attachmentOperation) error {
+	if operation.Attachments == nil {
+		operation.Attachments = []attachmentFile{}
+	}
+
+	table := output.NewTable("Action", "ID", "File", "Version", "Size", "Path")
+	for _, attachment := range operation.Attachments {
+		table.AddRow(attachment.Action, attachment.ID, attachment.Title, attachment.Version, attachment.FileSize, attachment.Path)
+	}
+	table.Empty = "No attachments to " + operation.Action
+	if operation.DryRun && len(operation.Attachments) > 0 {
+		table.Footer = fmt.Sprintf("Dry run: %d %s would be removed", len(operation.Attachments), pluralAttachments(len(operation.Attachments)))
+	}
+	if result := operation.Result; result != nil && (len(result.Failed) > 0 || result.Canceled) {
+		var summary strings.Builder
+		result.PrintSummary(&summary)
+		table.Footer = strings.TrimRight(summary.String(), "\n")
+	}
+
+	return cmdutil.WriteList(cmd, operation, operation.Attachments, table)
+}
+
+// pluralAttachments returns "attachment" or "attachments" to follow a count
+func pluralAttachments(n int) string {
+	if n == 1 {
+		return "attachment"
+	}
+	return "attachments"
+}
@@ -0,0 +1,112 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentClient serves the attachments of pages and their content
+type attachmentClient struct {
+	confluence.ConfluenceClient
+	attachments map[string][]types.Attachment
+	files       map[string]string // Attachment content by download URL
+}
+
+func (c *attachmentClient) ListAttachments(ctx context.Context, pageID string) ([]types.Attachment, error) {
+	return c.attachments[pageID], nil
+}
+
+func (c *attachmentClient) GetAttachment(ctx context.Context, pageID, fileName string) (*types.Attachment, error) {
+	for _, attachment := range c.attachments[pageID] {
+		if attachment.Title == fileName {
+			return &attachment, nil
+		}
+	}
+	return nil, clierr.New(clierr.KindNotFound, "page %s has no attachment named %s", pageID, fileName)
+}
+
+func (c *attachmentClient) DownloadAttachment(ctx context.Context, attachment *types.Attachment, w io.Writer) error {
+	content, ok := c.files[attachment.DownloadURL]
+	if !ok {
+		io.WriteString(w, "partial")
+		return clierr.New(clierr.KindGeneral, "download failed")
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+func newAttachmentClient() *attachmentClient {
+	return &attachmentClient{
+		attachments: map[string][]types.Attachment{
+			"1": {
+				{ID: "a1", Title: "diagram.png", PageID: "1", DownloadURL: "wiki/download/1/diagram.png"},
+				{ID: "a2", Title: "notes/draft.txt", PageID: "1", DownloadURL: "wiki/download/1/notes"},
+			},
+			"2": {{ID: "a3", Title: "diagram.png", PageID: "2", DownloadURL: "wiki/download/2/diagram.png"}},
+		},
+		files: map[string]string{
+			"wiki/download/1/diagram.png": "one",
+			"wiki/download/1/notes":       "draft",
+			"wiki/download/2/diagram.png": "two",
+		},
+	}
+}
+
+func TestPageAttachments(t *testing.T) {
+	client := newAttachmentClient()
+
+	attachments, err := pageAttachments(context.Background(), client, "1", nil)
+	require.NoError(t, err)
+	assert.Len(t, attachments, 2)
+
+	attachments, err = pageAttachments(context.Background(), client, "1", []string{"diagram.png"})
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "a1", attachments[0].ID)
+
+	_, err = pageAttachments(context.Background(), client, "1", []string{"missing.png"})
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+}
+
+func TestDownloadAttachments_ByPage(t *testing.T) {
+	client := newAttachmentClient()
+	dir := t.TempDir()
+	attachments := append(append([]types.Attachment{}, client.attachments["1"]...), client.attachments["2"]...)
+
+	cmd, out := newPageTestCmd("json")
+	require.NoError(t, downloadAttachments(cmd, client, attachments, dir, true))
+
+	for file, content := range map[string]string{"1/diagram.png": "one", "1/notes-draft.txt": "draft", "2/diagram.png": "two"} {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	}
+
+	var operation attachmentOperation
+	require.NoError(t, json.Unmarshal(out.Bytes(), &operation))
+	assert.Len(t, operation.Attachments, 3)
+	assert.Equal(t, 3, operation.Result.Total)
+}
+
+func TestDownloadAttachments_RemovesPartialFile(t *testing.T) {
+	client := newAttachmentClient()
+	delete(client.files, "wiki/download/1/notes")
+	dir := t.TempDir()
+
+	cmd, out := newPageTestCmd("table")
+	err := downloadAttachments(cmd, client, client.attachments["1"], dir, false)
+	assert.EqualError(t, err, "1 of 2 items failed")
+	assert.FileExists(t, filepath.Join(dir, "diagram.png"))
+	assert.NoFileExists(t, filepath.Join(dir, "notes-draft.txt"))
+	assert.Contains(t, out.String(), "Completed 1 of 2, 1 failed")
+}
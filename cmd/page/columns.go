@@ -0,0 +1,22 @@
+package page
+
+import (
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"strings"
+)
+
+// pageColumns are the columns available to page list and search tables
+var pageColumns = output.ColumnSet[types.Page]{
+	Columns: []output.ColumnSpec[types.Page]{
+		{Name: "id", Header: "ID", Value: func(p types.Page) interface{} { return p.ID }},
+		{Name: "title", Header: "Title", MaxWidth: 50, Value: func(p types.Page) interface{} { return p.Title }},
+		{Name: "space", Header: "Space", Value: func(p types.Page) interface{} { return p.SpaceKey }},
+		{Name: "version", Header: "Version", Value: func(p types.Page) interface{} { return p.Version }},
+		{Name: "type", Header: "Type", Wide: true, Value: func(p types.Page) interface{} { return p.Type }},
+		{Name: "updated", Header: "Updated", Wide: true, Kind: output.KindTime, Value: func(p types.Page) interface{} { return p.Updated }},
+		{Name: "parent", Header: "Parent ID", Wide: true, Value: func(p types.Page) interface{} { return p.ParentID }},
+		{Name: "position", Header: "Position", Wide: true, Value: func(p types.Page) interface{} { return p.Position }},
+		{Name: "labels", Header: "Labels", Wide: true, MaxWidth: 30, Value: func(p types.Page) interface{} { return strings.Join(p.Labels, ", ") }},
+	},
+}
@@ -0,0 +1,321 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/markdown"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// commentPreviewWidth caps the comment text shown in list tables
+const commentPreviewWidth = 60
+
+// commentColumns are the columns available to the comment list table
+var commentColumns = output.ColumnSet[types.Comment]{
+	Columns: []output.ColumnSpec[types.Comment]{
+		{Name: "id", Header: "ID", Value: func(c types.Comment) interface{} { return c.ID }},
+		{Name: "location", Header: "Type", Value: func(c types.Comment) interface{} { return c.Location }},
+		{Name: "author", Header: "Author", MaxWidth: 20, Value: func(c types.Comment) interface{} { return c.Author }},
+		{Name: "created", Header: "Created", Kind: output.KindTime, Value: func(c types.Comment) interface{} { return c.Created }},
+		{Name: "status", Header: "Status", Value: func(c types.Comment) interface{} { return c.Resolution }},
+		{Name: "selection", Header: "Selection", MaxWidth: 30, Value: func(c types.Comment) interface{} { return c.Selection }},
+		{Name: "comment", Header: "Comment", MaxWidth: commentPreviewWidth, Value: commentPreview},
+		{Name: "parent", Header: "Reply To", Wide: true, Value: func(c types.Comment) interface{} { return c.ParentID }},
+	},
+}
+
+// commentPreview returns a comment's text on one line, marking replies
+func commentPreview(c types.Comment) interface{} {
+	text := strings.Join(strings.Fields(confluence.StorageText(c.Content)), " ")
+	if c.ParentID != "" {
+		return "↳ " + text
+	}
+	return text
+}
+
+// bodyFlags holds the flags that supply a comment body
+type bodyFlags struct {
+	body   string
+	file   string
+	format string
+}
+
+// addBodyFlags adds --body, --body-file and --format to cmd
+func addBodyFlags(cmd *cobra.Command, flags *bodyFlags) {
+	cmd.Flags().StringVar(&flags.body, "body", "", "Comment text")
+	cmd.Flags().StringVar(&flags.file, "body-file", "", `Read the comment from a file ("-" for stdin)`)
+	cmd.Flags().StringVar(&flags.format, "format", FormatMarkdown, "Comment format: markdown or storage")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
+}
+
+// resolve returns the comment body in storage format, converting Markdown
+func (f *bodyFlags) resolve(cmd *cobra.Command) (string, error) {
+	if f.format != FormatMarkdown && f.format != FormatStorage {
+		return "", clierr.New(clierr.KindValidation, "invalid --format %q: must be markdown or storage", f.format)
+	}
+
+	body := f.body
+	switch {
+	case f.file == "-":
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", clierr.Wrap(clierr.KindGeneral, err, "failed to read comment from stdin")
+		}
+		body = string(data)
+	case f.file != "":
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			return "", clierr.Wrap(clierr.KindValidation, err, "failed to read comment file")
+		}
+		body = string(data)
+	}
+	if strings.TrimSpace(body) == "" {
+		return "", clierr.New(clierr.KindValidation, "a comment is required: use --body or --body-file")
+	}
+
+	if f.format == FormatMarkdown {
+		body = markdown.ToStorage(body)
+	}
+	return body, nil
+}
+
+func newCommentCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "comment",
+		Aliases: []string{"comments"},
+		Short:   "Read and write page comments",
+		Long: `List, add, reply to and resolve the footer and inline comments of a page.
+Comment bodies are written in Markdown unless --format storage is given.`,
+	}
+
+	cmd.AddCommand(newCommentListCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newCommentAddCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newCommentReplyCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newCommentResolveCmd(tokenManager)))
+
+	return cmd
+}
+
+func newCommentListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		location   string
+		unresolved bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list <page-id>",
+		Short: "List the comments on a page",
+		Long: `List the comments on a page, each followed by its replies. Inline comments show
+the page text they are anchored to and whether they are resolved.
+
+Examples:
+  # All comments
+  atlassian-cli page comment list 123456
+
+  # Open review comments
+  atlassian-cli page comment list 123456 --location inline --unresolved
+
+  # Full comment bodies
+  atlassian-cli page comment list 123456 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			comments, err := client.ListComments(cmd.Context(), args[0], location)
+			if err != nil {
+				return err
+			}
+			if unresolved {
+				comments = unresolvedComments(comments)
+			}
+			if comments == nil {
+				comments = []types.Comment{}
+			}
+
+			table, err := cmdutil.BuildTable(cmd, commentColumns, comments)
+			if err != nil {
+				return err
+			}
+			table.Empty = "No comments found"
+			return cmdutil.WriteList(cmd, comments, comments, table)
+		},
+	}
+
+	cmd.Flags().StringVar(&location, "location", "", "Only footer or inline comments")
+	cmd.Flags().BoolVar(&unresolved, "unresolved", false, "Only inline comments that are not resolved, with their replies")
+	cmdutil.AddTableFlags(cmd, commentColumns.Names())
+
+	return cmd
+}
+
+// unresolvedComments keeps the inline comments that are open or reopened, and
+// the replies to them
+func unresolvedComments(comments []types.Comment) []types.Comment {
+	kept := map[string]bool{}
+	var result []types.Comment
+	for _, comment := range comments {
+		open := comment.Location == confluence.CommentInline && comment.ParentID == "" &&
+			comment.Resolution != "resolved" && comment.Resolution != "dangling"
+		if open || kept[comment.ParentID] {
+			kept[comment.ID] = true
+			result = append(result, comment)
+		}
+	}
+	return result
+}
+
+func newCommentAddCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		body       bodyFlags
+		selection  string
+		matchIndex int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <page-id>",
+		Short: "Comment on a page",
+		Long: `Add a comment at the foot of a page, or with --inline an inline comment anchored
+to a passage of the page's text. When the text occurs more than once, choose
+the occurrence with --match-index (counting from 0).
+
+Examples:
+  # Footer comment
+  atlassian-cli page comment add 123456 --body "Looks good, **ship it**"
+
+  # Inline comment on a passage
+  atlassian-cli page comment add 123456 --inline "retry three times" --body "Why three?"
+
+  # Comment from a Markdown file
+  atlassian-cli page comment add 123456 --body-file review.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := body.resolve(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			comment, err := client.AddComment(cmd.Context(), &types.CreateCommentRequest{
+				PageID:         args[0],
+				Content:        content,
+				Selection:      selection,
+				SelectionIndex: matchIndex,
+			})
+			if err != nil {
+				return err
+			}
+			return outputComment(cmd, comment)
+		},
+	}
+
+	addBodyFlags(cmd, &body)
+	cmd.Flags().StringVar(&selection, "inline", "", "Page text to anchor an inline comment to")
+	cmd.Flags().IntVar(&matchIndex, "match-index", 0, "Which occurrence of the --inline text to anchor to, from 0")
+
+	return cmd
+}
+
+func newCommentReplyCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var body bodyFlags
+
+	cmd := &cobra.Command{
+		Use:   "reply <comment-id>",
+		Short: "Reply to a comment",
+		Long: `Reply to a footer or inline comment.
+
+Examples:
+  atlassian-cli page comment reply 987654 --body "Fixed in version 12"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := body.resolve(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			comment, err := client.ReplyComment(cmd.Context(), args[0], content)
+			if err != nil {
+				return err
+			}
+			return outputComment(cmd, comment)
+		},
+	}
+
+	addBodyFlags(cmd, &body)
+
+	return cmd
+}
+
+func newCommentResolveCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var reopen bool
+
+	cmd := &cobra.Command{
+		Use:   "resolve <comment-id>",
+		Short: "Resolve an inline comment",
+		Long: `Mark an inline comment as resolved, or reopen it with --reopen. Footer
+comments cannot be resolved.
+
+Examples:
+  atlassian-cli page comment resolve 987654
+  atlassian-cli page comment resolve 987654 --reopen`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			comment, err := client.ResolveComment(cmd.Context(), args[0], !reopen)
+			if err != nil {
+				return err
+			}
+			return outputComment(cmd, comment)
+		},
+	}
+
+	cmd.Flags().BoolVar(&reopen, "reopen", false, "Reopen a resolved comment")
+
+	return cmd
+}
+
+// outputComment writes a single comment
+func outputComment(cmd *cobra.Command, comment *types.Comment) error {
+	record := output.NewRecord().
+		Field("ID", comment.ID).
+		Field("Page ID", comment.PageID).
+		Field("Type", comment.Location)
+	if comment.ParentID != "" {
+		record.Field("Reply To", comment.ParentID)
+	}
+	if comment.Selection != "" {
+		record.Field("Selection", comment.Selection)
+	}
+	if comment.Resolution != "" {
+		record.Field("Status", comment.Resolution)
+	}
+	record.Field("Comment", strings.Join(strings.Fields(confluence.StorageText(comment.Content)), " "))
+
+	return cmdutil.WriteOutput(cmd, comment, record)
+}
@@ -0,0 +1,49 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnresolvedComments(t *testing.T) {
+	comments := []types.Comment{
+		{ID: "1", Location: "footer"},
+		{ID: "2", Location: "inline", Resolution: "open"},
+		{ID: "3", Location: "inline", ParentID: "2"},
+		{ID: "4", Location: "inline", Resolution: "resolved"},
+		{ID: "5", Location: "inline", ParentID: "4"},
+		{ID: "6", Location: "inline", Resolution: "reopened"},
+	}
+
+	var ids []string
+	for _, comment := range unresolvedComments(comments) {
+		ids = append(ids, comment.ID)
+	}
+	assert.Equal(t, []string{"2", "3", "6"}, ids)
+}
+
+func TestBodyFlags_Resolve(t *testing.T) {
+	cmd, _ := newPageTestCmd("table")
+
+	flags := bodyFlags{body: "Looks **good**", format: FormatMarkdown}
+	body, err := flags.resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Looks <strong>good</strong></p>", body)
+
+	flags = bodyFlags{body: "<p>raw</p>", format: FormatStorage}
+	body, err = flags.resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>raw</p>", body)
+
+	flags = bodyFlags{format: FormatMarkdown}
+	_, err = flags.resolve(cmd)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestCommentPreview(t *testing.T) {
+	assert.Equal(t, "↳ Fixed in v2", commentPreview(types.Comment{ParentID: "1", Content: "<p>Fixed\nin <em>v2</em></p>"}))
+}
@@ -0,0 +1,112 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/markdown"
+	"atlassian-cli/internal/types"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Page body formats accepted by --format
+const (
+	FormatStorage  = "storage"
+	FormatMarkdown = "markdown"
+)
+
+// ContentFlags holds the flags that supply a page body
+type ContentFlags struct {
+	content string
+	file    string
+	format  string
+}
+
+// AddContentFlags adds --content, --content-file and --format to cmd
+func AddContentFlags(cmd *cobra.Command, flags *ContentFlags, usage string) {
+	cmd.Flags().StringVar(&flags.content, "content", "", usage)
+	cmd.Flags().StringVar(&flags.file, "content-file", "", `Read page content from a file ("-" for stdin)`)
+	cmd.Flags().StringVar(&flags.format, "format", "", "Content format: storage or markdown (default: markdown for .md files, otherwise storage)")
+	cmd.MarkFlagsMutuallyExclusive("content", "content-file")
+}
+
+// Resolve returns the page body in storage format, converting Markdown, or nil
+// when neither --content nor --content-file was given
+func (f *ContentFlags) Resolve(cmd *cobra.Command) (*string, error) {
+	format, err := contentFormat(f.format, f.file)
+	if err != nil {
+		return nil, err
+	}
+
+	content := f.content
+	switch {
+	case f.file == "-":
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to read content from stdin")
+		}
+		content = string(data)
+	case f.file != "":
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindValidation, err, "failed to read content file")
+		}
+		content = string(data)
+	case content == "":
+		return nil, nil
+	}
+
+	if format == FormatMarkdown {
+		// Files often carry front matter for static site generators
+		if f.file != "" {
+			content = markdown.StripFrontMatter(content)
+		}
+		content = markdown.ToStorage(content)
+	}
+	return &content, nil
+}
+
+// contentFormat validates --format, inferring Markdown from a .md or .markdown file
+func contentFormat(format, file string) (string, error) {
+	switch format {
+	case FormatStorage, FormatMarkdown:
+		return format, nil
+	case "":
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".md", ".markdown":
+			return FormatMarkdown, nil
+		}
+		return FormatStorage, nil
+	}
+	return "", clierr.New(clierr.KindValidation, "invalid --format %q: must be storage or markdown", format)
+}
+
+// OutputPageBody writes a page for page get. With --format markdown the body is
+// converted; table output then prints only the Markdown, ready to edit and
+// update the page with, and structured output carries it in the content field.
+func OutputPageBody(cmd *cobra.Command, page *types.Page, format string) error {
+	if format != FormatMarkdown {
+		return OutputPage(cmd, page)
+	}
+
+	body, err := markdown.FromStorage(page.Content)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to convert page %s to Markdown", page.ID)
+	}
+
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	if !formatter.IsStructured() {
+		return cmdutil.WriteText(cmd, body)
+	}
+
+	converted := *page
+	converted.Content = body
+	return OutputPage(cmd, &converted)
+}
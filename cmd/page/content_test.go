@@ -0,0 +1,83 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentFormat(t *testing.T) {
+	tests := []struct {
+		format, file, want string
+	}{
+		{"", "", FormatStorage},
+		{"", "page.xml", FormatStorage},
+		{"", "guide.md", FormatMarkdown},
+		{"", "GUIDE.Markdown", FormatMarkdown},
+		{"storage", "guide.md", FormatStorage},
+		{"markdown", "-", FormatMarkdown},
+	}
+
+	for _, tt := range tests {
+		got, err := contentFormat(tt.format, tt.file)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "format %q, file %q", tt.format, tt.file)
+	}
+
+	_, err := contentFormat("html", "")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestContentFlags_Resolve(t *testing.T) {
+	cmd := &cobra.Command{}
+
+	body, err := (&ContentFlags{}).Resolve(cmd)
+	require.NoError(t, err)
+	assert.Nil(t, body, "no content given")
+
+	body, err = (&ContentFlags{content: "<p>raw</p>"}).Resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>raw</p>", *body)
+
+	body, err = (&ContentFlags{content: "**bold**", format: FormatMarkdown}).Resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<p><strong>bold</strong></p>", *body)
+
+	file := filepath.Join(t.TempDir(), "guide.md")
+	require.NoError(t, os.WriteFile(file, []byte("---\ntitle: Guide\n---\n# Guide\n"), 0o600))
+	body, err = (&ContentFlags{file: file}).Resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>Guide</h1>", *body, "front matter is dropped")
+
+	cmd.SetIn(strings.NewReader("- a\n"))
+	body, err = (&ContentFlags{file: "-", format: FormatMarkdown}).Resolve(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "<ul><li>a</li></ul>", *body)
+
+	_, err = (&ContentFlags{file: filepath.Join(t.TempDir(), "missing.md")}).Resolve(cmd)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestOutputPageBody_Markdown(t *testing.T) {
+	page := &types.Page{ID: "1", Title: "Guide", Content: "<h1>Guide</h1><p>Read <em>this</em></p>"}
+
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, OutputPageBody(cmd, page, FormatMarkdown))
+	assert.Equal(t, "# Guide\n\nRead *this*\n", out.String())
+
+	cmd, out = newPageTestCmd("json")
+	require.NoError(t, OutputPageBody(cmd, page, FormatMarkdown))
+	var got types.Page
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "Guide", got.Title)
+	assert.Equal(t, "# Guide\n\nRead *this*\n", got.Content)
+	assert.Equal(t, "<h1>Guide</h1><p>Read <em>this</em></p>", page.Content, "page is not modified")
+}
@@ -0,0 +1,86 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/export"
+	"atlassian-cli/internal/types"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		recursive bool
+		format    string
+		outDir    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <page-id>",
+		Short: "Export a page, or a page tree, to local files",
+		Long: `Export a page to a local directory as Markdown or HTML, with its attachments.
+
+With --recursive the pages below it are exported too: a page with children
+becomes a directory holding its index file, other pages a file named after
+their title. Attachments are downloaded to attachments/<page-id>/, and links
+between exported pages and to attachments are rewritten as relative paths.
+
+A manifest.json records each page's ID, version and file. Exporting again to
+the same directory only writes pages and attachments that changed, and
+removes the files of pages that were deleted or moved away.
+
+Examples:
+  # Export a runbook tree as Markdown
+  atlassian-cli page export 123456 --recursive --out ./runbooks
+
+  # Export a single page as HTML
+  atlassian-cli page export 123456 --format html --out ./runbook`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := export.CheckFormat(format); err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			root, err := client.GetPage(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get page: %w", err)
+			}
+			root.Content = ""
+
+			depth := 1
+			if recursive {
+				depth = 0
+			}
+			tree, err := confluence.FetchTree(cmd.Context(), client, []types.Page{*root}, depth)
+			if err != nil {
+				return err
+			}
+
+			opts := export.Options{Dir: outDir, Format: format}
+			results, exportErr := export.Run(cmd.Context(), client, tree, "page:"+root.ID, opts)
+			if results == nil {
+				return exportErr
+			}
+			result := &export.Result{Dir: outDir, Format: format, Pages: results}
+			if err := cmdutil.WriteList(cmd, result, result.Pages, result.Table()); err != nil {
+				return err
+			}
+			return exportErr
+		},
+	}
+
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Export the pages below the page too")
+	cmd.Flags().StringVar(&format, "format", export.FormatMarkdown, "File format (markdown, html)")
+	cmd.Flags().StringVar(&outDir, "out", "", "Directory to export to")
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
@@ -0,0 +1,198 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newChildrenCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "children <page-id>",
+		Short: "List the child pages of a page",
+		Long: `List the direct child pages of a Confluence page in their sibling order.
+
+Examples:
+  atlassian-cli page children 123456
+  atlassian-cli page children 123456 --columns id,title,position`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			pages, err := client.ListChildren(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return outputPages(cmd, pages, "No child pages")
+		},
+	}
+
+	cmdutil.AddTableFlags(cmd, pageColumns.Names())
+
+	return cmd
+}
+
+func newAncestorsCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ancestors <page-id>",
+		Short: "List the pages above a page",
+		Long: `List the ancestors of a Confluence page, from the top of the space down to its parent.
+
+Examples:
+  atlassian-cli page ancestors 123456`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			pages, err := client.ListAncestors(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return outputPages(cmd, pages, "Page is at the top of its space")
+		},
+	}
+
+	cmdutil.AddTableFlags(cmd, pageColumns.Names())
+
+	return cmd
+}
+
+func newTreeCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey string
+		rootID   string
+		depth    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show the page hierarchy of a space",
+		Long: `Show the pages of a Confluence space, or of the subtree below --root, as an
+indented tree. Subtrees are fetched concurrently, so large spaces load quickly.
+
+JSON and YAML output nest each page's children under "children"; CSV, TSV and
+Markdown list one page per row with its depth and parent.
+
+Examples:
+  # Whole tree of the default space
+  atlassian-cli page tree
+
+  # Two levels below a page
+  atlassian-cli page tree --root 123456 --depth 2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if depth < 0 {
+				return clierr.New(clierr.KindValidation, "--depth must not be negative")
+			}
+
+			var resolvedSpace string
+			if rootID == "" {
+				var err error
+				if resolvedSpace, err = config.ResolveSpace(cmd); err != nil {
+					return err
+				}
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			var roots []types.Page
+			if rootID != "" {
+				root, err := client.GetPage(cmd.Context(), rootID)
+				if err != nil {
+					return fmt.Errorf("failed to get page: %w", err)
+				}
+				root.Content = ""
+				roots = []types.Page{*root}
+			} else if roots, err = client.ListRootPages(cmd.Context(), resolvedSpace); err != nil {
+				return err
+			}
+
+			tree, err := confluence.FetchTree(cmd.Context(), client, roots, depth)
+			if err != nil {
+				return err
+			}
+			return outputTree(cmd, tree)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+	cmd.Flags().StringVar(&rootID, "root", "", "Show only the tree below this page")
+	cmd.Flags().IntVar(&depth, "depth", 0, "Levels to show, counting the top level as 1 (0 for all)")
+
+	return cmd
+}
+
+// outputPages writes a list of pages that is not paginated
+func outputPages(cmd *cobra.Command, pages []types.Page, empty string) error {
+	table, err := cmdutil.BuildTable(cmd, pageColumns, pages)
+	if err != nil {
+		return err
+	}
+	table.Empty = empty
+
+	return cmdutil.WriteList(cmd, pages, pages, table)
+}
+
+// outputTree writes a page tree. The table format draws the hierarchy in the
+// title column; the other tabular formats get one row per page with its depth.
+func outputTree(cmd *cobra.Command, tree []*confluence.PageNode) error {
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+
+	var pages []types.Page
+	confluence.Walk(tree, func(node *confluence.PageNode, depth int) {
+		pages = append(pages, node.Page)
+	})
+
+	var table *output.Table
+	if formatter.Name() == output.FormatTable {
+		table = output.NewTable("Title", "ID")
+		writeTreeRows(table, tree)
+		table.Footer = fmt.Sprintf("%d pages", len(pages))
+	} else {
+		table = output.NewTable("Depth", "ID", "Title", "Parent ID")
+		confluence.Walk(tree, func(node *confluence.PageNode, depth int) {
+			table.AddRow(depth+1, node.ID, node.Title, node.ParentID)
+		})
+	}
+	table.Empty = "No pages found"
+
+	return cmdutil.WriteList(cmd, tree, pages, table)
+}
+
+// writeTreeRows adds a row per page, drawing each root's descendants as branches
+func writeTreeRows(table *output.Table, roots []*confluence.PageNode) {
+	for _, root := range roots {
+		table.AddRow(root.Title, root.ID)
+		writeBranches(table, root.Children, "")
+	}
+}
+
+// writeBranches adds rows for nodes with box-drawing branches before their titles
+func writeBranches(table *output.Table, nodes []*confluence.PageNode, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		table.AddRow(prefix+branch+node.Title, node.ID)
+		writeBranches(table, node.Children, prefix+indent)
+	}
+}
@@ -0,0 +1,35 @@
+package page
+
+import (
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputTree(t *testing.T) {
+	tree := []*confluence.PageNode{{
+		Page: types.Page{ID: "1", Title: "Home"},
+		Children: []*confluence.PageNode{
+			{Page: types.Page{ID: "2", Title: "Guides", ParentID: "1"}, Children: []*confluence.PageNode{
+				{Page: types.Page{ID: "4", Title: "Setup", ParentID: "2"}},
+			}},
+			{Page: types.Page{ID: "3", Title: "FAQ", ParentID: "1"}},
+		},
+	}}
+
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, outputTree(cmd, tree))
+	assert.Equal(t, "TITLE         ID\n----------------\n"+
+		"Home          1\n"+
+		"├── Guides    2\n"+
+		"│   └── Setup 4\n"+
+		"└── FAQ       3\n"+
+		"\n4 pages\n", out.String())
+
+	cmd, out = newPageTestCmd("csv")
+	require.NoError(t, outputTree(cmd, tree))
+	assert.Equal(t, "Depth,ID,Title,Parent ID\n1,1,Home,\n2,2,Guides,1\n3,4,Setup,2\n2,3,FAQ,1\n", out.String())
+}
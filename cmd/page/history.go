@@ -0,0 +1,231 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/diff"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pageDiff is the difference between two versions of a page
+type pageDiff struct {
+	PageID    string `json:"pageId"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	FromTitle string `json:"fromTitle"`
+	ToTitle   string `json:"toTitle"`
+	Changed   bool   `json:"changed"`
+	Diff      string `json:"diff"` // Unified diff of the storage-format content
+}
+
+// diffStyles colors unified diff lines by their first character
+var diffStyles = map[byte]string{'+': "green", '-': "red", '@': "cyan"}
+
+func newHistoryCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <page-id>",
+		Short: "List the versions of a page",
+		Long: `List the versions of a Confluence page, newest first, with the author, date and
+version message of each.
+
+Examples:
+  atlassian-cli page history 123456
+  atlassian-cli page history 123456 -o json --query '.[0].message'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			versions, err := client.ListVersions(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			table := output.NewTable("Version", "Author", "When", "Message")
+			table.Columns[2].Kind = output.KindTime
+			table.WithMaxWidth("Message", 60)
+			for _, version := range versions {
+				message := version.Message
+				if version.MinorEdit {
+					message = strings.TrimSpace("(minor) " + message)
+				}
+				table.AddRow(version.Number, version.Author, version.When, message)
+			}
+			table.Empty = "No versions found"
+
+			return cmdutil.WriteList(cmd, versions, versions, table)
+		},
+	}
+
+	return cmd
+}
+
+func newDiffCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		from         int
+		to           int
+		contextLines int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <page-id>",
+		Short: "Show the changes between two versions of a page",
+		Long: `Show a unified diff of a page's storage-format content between two versions.
+The content is split into one line per paragraph, heading, list item or table
+row, so the diff stays readable for pages stored on a single line.
+
+--to defaults to the current version and --from to the version before --to.
+
+Examples:
+  # What changed in the latest edit
+  atlassian-cli page diff 123456
+
+  # Changes from version 3 to version 7
+  atlassian-cli page diff 123456 --from 3 --to 7`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from < 0 || to < 0 || contextLines < 0 {
+				return clierr.New(clierr.KindValidation, "--from, --to and --context must not be negative")
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			result, err := diffVersions(cmd.Context(), client, args[0], from, to, contextLines)
+			if err != nil {
+				return err
+			}
+			return outputPageDiff(cmd, result)
+		},
+	}
+
+	cmd.Flags().IntVar(&from, "from", 0, "Version to compare from (default: the version before --to)")
+	cmd.Flags().IntVar(&to, "to", 0, "Version to compare to (default: the current version)")
+	cmd.Flags().IntVar(&contextLines, "context", 3, "Unchanged lines to show around each change")
+
+	return cmd
+}
+
+// diffVersions compares two versions of a page, filling in the defaults for from
+// and to when they are 0
+func diffVersions(ctx context.Context, client confluence.ConfluenceClient, id string, from, to, contextLines int) (*pageDiff, error) {
+	var newer *types.Page
+	var err error
+	if to == 0 {
+		if newer, err = client.GetPage(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to get page: %w", err)
+		}
+		to = newer.Version
+	} else if newer, err = client.GetPageVersion(ctx, id, to); err != nil {
+		return nil, err
+	}
+
+	if from == 0 {
+		from = to - 1
+	}
+	if from < 1 {
+		return nil, clierr.New(clierr.KindValidation, "page %s has no version before %d", id, to)
+	}
+	older, err := client.GetPageVersion(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := diff.Lines(confluence.StorageLines(older.Content), confluence.StorageLines(newer.Content))
+	unified := diff.Unified(edits, fmt.Sprintf("version %d", from), fmt.Sprintf("version %d", to), contextLines)
+
+	return &pageDiff{
+		PageID:    id,
+		From:      from,
+		To:        to,
+		FromTitle: older.Title,
+		ToTitle:   newer.Title,
+		Changed:   unified != "" || older.Title != newer.Title,
+		Diff:      unified,
+	}, nil
+}
+
+// outputPageDiff writes the diff as colored text, or as a record in structured formats
+func outputPageDiff(cmd *cobra.Command, result *pageDiff) error {
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	if formatter.IsStructured() {
+		return cmdutil.WriteOutput(cmd, result, nil)
+	}
+
+	var sb strings.Builder
+	if result.FromTitle != result.ToTitle {
+		fmt.Fprintf(&sb, "Title: %q → %q\n\n", result.FromTitle, result.ToTitle)
+	}
+	if !result.Changed {
+		fmt.Fprintf(&sb, "No changes between version %d and version %d\n", result.From, result.To)
+	}
+
+	color := cmdutil.ColorEnabled(cmd)
+	for _, line := range diff.SplitLines(result.Diff) {
+		if style, ok := diffStyles[line[0]]; ok && color && !strings.HasPrefix(line, "+++") && !strings.HasPrefix(line, "---") {
+			line = output.Colorize(style, line)
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	return cmdutil.WriteText(cmd, sb.String())
+}
+
+func newRestoreVersionCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var message string
+
+	cmd := &cobra.Command{
+		Use:   "restore-version <page-id> <version>",
+		Short: "Roll a page back to an earlier version",
+		Long: `Restore the title and content of an earlier version of a page. The restore is
+saved as a new version, so it can itself be undone.
+
+Examples:
+  atlassian-cli page restore-version 123456 3
+  atlassian-cli page restore-version 123456 3 --message "Revert accidental edit"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[1])
+			if err != nil || version < 1 {
+				return clierr.New(clierr.KindValidation, "invalid version %q: must be a positive number", args[1])
+			}
+			if message == "" {
+				message = fmt.Sprintf("Restored version %d", version)
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			page, err := client.RestoreVersion(cmd.Context(), args[0], version, message)
+			if err != nil {
+				return err
+			}
+			page.Content = ""
+			return OutputPage(cmd, page)
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", `Version message (default "Restored version N")`)
+
+	return cmd
+}
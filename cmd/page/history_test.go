@@ -0,0 +1,87 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyClient serves the current version of a page and its earlier versions
+type historyClient struct {
+	confluence.ConfluenceClient
+	page     types.Page
+	versions map[int]types.Page
+}
+
+func (c *historyClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page := c.page
+	return &page, nil
+}
+
+func (c *historyClient) GetPageVersion(ctx context.Context, id string, version int) (*types.Page, error) {
+	page, ok := c.versions[version]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "version %d of page %s not found", version, id)
+	}
+	return &page, nil
+}
+
+func newVersionedClient() *historyClient {
+	return &historyClient{
+		page: types.Page{ID: "1", Title: "Guide", Version: 3, Content: "<p>Intro</p><p>Step two</p>"},
+		versions: map[int]types.Page{
+			1: {ID: "1", Title: "Draft", Version: 1, Content: "<p>Intro</p>"},
+			2: {ID: "1", Title: "Guide", Version: 2, Content: "<p>Intro</p><p>Step 2</p>"},
+			3: {ID: "1", Title: "Guide", Version: 3, Content: "<p>Intro</p><p>Step two</p>"},
+		},
+	}
+}
+
+func TestDiffVersions_Defaults(t *testing.T) {
+	result, err := diffVersions(context.Background(), newVersionedClient(), "1", 0, 0, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.From)
+	assert.Equal(t, 3, result.To)
+	assert.True(t, result.Changed)
+	assert.Equal(t, "--- version 2\n+++ version 3\n@@ -1,2 +1,2 @@\n <p>Intro</p>\n-<p>Step 2</p>\n+<p>Step two</p>\n", result.Diff)
+}
+
+func TestDiffVersions_TitleOnly(t *testing.T) {
+	client := newVersionedClient()
+	client.versions[2] = types.Page{ID: "1", Title: "Draft", Content: "<p>Intro</p>"}
+
+	result, err := diffVersions(context.Background(), client, "1", 1, 2, 3)
+	require.NoError(t, err)
+	assert.Empty(t, result.Diff)
+	assert.False(t, result.Changed)
+
+	result, err = diffVersions(context.Background(), newVersionedClient(), "1", 1, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Draft", result.FromTitle)
+	assert.Equal(t, "Guide", result.ToTitle)
+}
+
+func TestDiffVersions_NoEarlierVersion(t *testing.T) {
+	_, err := diffVersions(context.Background(), newVersionedClient(), "1", 0, 1, 3)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestOutputPageDiff(t *testing.T) {
+	result, err := diffVersions(context.Background(), newVersionedClient(), "1", 1, 2, 3)
+	require.NoError(t, err)
+
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, outputPageDiff(cmd, result))
+	assert.Equal(t, "Title: \"Draft\" → \"Guide\"\n\n"+
+		"--- version 1\n+++ version 2\n@@ -1 +1,2 @@\n <p>Intro</p>\n+<p>Step 2</p>\n", out.String())
+
+	cmd, out = newPageTestCmd("json")
+	require.NoError(t, outputPageDiff(cmd, result))
+	assert.Contains(t, out.String(), `"changed": true`)
+}
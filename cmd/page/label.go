@@ -0,0 +1,146 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newLabelCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "label",
+		Aliases: []string{"labels"},
+		Short:   "Manage the labels of Confluence pages",
+		Long: `Add, remove and list page labels. To find pages by label, use --label with
+"page list" or "page search".`,
+	}
+
+	cmd.AddCommand(cmdutil.MarkAudited(newLabelAddCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newLabelRmCmd(tokenManager)))
+	cmd.AddCommand(newLabelListCmd(tokenManager))
+
+	return cmd
+}
+
+func newLabelAddCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <page-id> <label>...",
+		Short: "Add labels to a page",
+		Long: `Add labels to a page. Labels the page already has are kept as they are.
+Confluence stores labels in lower case, and they cannot contain spaces.
+
+Examples:
+  # Label a runbook
+  atlassian-cli page label add 123456 runbook database`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confluence.ValidateLabels(args[1:]); err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			labels, err := client.AddLabels(cmd.Context(), args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			return outputLabels(cmd, labels)
+		},
+	}
+
+	return cmd
+}
+
+func newLabelRmCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm <page-id> <label>...",
+		Aliases: []string{"remove"},
+		Short:   "Remove labels from a page",
+		Long: `Remove labels from a page.
+
+Examples:
+  # Remove a label
+  atlassian-cli page label rm 123456 draft`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			result := cmdutil.RunBulk(cmd.Context(), args[1:], func(ctx context.Context, label string) error {
+				return client.RemoveLabel(ctx, args[0], label)
+			})
+
+			formatter, err := cmdutil.GetFormatter(cmd)
+			if err != nil {
+				return err
+			}
+			if formatter.IsStructured() {
+				if err := cmdutil.WriteOutput(cmd, result, nil); err != nil {
+					return err
+				}
+			} else {
+				result.PrintSummary(cmd.OutOrStdout())
+			}
+			return result.Err()
+		},
+	}
+
+	return cmd
+}
+
+func newLabelListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <page-id>",
+		Short: "List the labels of a page",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			labels, err := client.ListLabels(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return outputLabels(cmd, labels)
+		},
+	}
+
+	return cmd
+}
+
+// outputLabels lists the labels of a page
+func outputLabels(cmd *cobra.Command, labels []types.Label) error {
+	table := output.NewTable("Name", "Prefix", "ID")
+	for _, label := range labels {
+		table.AddRow(label.Name, label.Prefix, label.ID)
+	}
+	table.Empty = "No labels"
+	if len(labels) > 0 {
+		table.Footer = fmt.Sprintf("%d %s", len(labels), pluralLabels(len(labels)))
+	}
+
+	return cmdutil.WriteList(cmd, labels, labels, table)
+}
+
+// pluralLabels returns "label" or "labels" to follow a count
+func pluralLabels(n int) string {
+	if n == 1 {
+		return "label"
+	}
+	return "labels"
+}
@@ -0,0 +1,60 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/diff"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// mergeUpdate applies req to a page that may have changed since req.ExpectedVersion.
+// Edits made by others since then are merged with req's title and content, three-way
+// against the expected version; the update fails with a conflict when both changed
+// the same part of the page.
+func mergeUpdate(ctx context.Context, client confluence.ConfluenceClient, id string, req *types.UpdatePageRequest) (*types.Page, error) {
+	current, err := client.GetPage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if current.Version == req.ExpectedVersion {
+		return client.UpdatePage(ctx, id, req)
+	}
+
+	base, err := client.GetPageVersion(ctx, id, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *req
+	merged.ExpectedVersion = current.Version
+
+	if req.Title != nil {
+		switch {
+		case *req.Title == base.Title:
+			// The title was not changed here, so keep any rename made since
+			title := current.Title
+			merged.Title = &title
+		case current.Title != base.Title && current.Title != *req.Title:
+			return nil, clierr.New(clierr.KindConflict, "cannot merge: the title was changed to %q in version %d", current.Title, current.Version)
+		}
+	}
+
+	if req.Content != nil {
+		// Segments keep their separators, so parts of the page that neither side
+		// changed, such as code blocks, are written back exactly as they were
+		result := diff.Merge(confluence.StorageSegments(base.Content), confluence.StorageSegments(current.Content), confluence.StorageSegments(*req.Content),
+			fmt.Sprintf("version %d", current.Version), "your changes")
+		if result.Conflicts > 0 {
+			return nil, clierr.New(clierr.KindConflict,
+				"cannot merge: %d of your changes overlap edits made since version %d; review them with \"page diff %s --from %d --to %d\"",
+				result.Conflicts, req.ExpectedVersion, id, req.ExpectedVersion, current.Version)
+		}
+		content := strings.Join(result.Lines, "")
+		merged.Content = &content
+	}
+
+	return client.UpdatePage(ctx, id, &merged)
+}
@@ -0,0 +1,121 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeClient serves a page and the version an update was based on, and
+// records the updates it is sent
+type mergeClient struct {
+	confluence.ConfluenceClient
+	page    types.Page
+	base    types.Page
+	updates []types.UpdatePageRequest
+}
+
+func (c *mergeClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page := c.page
+	return &page, nil
+}
+
+func (c *mergeClient) GetPageVersion(ctx context.Context, id string, version int) (*types.Page, error) {
+	if version != c.base.Version {
+		return nil, clierr.New(clierr.KindNotFound, "version %d of page %s not found", version, id)
+	}
+	base := c.base
+	return &base, nil
+}
+
+func (c *mergeClient) UpdatePage(ctx context.Context, id string, req *types.UpdatePageRequest) (*types.Page, error) {
+	page := c.page
+	if req.ExpectedVersion > 0 && req.ExpectedVersion != page.Version {
+		return nil, clierr.New(clierr.KindConflict, "page %s has changed", id)
+	}
+	c.updates = append(c.updates, *req)
+	page.Version++
+	if req.Content != nil {
+		page.Content = *req.Content
+	}
+	if req.Title != nil {
+		page.Title = *req.Title
+	}
+	return &page, nil
+}
+
+// newMergeClient returns a page at version 3 whose base version 2 had three
+// paragraphs; version 3 changed the first one
+func newMergeClient() *mergeClient {
+	return &mergeClient{
+		page: types.Page{ID: "1", Title: "Guide", Version: 3, Content: "<p>Intro, revised</p><p>Setup</p><p>Steps</p>"},
+		base: types.Page{ID: "1", Title: "Guide", Version: 2, Content: "<p>Intro</p><p>Setup</p><p>Steps</p>"},
+	}
+}
+
+func TestMergeUpdate_Clean(t *testing.T) {
+	client := newMergeClient()
+	content := "<p>Intro</p><p>Setup</p><p>Steps, expanded</p>"
+
+	page, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Content: &content, ExpectedVersion: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Intro, revised</p><p>Setup</p><p>Steps, expanded</p>", page.Content)
+	assert.Equal(t, 3, client.updates[0].ExpectedVersion, "the merged update is based on the current version")
+}
+
+func TestMergeUpdate_KeepsCodeMacros(t *testing.T) {
+	code := "<ac:structured-macro ac:name=\"code\"><ac:plain-text-body><![CDATA[if a {\n\tb()<br/>\n}]]></ac:plain-text-body></ac:structured-macro>"
+	client := &mergeClient{
+		page: types.Page{ID: "1", Title: "Guide", Version: 3, Content: "<p>Intro, revised</p>\n" + code + "<p>Steps</p>"},
+		base: types.Page{ID: "1", Title: "Guide", Version: 2, Content: "<p>Intro</p>\n" + code + "<p>Steps</p>"},
+	}
+	content := "<p>Intro</p>\n" + code + "<p>Steps, expanded</p>"
+
+	page, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Content: &content, ExpectedVersion: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Intro, revised</p>\n"+code+"<p>Steps, expanded</p>", page.Content, "untouched code and whitespace are kept as they were")
+}
+
+func TestMergeUpdate_KeepsRename(t *testing.T) {
+	client := newMergeClient()
+	client.page.Title = "Their title"
+	title := "Guide"
+	content := "<p>Intro</p><p>Setup</p><p>Steps, expanded</p>"
+
+	page, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Title: &title, Content: &content, ExpectedVersion: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "Their title", page.Title, "an unchanged title does not revert the rename")
+}
+
+func TestMergeUpdate_Conflict(t *testing.T) {
+	client := newMergeClient()
+	content := "<p>Intro, mine</p><p>Setup</p><p>Steps</p>"
+
+	_, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Content: &content, ExpectedVersion: 2})
+	assert.Equal(t, clierr.KindConflict, clierr.KindOf(err))
+	assert.Contains(t, err.Error(), "page diff 1 --from 2 --to 3")
+	assert.Empty(t, client.updates)
+}
+
+func TestMergeUpdate_TitleConflict(t *testing.T) {
+	client := newMergeClient()
+	client.page = types.Page{ID: "1", Title: "Their title", Version: 3}
+	title := "My title"
+
+	_, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Title: &title, ExpectedVersion: 2})
+	assert.Equal(t, clierr.KindConflict, clierr.KindOf(err))
+}
+
+func TestMergeUpdate_Unchanged(t *testing.T) {
+	client := newMergeClient()
+	content := "<p>New</p>"
+
+	page, err := mergeUpdate(context.Background(), client, "1", &types.UpdatePageRequest{Content: &content, ExpectedVersion: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "<p>New</p>", page.Content, "content is sent as is when nothing needs merging")
+}
@@ -0,0 +1,234 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// pageCopyResult reports the pages created by page copy
+type pageCopyResult struct {
+	Copies []types.PageCopy `json:"copies"`
+}
+
+func newMoveCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		parentID string
+		spaceKey string
+		position string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move <page-id>",
+		Short: "Move a page to a new place in the hierarchy",
+		Long: `Move a Confluence page, together with its children, to a new place in the hierarchy.
+
+By default the page becomes the last child of --parent. With --position before or
+after it is placed next to the --parent page instead, as its sibling. With --space
+and no --parent the page moves under the home page of that space; with both, the
+parent must belong to that space.
+
+Examples:
+  # Move a page under another page
+  atlassian-cli page move 123456 --parent 654321
+
+  # Place a page just before one of its new siblings
+  atlassian-cli page move 123456 --parent 654321 --position before
+
+  # Move a page to the top of another space
+  atlassian-cli page move 123456 --space ARCHIVE`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if parentID == "" && spaceKey == "" {
+				return clierr.New(clierr.KindValidation, "--parent or --space is required")
+			}
+			if position != "append" && position != "before" && position != "after" {
+				return clierr.New(clierr.KindValidation, "invalid --position %q: must be before, after or append", position)
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			page, err := movePage(cmd.Context(), client, args[0], parentID, spaceKey, position)
+			if err != nil {
+				return err
+			}
+			return OutputPage(cmd, page)
+		},
+	}
+
+	cmd.Flags().StringVar(&parentID, "parent", "", "Page to move the page under, or next to with --position")
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Destination space; without --parent the page moves under its home page")
+	cmd.Flags().StringVar(&position, "position", "append", "Where to place the page: append (last child), before or after")
+
+	return cmd
+}
+
+// movePage resolves the move target and moves the page, returning it in its new place
+func movePage(ctx context.Context, client confluence.ConfluenceClient, id, parentID, spaceKey, position string) (*types.Page, error) {
+	var target *types.Page
+	var err error
+	if parentID != "" {
+		if target, err = client.GetPage(ctx, parentID); err != nil {
+			return nil, fmt.Errorf("failed to get parent page: %w", err)
+		}
+		if spaceKey != "" && target.SpaceKey != spaceKey {
+			return nil, clierr.New(clierr.KindValidation, "parent page %s is in space %s, not %s", parentID, target.SpaceKey, spaceKey)
+		}
+	} else if target, err = client.GetSpaceHomepage(ctx, spaceKey); err != nil {
+		return nil, err
+	}
+
+	if target.ID == id {
+		return nil, clierr.New(clierr.KindValidation, "cannot move page %s relative to itself", id)
+	}
+	if err := client.MovePage(ctx, id, position, target.ID); err != nil {
+		return nil, err
+	}
+
+	page, err := client.GetPage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moved page: %w", err)
+	}
+	page.Content = ""
+	return page, nil
+}
+
+func newCopyCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		parentID    string
+		recursive   bool
+		attachments bool
+		labels      bool
+		titlePrefix string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "copy <page-id>",
+		Short: "Copy a page, or a whole subtree, under another page",
+		Long: `Copy a Confluence page to the end of another page's children. With --recursive
+every descendant is copied as well, keeping the shape and order of the subtree.
+
+Page titles must be unique within a space, so copies into the same space need
+--title-prefix. The command reports the ID of each copy next to the ID of the page
+it was copied from. If a copy fails, the pages copied so far are reported before
+the error.
+
+Examples:
+  # Copy a page into another space
+  atlassian-cli page copy 123456 --to-parent 654321
+
+  # Duplicate a subtree next to the original, with its attachments and labels
+  atlassian-cli page copy 123456 --to-parent 111111 --recursive \
+    --include-attachments --include-labels --title-prefix "Copy of "
+
+  # Map old IDs to new IDs in a script
+  atlassian-cli page copy 123456 --to-parent 654321 -r -o json --query '.copies[]'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if parentID == "" {
+				return clierr.New(clierr.KindValidation, "--to-parent is required")
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			root, err := client.GetPage(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get page: %w", err)
+			}
+
+			depth := 1
+			if recursive {
+				depth = 0
+			}
+			tree, err := confluence.FetchTree(cmd.Context(), client, []types.Page{*root}, depth)
+			if err != nil {
+				return err
+			}
+
+			request := types.CopyPageRequest{
+				ParentID:           parentID,
+				IncludeAttachments: attachments,
+				IncludeLabels:      labels,
+			}
+			copies, copyErr := copyPages(cmd.Context(), client, tree[0], request, titlePrefix)
+			if err := outputPageCopies(cmd, copies); err != nil {
+				return err
+			}
+			return copyErr
+		},
+	}
+
+	cmd.Flags().StringVar(&parentID, "to-parent", "", "Page to copy the page under (required)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Also copy every descendant page")
+	cmd.Flags().BoolVar(&attachments, "include-attachments", false, "Copy the attachments of each page")
+	cmd.Flags().BoolVar(&labels, "include-labels", false, "Copy the labels of each page")
+	cmd.Flags().StringVar(&titlePrefix, "title-prefix", "", "Prefix for the title of each copy")
+
+	return cmd
+}
+
+// copyPages copies root and the children loaded below it, parents before their
+// children so each copy can be placed under its parent's copy. It stops at the
+// first failure and returns the copies made so far.
+func copyPages(ctx context.Context, client confluence.ConfluenceClient, root *confluence.PageNode, request types.CopyPageRequest, titlePrefix string) ([]types.PageCopy, error) {
+	var copies []types.PageCopy
+
+	var copyNode func(node *confluence.PageNode, parentID string) error
+	copyNode = func(node *confluence.PageNode, parentID string) error {
+		if err := ctx.Err(); err != nil {
+			return clierr.Wrap(clierr.KindCanceled, err, "copy interrupted")
+		}
+
+		req := request
+		req.ParentID = parentID
+		if titlePrefix != "" {
+			req.Title = titlePrefix + node.Title
+		}
+		page, err := client.CopyPage(ctx, node.ID, &req)
+		if err != nil {
+			return err
+		}
+		copies = append(copies, types.PageCopy{OldID: node.ID, NewID: page.ID, Title: page.Title})
+
+		for _, child := range node.Children {
+			if err := copyNode(child, page.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := copyNode(root, request.ParentID)
+	return copies, err
+}
+
+// outputPageCopies reports each copied page with the ID of its copy
+func outputPageCopies(cmd *cobra.Command, copies []types.PageCopy) error {
+	table := output.NewTable("Old ID", "New ID", "Title")
+	for _, c := range copies {
+		table.AddRow(c.OldID, c.NewID, c.Title)
+	}
+	table.Empty = "No pages copied"
+	if len(copies) == 1 {
+		table.Footer = "Copied 1 page"
+	} else if len(copies) > 1 {
+		table.Footer = fmt.Sprintf("Copied %d pages", len(copies))
+	}
+
+	return cmdutil.WriteList(cmd, pageCopyResult{Copies: copies}, copies, table)
+}
@@ -0,0 +1,146 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// moveClient serves pages and space homepages and records the moves it is sent
+type moveClient struct {
+	confluence.ConfluenceClient
+	pages     map[string]types.Page
+	homepages map[string]types.Page
+	moves     []string
+}
+
+func (c *moveClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page, ok := c.pages[id]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "page %s not found", id)
+	}
+	return &page, nil
+}
+
+func (c *moveClient) GetSpaceHomepage(ctx context.Context, spaceKey string) (*types.Page, error) {
+	page, ok := c.homepages[spaceKey]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "space %s not found", spaceKey)
+	}
+	return &page, nil
+}
+
+func (c *moveClient) MovePage(ctx context.Context, id, position, targetID string) error {
+	c.moves = append(c.moves, fmt.Sprintf("%s %s %s", id, position, targetID))
+	return nil
+}
+
+func TestMovePage(t *testing.T) {
+	client := &moveClient{
+		pages: map[string]types.Page{
+			"1": {ID: "1", Title: "Moved", SpaceKey: "DEV", Content: "<p>body</p>"},
+			"2": {ID: "2", Title: "Parent", SpaceKey: "DEV"},
+		},
+		homepages: map[string]types.Page{"ARCH": {ID: "9", SpaceKey: "ARCH"}},
+	}
+
+	page, err := movePage(context.Background(), client, "1", "2", "", "before")
+	require.NoError(t, err)
+	assert.Equal(t, "1", page.ID)
+	assert.Empty(t, page.Content)
+
+	_, err = movePage(context.Background(), client, "1", "", "ARCH", "append")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1 before 2", "1 append 9"}, client.moves)
+}
+
+func TestMovePage_Invalid(t *testing.T) {
+	client := &moveClient{pages: map[string]types.Page{
+		"1": {ID: "1", SpaceKey: "DEV"},
+		"2": {ID: "2", SpaceKey: "DEV"},
+	}}
+
+	_, err := movePage(context.Background(), client, "1", "2", "OPS", "append")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+	assert.Contains(t, err.Error(), "is in space DEV, not OPS")
+
+	_, err = movePage(context.Background(), client, "1", "1", "", "append")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+	assert.Empty(t, client.moves)
+}
+
+// copyClient records the copies it is sent, failing for the page copyFail
+type copyClient struct {
+	confluence.ConfluenceClient
+	copyFail string
+	copied   []types.CopyPageRequest
+}
+
+func (c *copyClient) CopyPage(ctx context.Context, id string, req *types.CopyPageRequest) (*types.Page, error) {
+	if id == c.copyFail {
+		return nil, errors.New("copy failed")
+	}
+	c.copied = append(c.copied, *req)
+	return &types.Page{ID: "new-" + id, Title: req.Title, ParentID: req.ParentID}, nil
+}
+
+func copyTree() *confluence.PageNode {
+	return &confluence.PageNode{
+		Page: types.Page{ID: "1", Title: "Guide"},
+		Children: []*confluence.PageNode{
+			{Page: types.Page{ID: "2", Title: "Setup"}, Children: []*confluence.PageNode{
+				{Page: types.Page{ID: "4", Title: "Linux"}},
+			}},
+			{Page: types.Page{ID: "3", Title: "FAQ"}},
+		},
+	}
+}
+
+func TestCopyPages(t *testing.T) {
+	client := &copyClient{}
+	request := types.CopyPageRequest{ParentID: "100", IncludeLabels: true}
+
+	copies, err := copyPages(context.Background(), client, copyTree(), request, "Copy of ")
+	require.NoError(t, err)
+	assert.Equal(t, []types.PageCopy{
+		{OldID: "1", NewID: "new-1", Title: "Copy of Guide"},
+		{OldID: "2", NewID: "new-2", Title: "Copy of Setup"},
+		{OldID: "4", NewID: "new-4", Title: "Copy of Linux"},
+		{OldID: "3", NewID: "new-3", Title: "Copy of FAQ"},
+	}, copies)
+
+	// Each copy goes under the copy of its parent
+	var parents []string
+	for _, req := range client.copied {
+		parents = append(parents, req.ParentID)
+		assert.True(t, req.IncludeLabels)
+	}
+	assert.Equal(t, []string{"100", "new-1", "new-2", "new-1"}, parents)
+}
+
+func TestCopyPages_StopsAtFirstError(t *testing.T) {
+	client := &copyClient{copyFail: "2"}
+
+	copies, err := copyPages(context.Background(), client, copyTree(), types.CopyPageRequest{ParentID: "100"}, "")
+	assert.EqualError(t, err, "copy failed")
+	assert.Equal(t, []types.PageCopy{{OldID: "1", NewID: "new-1"}}, copies)
+}
+
+func TestOutputPageCopies(t *testing.T) {
+	copies := []types.PageCopy{{OldID: "1", NewID: "11", Title: "Guide"}}
+
+	cmd, out := newPageTestCmd("json")
+	require.NoError(t, outputPageCopies(cmd, copies))
+	assert.JSONEq(t, `{"copies":[{"oldId":"1","newId":"11","title":"Guide"}]}`, out.String())
+
+	cmd, out = newPageTestCmd("table")
+	require.NoError(t, outputPageCopies(cmd, copies))
+	assert.Contains(t, out.String(), "Copied 1 page\n")
+}
@@ -3,11 +3,14 @@ package page
 import (
 	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
 	"atlassian-cli/internal/types"
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,11 +24,30 @@ func NewPageCmd(tokenManager auth.TokenManager) *cobra.Command {
 		Long:  `Create, read, update, and manage Confluence pages`,
 	}
 
-	cmd.AddCommand(newCreateCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newCreateCmd(tokenManager)))
 	cmd.AddCommand(newGetCmd(tokenManager))
 	cmd.AddCommand(newListCmd(tokenManager))
-	cmd.AddCommand(newUpdateCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newUpdateCmd(tokenManager)))
 	cmd.AddCommand(newSearchCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newDeleteCmd(tokenManager)))
+	cmd.AddCommand(newTrashCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newRestoreCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newPurgeCmd(tokenManager)))
+	cmd.AddCommand(newChildrenCmd(tokenManager))
+	cmd.AddCommand(newAncestorsCmd(tokenManager))
+	cmd.AddCommand(newTreeCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newMoveCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newCopyCmd(tokenManager)))
+	cmd.AddCommand(newHistoryCmd(tokenManager))
+	cmd.AddCommand(newDiffCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newRestoreVersionCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newSyncCmd(tokenManager)))
+	cmd.AddCommand(newExportCmd(tokenManager))
+	cmd.AddCommand(newAttachmentCmd(tokenManager))
+	cmd.AddCommand(newLabelCmd(tokenManager))
+	cmd.AddCommand(newCommentCmd(tokenManager))
+	cmd.AddCommand(newRestrictionsCmd(tokenManager))
+	cmd.AddCommand(newPropertyCmd(tokenManager))
 
 	return cmd
 }
@@ -34,7 +56,8 @@ func newCreateCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
 		spaceKey string
 		title    string
-		content  string
+		content  ContentFlags
+		tmpl     templateFlags
 		parentID string
 	)
 
@@ -46,13 +69,26 @@ func newCreateCmd(tokenManager auth.TokenManager) *cobra.Command {
 Examples:
   # Create page using default space
   atlassian-cli page create --title "New Page" --content "Page content"
+
+  # Create from a Markdown file, converted to storage format
+  atlassian-cli page create --title "API Guide" --content-file guide.md
+
+  # Create from a Confluence template, filling in its variables
+  atlassian-cli page create --title "Postmortem: checkout outage" --template Postmortem --var service=checkout --var severity=2
+
+  # Create from a local Go template, e.g. containing "# ADR: {{.title}}"
+  atlassian-cli page create --title "ADR 12: Use Postgres" --template-file adr.md --var title="Use Postgres"
   
   # Override default space
   atlassian-cli page create --confluence-space DOCS --title "API Guide"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
+			body, err := content.Resolve(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
+				return err
+			}
+			values, err := tmpl.values()
+			if err != nil {
+				return err
 			}
 
 			resolvedSpace, err := config.ResolveSpace(cmd)
@@ -60,36 +96,42 @@ Examples:
 				return err
 			}
 
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
+				return err
 			}
 
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
-			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+			if tmpl.given() {
+				filled, err := tmpl.resolve(cmd.Context(), client, resolvedSpace, content.format, values)
+				if err != nil {
+					return err
+				}
+				body = &filled
 			}
 
 			req := &types.CreatePageRequest{
 				SpaceKey: resolvedSpace,
 				Title:    title,
-				Content:  content,
 				ParentID: parentID,
 			}
+			if body != nil {
+				req.Content = *body
+			}
 
-			page, err := client.CreatePage(context.Background(), req)
+			page, err := client.CreatePage(cmd.Context(), req)
 			if err != nil {
 				return fmt.Errorf("failed to create page: %w", err)
 			}
+			cmdutil.SetAuditTarget(cmd, page.ID)
 
-			return outputPage(cmd, page)
+			return OutputPage(cmd, page)
 		},
 	}
 
 	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
 	cmd.Flags().StringVar(&title, "title", "", "Page title (required)")
-	cmd.Flags().StringVar(&content, "content", "", "Page content")
+	AddContentFlags(cmd, &content, "Page content in storage format, or Markdown with --format markdown")
+	addTemplateFlags(cmd, &tmpl)
 	cmd.Flags().StringVar(&parentID, "parent-id", "", "Parent page ID")
 
 	cmd.MarkFlagRequired("title")
@@ -98,39 +140,46 @@ Examples:
 }
 
 func newGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "get <page-id>",
 		Short: "Get a Confluence page by ID",
-		Long:  `Retrieve detailed information about a Confluence page by its ID`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Retrieve detailed information about a Confluence page by its ID.
+
+With --format markdown the page body is converted to Markdown and printed on its
+own, so it can be edited and written back with page update --content-file.
+
+Examples:
+  atlassian-cli page get 123456
+
+  # Edit a page as Markdown
+  atlassian-cli page get 123456 --format markdown > page.md
+  atlassian-cli page update 123456 --content-file page.md`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pageID := args[0]
 
-			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
-			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
-			}
-
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
-			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
+			if format != FormatStorage && format != FormatMarkdown {
+				return clierr.New(clierr.KindValidation, "invalid --format %q: must be storage or markdown", format)
 			}
 
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+				return err
 			}
 
-			page, err := client.GetPage(context.Background(), pageID)
+			page, err := client.GetPage(cmd.Context(), pageID)
 			if err != nil {
 				return fmt.Errorf("failed to get page: %w", err)
 			}
 
-			return outputPage(cmd, page)
+			return OutputPageBody(cmd, page, format)
 		},
 	}
 
+	cmd.Flags().StringVar(&format, "format", FormatStorage, "Page body format: storage or markdown")
+
 	return cmd
 }
 
@@ -138,9 +187,11 @@ func newListCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
 		spaceKey   string
 		title      string
+		labels     []string
 		maxResults int
 		startAt    int
 		cursor     string
+		all        bool
 	)
 
 	cmd := &cobra.Command{
@@ -155,6 +206,9 @@ Examples:
   # List pages with specific title
   atlassian-cli page list --title "API"
 
+  # List runbooks
+  atlassian-cli page list --label runbook
+
   # Use cursor-based pagination
   atlassian-cli page list --cursor "eyJsaW1pdCI6MjUsIm9mZnNldCI6MjV9"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -171,160 +225,192 @@ Examples:
 				}
 			}
 
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
+				return err
 			}
 
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
-			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+			if len(labels) > 0 {
+				if err := confluence.ValidateLabels(labels); err != nil {
+					return err
+				}
 			}
 
 			opts := &types.PageListOptions{
 				SpaceKey:   resolvedSpace,
 				Title:      title,
+				Labels:     labels,
 				MaxResults: maxResults,
 				StartAt:    startAt,
 				Cursor:     cursor,
 			}
 
-			response, err := client.ListPages(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to list pages: %w", err)
+			// List pages, following the next cursor with --all
+			var response *types.PageListResponse
+			offset := 0
+			pages, streamed, err := cmdutil.FetchPages(cmd, startAt, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Page], error) {
+				if response != nil {
+					opts.Cursor = strconv.Itoa(start)
+				}
+				page, err := client.ListPages(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Page]{}, fmt.Errorf("failed to list pages: %w", err)
+				}
+				if response == nil {
+					offset = page.StartAt
+				}
+				response = page
+				return cmdutil.CursorPage(page.Pages, page.NextCursor), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
+			response.Pages = pages
+			response.StartAt = offset
 			return outputPageList(cmd, response)
 		},
 	}
 
 	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
 	cmd.Flags().StringVar(&title, "title", "", "Filter by title")
+	cmd.Flags().StringSliceVar(&labels, "label", nil, "Filter by label (repeat for pages with all of them)")
 	cmd.Flags().IntVar(&maxResults, "max-results", 25, "Maximum number of results")
 	cmd.Flags().IntVar(&startAt, "start-at", 0, "Starting index for pagination (deprecated, use --cursor)")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for pagination (preferred over --start-at)")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, pageColumns.Names())
 
 	return cmd
 }
 
 func newUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
-		title   string
-		content string
+		title         string
+		content       ContentFlags
+		message       string
+		expectVersion int
+		merge         bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "update <page-id>",
 		Short: "Update a Confluence page",
-		Long:  `Update an existing Confluence page with new values`,
+		Long: `Update an existing Confluence page with new values.
+
+With --expect-version the update fails with a conflict (exit status 6) if someone
+else has saved the page since that version. Adding --merge combines their edits
+with yours instead, three-way against the expected version, and only fails when
+both changed the same part of the page.
+
+Examples:
+  atlassian-cli page update 123456 --title "API Guide v2" --message "Rename for v2"
+
+  # Replace the body with a Markdown file, converted to storage format
+  atlassian-cli page update 123456 --content-file page.md
+
+  # Update only if the page is still at version 7
+  atlassian-cli page update 123456 --content "$(cat page.xml)" --expect-version 7
+
+  # Merge with edits made since version 7
+  atlassian-cli page update 123456 --content "$(cat page.xml)" --expect-version 7 --merge`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			pageID := args[0]
 
-			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
-			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
+			if expectVersion < 0 {
+				return clierr.New(clierr.KindValidation, "--expect-version must not be negative")
 			}
-
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			if merge && expectVersion == 0 {
+				return clierr.New(clierr.KindValidation, "--merge requires --expect-version")
+			}
+			body, err := content.Resolve(cmd)
 			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
+				return err
 			}
 
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+				return err
 			}
 
-			req := &types.UpdatePageRequest{}
+			req := &types.UpdatePageRequest{Message: message, ExpectedVersion: expectVersion}
 			if title != "" {
 				req.Title = &title
 			}
-			if content != "" {
-				req.Content = &content
-			}
+			req.Content = body
 
-			page, err := client.UpdatePage(context.Background(), pageID, req)
+			var page *types.Page
+			if merge {
+				page, err = mergeUpdate(cmd.Context(), client, pageID, req)
+			} else {
+				page, err = client.UpdatePage(cmd.Context(), pageID, req)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to update page: %w", err)
 			}
 
-			return outputPage(cmd, page)
+			return OutputPage(cmd, page)
 		},
 	}
 
 	cmd.Flags().StringVar(&title, "title", "", "New page title")
-	cmd.Flags().StringVar(&content, "content", "", "New page content")
+	AddContentFlags(cmd, &content, "New page content in storage format, or Markdown with --format markdown")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Version message shown in the page history")
+	cmd.Flags().IntVar(&expectVersion, "expect-version", 0, "Fail with a conflict unless the page is still at this version")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge with changes made since --expect-version instead of failing")
 
 	return cmd
 }
 
-func outputPage(cmd *cobra.Command, page *types.Page) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(page)
-	default: // table
-		fmt.Fprintf(cmd.OutOrStdout(), "ID:       %s\n", page.ID)
-		fmt.Fprintf(cmd.OutOrStdout(), "Title:    %s\n", page.Title)
-		fmt.Fprintf(cmd.OutOrStdout(), "Space:    %s\n", page.SpaceKey)
-		fmt.Fprintf(cmd.OutOrStdout(), "Version:  %d\n", page.Version)
-		fmt.Fprintf(cmd.OutOrStdout(), "Updated:  %s\n", page.Updated.Format("2006-01-02 15:04:05"))
-		if page.Content != "" {
-			content := page.Content
-			if len(content) > 100 {
-				content = content[:100] + "..."
-			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Content:  %s\n", content)
-		}
+// OutputPage writes a page as a record, or the whole page in structured formats
+func OutputPage(cmd *cobra.Command, page *types.Page) error {
+	record := output.NewRecord().
+		Field("ID", page.ID).
+		Field("Title", page.Title).
+		Field("Space", page.SpaceKey).
+		Field("Parent ID", page.ParentID).
+		Field("Version", output.FormatValue(page.Version)).
+		Field("Updated", output.FormatValue(page.Updated)).WithKind(output.KindTime)
+	if page.Published != nil {
+		record.Field("Published", PublishDate(*page))
+	}
+	if len(page.Labels) > 0 {
+		record.Field("Labels", strings.Join(page.Labels, ", "))
+	}
+	if page.Content != "" {
+		record.FieldWithWidth("Content", page.Content, 103)
 	}
 
-	return nil
+	return cmdutil.WriteOutput(cmd, page, record)
 }
 
-func outputPageList(cmd *cobra.Command, response *types.PageListResponse) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(response)
-	default: // table
-		if len(response.Pages) == 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "No pages found\n")
-			return nil
-		}
-
-		fmt.Fprintf(cmd.OutOrStdout(), "%-15s %-50s %-10s %-8s\n",
-			"ID", "TITLE", "SPACE", "VERSION")
-		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 83))
-
-		for _, page := range response.Pages {
-			title := page.Title
-			if len(title) > 47 {
-				title = title[:47] + "..."
-			}
+// PublishDate returns the day a blog post is published, or "" if unknown
+func PublishDate(p types.Page) string {
+	if p.Published == nil {
+		return ""
+	}
+	return p.Published.Format("2006-01-02")
+}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "%-15s %-50s %-10s %-8d\n",
-				page.ID, title, page.SpaceKey, page.Version)
-		}
+func outputPageList(cmd *cobra.Command, response *types.PageListResponse) error {
+	table, err := cmdutil.BuildTable(cmd, pageColumns, response.Pages)
+	if err != nil {
+		return err
+	}
+	table.Empty = "No pages found"
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d-%d of %d pages\n",
+	if len(response.Pages) > 0 {
+		table.Footer = fmt.Sprintf("Showing %d-%d of %d pages",
 			response.StartAt+1,
 			response.StartAt+len(response.Pages),
 			response.Total)
 
 		if response.NextCursor != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "\nNext cursor: %s\n", response.NextCursor)
-			fmt.Fprintf(cmd.OutOrStdout(), "Use --cursor \"%s\" to fetch the next page\n", response.NextCursor)
+			table.Footer += fmt.Sprintf("\n\nNext cursor: %s\nUse --cursor \"%s\" to fetch the next page",
+				response.NextCursor, response.NextCursor)
 		}
 	}
 
-	return nil
+	return cmdutil.WriteList(cmd, response, response.Pages, table)
 }
@@ -0,0 +1,33 @@
+package page
+
+import (
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/types"
+	"bytes"
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newPageTestCmd creates a command with confirmation flags writing format output to a buffer
+func newPageTestCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	v := viper.New()
+	v.Set("output", format)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), cmdutil.ViperKey, v))
+	cmdutil.AddConfirmFlags(cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	return cmd, &out
+}
+
+func pageIDs(pages []types.Page) []string {
+	ids := make([]string, len(pages))
+	for i, page := range pages {
+		ids[i] = page.ID
+	}
+	return ids
+}
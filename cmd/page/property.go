@@ -0,0 +1,227 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// propertyDeletion is the outcome of deleting a page property
+type propertyDeletion struct {
+	PageID  string `json:"pageId"`
+	Key     string `json:"key"`
+	Deleted bool   `json:"deleted"`
+}
+
+func newPropertyCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "property",
+		Aliases: []string{"properties"},
+		Short:   "Manage the content properties of Confluence pages",
+		Long: `List, read, set and delete content properties: JSON values stored on a page
+under a key, for metadata such as the owning team or the next review date.
+Properties indexed by a Confluence app can be searched with
+"page search --property".`,
+	}
+
+	cmd.AddCommand(newPropertyListCmd(tokenManager))
+	cmd.AddCommand(newPropertyGetCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newPropertySetCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newPropertyDeleteCmd(tokenManager)))
+
+	return cmd
+}
+
+func newPropertyListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <page-id>",
+		Short: "List the properties of a page",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			properties, err := client.ListProperties(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			table := output.NewTable("Key", "Value", "Version")
+			for _, property := range properties {
+				table.AddRow(property.Key, compactJSON(property.Value), output.FormatValue(property.Version))
+			}
+			table.Empty = "No properties"
+			return cmdutil.WriteList(cmd, properties, properties, table)
+		},
+	}
+
+	return cmd
+}
+
+func newPropertyGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <page-id> <key>",
+		Short: "Show the value of a page property",
+		Long: `Show the value of a page property. Table output prints the JSON value alone,
+indented; JSON and YAML output carry the key and version too.
+
+Examples:
+  atlassian-cli page property get 123456 owner
+  atlassian-cli page property get 123456 owner -o json --query '.value.team'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			property, err := client.GetProperty(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return outputProperty(cmd, property)
+		},
+	}
+
+	return cmd
+}
+
+func newPropertySetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var valueFile string
+
+	cmd := &cobra.Command{
+		Use:   "set <page-id> <key> [<json>]",
+		Short: "Set a page property to a JSON value",
+		Long: `Store a JSON value on a page under key, creating the property or replacing
+its value. The value is given as an argument or read from --value-file ("-" for
+stdin). Strings must be quoted as JSON strings.
+
+Examples:
+  atlassian-cli page property set 123456 owner '{"team": "payments", "service": "checkout"}'
+  atlassian-cli page property set 123456 review-due '"2026-12-01"'
+  atlassian-cli page property set 123456 runbook --value-file runbook-meta.json`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := propertyValue(cmd, args[2:], valueFile)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			property, err := client.SetProperty(cmd.Context(), args[0], args[1], value)
+			if err != nil {
+				return err
+			}
+			return outputProperty(cmd, property)
+		},
+	}
+
+	cmd.Flags().StringVar(&valueFile, "value-file", "", `Read the JSON value from a file ("-" for stdin)`)
+
+	return cmd
+}
+
+func newPropertyDeleteCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete <page-id> <key>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a page property",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			if err := client.DeleteProperty(cmd.Context(), args[0], args[1]); err != nil {
+				return err
+			}
+
+			formatter, err := cmdutil.GetFormatter(cmd)
+			if err != nil {
+				return err
+			}
+			if formatter.IsStructured() {
+				return cmdutil.WriteOutput(cmd, propertyDeletion{PageID: args[0], Key: args[1], Deleted: true}, nil)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted property %s of page %s\n", args[1], args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// propertyValue returns the JSON value given as an argument or in --value-file
+func propertyValue(cmd *cobra.Command, args []string, valueFile string) (json.RawMessage, error) {
+	var data []byte
+	switch {
+	case len(args) > 0 && valueFile != "":
+		return nil, clierr.New(clierr.KindValidation, "give the value as an argument or with --value-file, not both")
+	case len(args) > 0:
+		data = []byte(args[0])
+	case valueFile == "-":
+		var err error
+		if data, err = io.ReadAll(cmd.InOrStdin()); err != nil {
+			return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to read the value from stdin")
+		}
+	case valueFile != "":
+		var err error
+		if data, err = os.ReadFile(valueFile); err != nil {
+			return nil, clierr.Wrap(clierr.KindValidation, err, "failed to read value file")
+		}
+	default:
+		return nil, clierr.New(clierr.KindValidation, "give a JSON value or --value-file")
+	}
+
+	data = bytes.TrimSpace(data)
+	if !json.Valid(data) {
+		return nil, clierr.New(clierr.KindValidation, `the value is not valid JSON; quote strings, e.g. '"payments"'`)
+	}
+	return json.RawMessage(data), nil
+}
+
+// outputProperty writes a property: its indented value for table output, or
+// the whole property in structured formats
+func outputProperty(cmd *cobra.Command, property *types.ContentProperty) error {
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	if formatter.IsStructured() {
+		return cmdutil.WriteOutput(cmd, property, nil)
+	}
+
+	var value bytes.Buffer
+	if err := json.Indent(&value, property.Value, "", "  "); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to format property %s", property.Key)
+	}
+	value.WriteByte('\n')
+	return cmdutil.WriteText(cmd, value.String())
+}
+
+// compactJSON returns a JSON value on one line, as stored if it cannot be parsed
+func compactJSON(value json.RawMessage) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, value); err != nil {
+		return string(value)
+	}
+	return compacted.String()
+}
@@ -0,0 +1,57 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyValue(t *testing.T) {
+	cmd, _ := newPageTestCmd("table")
+
+	value, err := propertyValue(cmd, []string{` {"team": "payments"} `}, "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"team": "payments"}`, string(value))
+
+	file := filepath.Join(t.TempDir(), "meta.json")
+	require.NoError(t, os.WriteFile(file, []byte("[1, 2]\n"), 0o644))
+	value, err = propertyValue(cmd, nil, file)
+	require.NoError(t, err)
+	assert.Equal(t, "[1, 2]", string(value))
+
+	cmd.SetIn(strings.NewReader(`"checkout"`))
+	value, err = propertyValue(cmd, nil, "-")
+	require.NoError(t, err)
+	assert.Equal(t, `"checkout"`, string(value))
+
+	_, err = propertyValue(cmd, []string{"payments"}, "")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+
+	_, err = propertyValue(cmd, []string{"1"}, file)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+
+	_, err = propertyValue(cmd, nil, "")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestOutputProperty(t *testing.T) {
+	property := &types.ContentProperty{ID: "1", Key: "owner", Value: json.RawMessage(`{"team":"payments"}`), Version: 2}
+
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, outputProperty(cmd, property))
+	assert.Equal(t, "{\n  \"team\": \"payments\"\n}\n", out.String())
+
+	cmd, out = newPageTestCmd("json")
+	require.NoError(t, outputProperty(cmd, property))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, decoded["value"])
+	assert.Equal(t, float64(2), decoded["version"])
+}
@@ -0,0 +1,77 @@
+package page
+
+import (
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restrictionClient serves and records a page's restrictions
+type restrictionClient struct {
+	confluence.ConfluenceClient
+	restrictions []types.Grant
+	sets         int
+}
+
+func (c *restrictionClient) GetRestrictions(ctx context.Context, pageID string) ([]types.Grant, error) {
+	return c.restrictions, nil
+}
+
+func (c *restrictionClient) SetRestrictions(ctx context.Context, pageID string, grants []types.Grant) error {
+	c.restrictions = grants
+	c.sets++
+	return nil
+}
+
+func onlyRead(current []types.Grant) []types.Grant {
+	var desired []types.Grant
+	for _, grant := range current {
+		if grant.Operation == confluence.RestrictionRead {
+			desired = append(desired, grant)
+		}
+	}
+	return append(desired, restrictionGrants(confluence.RestrictionUpdate, []string{"5b10ac8d"}, nil)...)
+}
+
+func newRestrictionClient() *restrictionClient {
+	return &restrictionClient{restrictions: []types.Grant{
+		{Operation: confluence.RestrictionRead, SubjectType: confluence.SubjectGroup, SubjectID: "ops", Subject: "ops"},
+		{Operation: confluence.RestrictionUpdate, SubjectType: confluence.SubjectGroup, SubjectID: "ops", Subject: "ops"},
+	}}
+}
+
+func TestChangeRestrictions_DryRunPrintsDiff(t *testing.T) {
+	client := newRestrictionClient()
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+
+	require.NoError(t, changeRestrictions(cmd, client, "123", onlyRead))
+	assert.Equal(t, "- update group ops\n+ update user 5b10ac8d\n", out.String())
+	assert.Zero(t, client.sets)
+}
+
+func TestChangeRestrictions_Applies(t *testing.T) {
+	client := newRestrictionClient()
+	cmd, out := newPageTestCmd("json")
+	require.NoError(t, cmd.Flags().Set("yes", "true"))
+
+	require.NoError(t, changeRestrictions(cmd, client, "123", onlyRead))
+	assert.Equal(t, 1, client.sets)
+	assert.Len(t, client.restrictions, 2)
+
+	var change restrictionChange
+	require.NoError(t, json.Unmarshal(out.Bytes(), &change))
+	assert.True(t, change.Applied)
+	assert.Len(t, change.Changes, 2)
+
+	// Nothing to do the second time
+	cmd, out = newPageTestCmd("table")
+	require.NoError(t, changeRestrictions(cmd, client, "123", onlyRead))
+	assert.Equal(t, "No changes\n", out.String())
+	assert.Equal(t, 1, client.sets)
+}
@@ -3,7 +3,9 @@ package page
 import (
 	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
 	"atlassian-cli/internal/types"
 	"context"
 	"fmt"
@@ -15,12 +17,15 @@ import (
 // newSearchCmd creates the page search command
 func newSearchCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
-		space    string
-		cql      string
-		text     string
-		title    string
-		pageType string
-		limit    int
+		space      string
+		cql        string
+		text       string
+		title      string
+		pageType   string
+		labels     []string
+		properties []string
+		limit      int
+		all        bool
 	)
 
 	cmd := &cobra.Command{
@@ -36,55 +41,62 @@ Examples:
   atlassian-cli page search --space DEV --text "documentation"
   
   # Search by title in default space
-  atlassian-cli page search --title "API Guide"`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			// Load configuration
-			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
-			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
-			}
+  atlassian-cli page search --title "API Guide"
 
-			// Get credentials
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
-			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
-			}
+  # Pages labelled both runbook and database
+  atlassian-cli page search --label runbook --label database
 
-			// Get Confluence client from factory
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+  # Pages by indexed content property
+  atlassian-cli page search --property owner.team=payments --property 'review.due<2026-12-01'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+				return err
 			}
 
 			// Build CQL query
 			var finalCQL string
+			if cql != "" && len(labels) > 0 {
+				return clierr.New(clierr.KindValidation, "--label cannot be combined with --cql; add label = \"name\" to the query instead")
+			}
+			if cql != "" && len(properties) > 0 {
+				return clierr.New(clierr.KindValidation, "--property cannot be combined with --cql; add content.property[key].alias = \"value\" to the query instead")
+			}
 			if cql != "" {
 				finalCQL = cql
 			} else {
-				finalCQL, err = buildCQLFromFilters(cmd, space, text, title, pageType)
+				finalCQL, err = buildCQLFromFilters(cmd, space, text, title, pageType, labels, properties)
 				if err != nil {
 					return err
 				}
 			}
 
-			// Search pages
+			// Search pages, following pagination with --all
 			opts := &types.PageSearchOptions{
 				CQL:        finalCQL,
 				MaxResults: limit,
 				StartAt:    0,
 			}
 
-			response, err := client.SearchPages(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to search pages: %w", err)
+			var response *types.PageSearchResponse
+			pages, streamed, err := cmdutil.FetchPages(cmd, 0, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Page], error) {
+				opts.StartAt = start
+				page, err := client.SearchPages(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Page]{}, fmt.Errorf("failed to search pages: %w", err)
+				}
+				response = page
+				return cmdutil.OffsetPage(page.Pages, page.StartAt, page.Total), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
 			// Convert SearchResponse to ListResponse for output
 			listResponse := &types.PageListResponse{
-				Pages:      response.Pages,
+				Pages:      pages,
 				Total:      response.Total,
-				StartAt:    response.StartAt,
+				StartAt:    0,
 				MaxResults: response.MaxResults,
 			}
 
@@ -98,13 +110,17 @@ Examples:
 	cmd.Flags().StringVar(&text, "text", "", "search in page content")
 	cmd.Flags().StringVar(&title, "title", "", "search in page title")
 	cmd.Flags().StringVar(&pageType, "type", "", "filter by content type (page, blogpost)")
+	cmd.Flags().StringSliceVar(&labels, "label", nil, "only pages with this label (repeat for pages with all of them)")
+	cmd.Flags().StringArrayVar(&properties, "property", nil, "only pages whose indexed property matches, as key.alias=value (also !=, <, <=, >, >=, ~; repeatable)")
 	cmd.Flags().IntVar(&limit, "limit", 25, "maximum results to return")
+	cmd.Flags().BoolVar(&all, "all", false, "fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, pageColumns.Names())
 
 	return cmd
 }
 
 // buildCQLFromFilters constructs a CQL query from individual filter parameters
-func buildCQLFromFilters(cmd *cobra.Command, space, text, title, pageType string) (string, error) {
+func buildCQLFromFilters(cmd *cobra.Command, space, text, title, pageType string, labels, properties []string) (string, error) {
 	var conditions []string
 
 	// Resolve space if not provided
@@ -118,12 +134,12 @@ func buildCQLFromFilters(cmd *cobra.Command, space, text, title, pageType string
 
 	// Add space condition
 	if space != "" {
-		conditions = append(conditions, fmt.Sprintf("space = %s", space))
+		conditions = append(conditions, "space = "+confluence.CQLString(space))
 	}
 
 	// Add content type condition
 	if pageType != "" {
-		conditions = append(conditions, fmt.Sprintf("type = %s", pageType))
+		conditions = append(conditions, "type = "+confluence.CQLString(pageType))
 	} else {
 		// Default to pages only
 		conditions = append(conditions, "type = page")
@@ -131,12 +147,29 @@ func buildCQLFromFilters(cmd *cobra.Command, space, text, title, pageType string
 
 	// Add text search condition
 	if text != "" {
-		conditions = append(conditions, fmt.Sprintf("text ~ \"%s\"", text))
+		conditions = append(conditions, "text ~ "+confluence.CQLString(text))
 	}
 
 	// Add title search condition
 	if title != "" {
-		conditions = append(conditions, fmt.Sprintf("title ~ \"%s\"", title))
+		conditions = append(conditions, "title ~ "+confluence.CQLString(title))
+	}
+
+	// Add label conditions; pages must have every label
+	if len(labels) > 0 {
+		if err := confluence.ValidateLabels(labels); err != nil {
+			return "", err
+		}
+		conditions = append(conditions, confluence.LabelCQL(labels))
+	}
+
+	// Add content property conditions; pages must match every filter
+	if len(properties) > 0 {
+		condition, err := confluence.PropertyCQL(properties)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, condition)
 	}
 
 	if len(conditions) == 0 {
@@ -95,3 +95,33 @@ func TestPageSearchCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCQLFromFilters_Labels(t *testing.T) {
+	cql, err := buildCQLFromFilters(&cobra.Command{}, "OPS", "", "", "", []string{"runbook", "database"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `space = "OPS" AND type = page AND label = "runbook" AND label = "database" ORDER BY lastModified DESC`, cql)
+
+	_, err = buildCQLFromFilters(&cobra.Command{}, "OPS", "", "", "", []string{"has space"}, nil)
+	assert.Error(t, err)
+}
+
+func TestBuildCQLFromFilters_Properties(t *testing.T) {
+	cql, err := buildCQLFromFilters(&cobra.Command{}, "OPS", "", "", "", nil, []string{"owner.team=payments"})
+	assert.NoError(t, err)
+	assert.Equal(t, `space = "OPS" AND type = page AND content.property[owner].team = "payments" ORDER BY lastModified DESC`, cql)
+
+	_, err = buildCQLFromFilters(&cobra.Command{}, "OPS", "", "", "", nil, []string{"owner"})
+	assert.Error(t, err)
+}
+
+func TestBuildCQLFromFilters_EscapesText(t *testing.T) {
+	cql, err := buildCQLFromFilters(&cobra.Command{}, "OPS", `say "hi" \o/`, `x" OR space = "HR`, "", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `space = "OPS" AND type = page AND text ~ "say \"hi\" \\o/" AND title ~ "x\" OR space = \"HR" ORDER BY lastModified DESC`, cql)
+}
+
+func TestBuildCQLFromFilters_QuotesSpaceAndType(t *testing.T) {
+	cql, err := buildCQLFromFilters(&cobra.Command{}, `OPS OR space = HR`, "", "", "blogpost", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `space = "OPS OR space = HR" AND type = "blogpost" ORDER BY lastModified DESC`, cql)
+}
@@ -0,0 +1,203 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/docsync"
+	"atlassian-cli/internal/output"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// syncResult reports the changes a sync made or would make
+type syncResult struct {
+	DryRun  bool              `json:"dryRun"`
+	Changes []*docsync.Change `json:"changes"`
+}
+
+func newSyncCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey  string
+		rootID    string
+		stateFile string
+		prune     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync <directory>",
+		Short: "Publish a directory of Markdown files as a page tree",
+		Long: `Publish a directory of Markdown files to Confluence, one page per file.
+
+The directory tree becomes a page tree under --root (default: the space's
+homepage). Each subdirectory is a page whose content comes from its index.md,
+_index.md or README.md, or lists its children when it has none; the top
+directory's index file updates the root page itself. Titles come from a
+"title" in front matter, a leading "# Title" heading, or the file name.
+
+Pages are matched to files by "confluence_page_id" in front matter or by the
+state file (default: .confluence-sync.json in the directory), which is written
+after each sync. Pages whose content and images have not changed since are
+skipped; pages whose directory changed are moved.
+
+Relative links to other synced files become page links, and local images are
+uploaded as attachments. With --prune, pages under the root that no file maps
+to are moved to the trash.
+
+Examples:
+  # See what a sync would change
+  atlassian-cli page sync ./docs --space ENG --root 123456 --dry-run
+
+  # Publish, trashing pages whose files were removed
+  atlassian-cli page sync ./docs --space ENG --root 123456 --prune --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+			resolvedSpace, err := config.ResolveSpace(cmd)
+			if err != nil {
+				return err
+			}
+
+			root, err := docsync.Scan(dir)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			if rootID == "" {
+				homepage, err := client.GetSpaceHomepage(cmd.Context(), resolvedSpace)
+				if err != nil {
+					return err
+				}
+				rootID = homepage.ID
+			}
+			cmdutil.SetAuditTarget(cmd, rootID)
+
+			if stateFile == "" {
+				stateFile = filepath.Join(dir, docsync.StateFile)
+			}
+			state, err := docsync.LoadState(stateFile, resolvedSpace, rootID)
+			if err != nil {
+				return err
+			}
+
+			opts := docsync.Options{Dir: dir, Space: resolvedSpace, RootID: rootID, Prune: prune}
+			return syncDocs(cmd, client, root, state, opts, stateFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+	cmd.Flags().StringVar(&rootID, "root", "", "Page the directory is published under (default: the space homepage)")
+	cmd.Flags().StringVar(&stateFile, "state", "", "State file (default: .confluence-sync.json in the directory)")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Trash pages under the root that no file maps to")
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// syncDocs plans a sync and, unless --dry-run is given, applies it and saves the state
+func syncDocs(cmd *cobra.Command, client confluence.ConfluenceClient, root *docsync.Doc, state *docsync.State, opts docsync.Options, stateFile string) error {
+	plan, err := docsync.NewPlan(cmd.Context(), client, root, state, opts)
+	if err != nil {
+		return err
+	}
+
+	result := syncResult{DryRun: cmdutil.IsDryRun(cmd), Changes: plan.Changes}
+	if result.DryRun {
+		return outputSyncResult(cmd, result)
+	}
+
+	if deletes := countChanges(plan.Changes, docsync.ActionDelete); deletes > 0 {
+		if err := cmdutil.Confirm(cmd, fmt.Sprintf("Move %d %s with no matching file to the trash?", deletes, pluralPages(deletes))); err != nil {
+			return err
+		}
+	}
+
+	newState, applyErr := plan.Apply(cmd.Context(), client)
+	if err := newState.Save(stateFile); err != nil {
+		return err
+	}
+	if err := outputSyncResult(cmd, result); err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// outputSyncResult lists each page's change with a count of each action
+func outputSyncResult(cmd *cobra.Command, result syncResult) error {
+	table := output.NewTable("Action", "Path", "ID", "Title", "Details")
+	failed := 0
+	for _, change := range result.Changes {
+		table.AddRow(change.Action, change.Path, change.PageID, change.Title, describeChange(change))
+		if change.Error != "" {
+			failed++
+		}
+	}
+	table.Empty = "No pages to sync"
+
+	var counts []string
+	for _, action := range []string{docsync.ActionCreate, docsync.ActionUpdate, docsync.ActionUnchanged, docsync.ActionDelete} {
+		if n := countChanges(result.Changes, action); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, action))
+		}
+	}
+	if failed > 0 {
+		counts = append(counts, fmt.Sprintf("%d failed", failed))
+	}
+	if len(counts) > 0 {
+		table.Footer = strings.Join(counts, ", ")
+		if result.DryRun {
+			table.Footer = "Dry run: " + table.Footer
+		}
+	}
+
+	return cmdutil.WriteList(cmd, result, result.Changes, table)
+}
+
+// describeChange summarizes what a change does beyond its action
+func describeChange(change *docsync.Change) string {
+	if change.Error != "" {
+		return "error: " + change.Error
+	}
+	var details []string
+	if change.Content && change.Action == docsync.ActionUpdate {
+		details = append(details, "content")
+	}
+	if change.Moved {
+		details = append(details, "moved")
+	}
+	switch n := len(change.Attachments); {
+	case n == 1:
+		details = append(details, "1 image")
+	case n > 1:
+		details = append(details, fmt.Sprintf("%d images", n))
+	}
+	return strings.Join(details, ", ")
+}
+
+// countChanges counts the changes with an action
+func countChanges(changes []*docsync.Change, action string) int {
+	n := 0
+	for _, change := range changes {
+		if change.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// pluralPages returns "page" or "pages" to follow a count
+func pluralPages(n int) string {
+	if n == 1 {
+		return "page"
+	}
+	return "pages"
+}
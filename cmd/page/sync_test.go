@@ -0,0 +1,29 @@
+package page
+
+import (
+	"atlassian-cli/internal/docsync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputSyncResult(t *testing.T) {
+	result := syncResult{DryRun: true, Changes: []*docsync.Change{
+		{Action: docsync.ActionUnchanged, Path: ".", PageID: "1", Title: "Home"},
+		{Action: docsync.ActionUpdate, Path: "guide.md", PageID: "2", Title: "Guide", Content: true, Moved: true, Attachments: []string{"a.png", "b.png"}},
+		{Action: docsync.ActionCreate, Path: "new.md", Title: "New", Content: true},
+		{Action: docsync.ActionDelete, PageID: "3", Title: "Old", Error: "forbidden"},
+	}}
+
+	cmd, out := newPageTestCmd("table")
+	require.NoError(t, outputSyncResult(cmd, result))
+	assert.Contains(t, out.String(), "content, moved, 2 images")
+	assert.Contains(t, out.String(), "error: forbidden")
+	assert.Contains(t, out.String(), "Dry run: 1 create, 1 update, 1 unchanged, 1 delete, 1 failed")
+}
+
+func TestDescribeChange(t *testing.T) {
+	assert.Equal(t, "", describeChange(&docsync.Change{Action: docsync.ActionCreate, Content: true}))
+	assert.Equal(t, "1 image", describeChange(&docsync.Change{Action: docsync.ActionUpdate, Attachments: []string{"a.png"}}))
+}
@@ -0,0 +1,151 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/markdown"
+	"atlassian-cli/internal/types"
+	"bytes"
+	"context"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// templateFlags holds the flags that create a page from a template
+type templateFlags struct {
+	template string
+	file     string
+	vars     []string
+}
+
+// addTemplateFlags adds --template, --template-file and --var to a command
+// that already has the content flags
+func addTemplateFlags(cmd *cobra.Command, flags *templateFlags) {
+	cmd.Flags().StringVar(&flags.template, "template", "", "Name or ID of a Confluence template to create the page from")
+	cmd.Flags().StringVar(&flags.file, "template-file", "", "Local Go template file to create the page from")
+	cmd.Flags().StringArrayVar(&flags.vars, "var", nil, "Template variable as key=value (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("template", "template-file", "content", "content-file")
+}
+
+// given reports whether a template was asked for
+func (f *templateFlags) given() bool {
+	return f.template != "" || f.file != ""
+}
+
+// values parses the --var flags
+func (f *templateFlags) values() (map[string]string, error) {
+	if len(f.vars) > 0 && !f.given() {
+		return nil, clierr.New(clierr.KindValidation, "--var needs --template or --template-file")
+	}
+	values := make(map[string]string, len(f.vars))
+	for _, v := range f.vars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, clierr.New(clierr.KindValidation, "invalid --var %q: use key=value", v)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// resolve returns the page body in storage format from the Confluence template
+// or the local template file, filled in with values. format is the --format of
+// a local file, as for --content-file.
+func (f *templateFlags) resolve(ctx context.Context, client confluence.ConfluenceClient, spaceKey, format string, values map[string]string) (string, error) {
+	if f.file != "" {
+		return renderTemplateFile(f.file, format, values)
+	}
+
+	tmpl, err := FindTemplate(ctx, client, spaceKey, f.template)
+	if err != nil {
+		return "", err
+	}
+	return confluence.FillTemplate(tmpl.Content, values)
+}
+
+// renderTemplateFile executes a local Go template with values, so {{.service}}
+// is replaced with the value of --var service=.... Values are escaped for
+// storage-format templates; Markdown templates are converted after filling in.
+func renderTemplateFile(path, format string, values map[string]string) (string, error) {
+	format, err := contentFormat(format, path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", clierr.Wrap(clierr.KindValidation, err, "failed to read template file")
+	}
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", clierr.Wrap(clierr.KindValidation, err, "invalid template file %s", path)
+	}
+
+	if format == FormatStorage {
+		escaped := make(map[string]string, len(values))
+		for key, value := range values {
+			escaped[key] = html.EscapeString(value)
+		}
+		values = escaped
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, values); err != nil {
+		return "", clierr.Wrap(clierr.KindValidation, err, "failed to fill in template file %s", path)
+	}
+
+	if format == FormatMarkdown {
+		return markdown.ToStorage(body.String()), nil
+	}
+	return body.String(), nil
+}
+
+// FindTemplate finds a template by ID, or by name among the templates of the
+// space and then the global templates, and returns it with its body
+func FindTemplate(ctx context.Context, client confluence.ConfluenceClient, spaceKey, nameOrID string) (*types.Template, error) {
+	if _, err := strconv.ParseUint(nameOrID, 10, 64); err == nil {
+		return client.GetTemplate(ctx, nameOrID)
+	}
+
+	scopes := []string{""}
+	if spaceKey != "" {
+		scopes = []string{spaceKey, ""}
+	}
+	for _, scope := range scopes {
+		templates, err := client.ListTemplates(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		var matches []types.Template
+		for _, tmpl := range templates {
+			if strings.EqualFold(tmpl.Name, nameOrID) {
+				matches = append(matches, tmpl)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return client.GetTemplate(ctx, matches[0].ID)
+		}
+		return nil, clierr.New(clierr.KindValidation, "%d templates in %s are named %q; give the template ID instead",
+			len(matches), TemplateScope(scope), nameOrID)
+	}
+
+	if spaceKey == "" {
+		return nil, clierr.New(clierr.KindNotFound, "no global template is named %q", nameOrID)
+	}
+	return nil, clierr.New(clierr.KindNotFound, "no template in space %s or the global templates is named %q", spaceKey, nameOrID)
+}
+
+// TemplateScope names where a template lives: its space, or "global"
+func TemplateScope(spaceKey string) string {
+	if spaceKey == "" {
+		return "global"
+	}
+	return spaceKey
+}
@@ -0,0 +1,100 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// templateClient serves templates by space key, "" for the global ones
+type templateClient struct {
+	confluence.ConfluenceClient
+	templates map[string][]types.Template
+}
+
+func (c *templateClient) ListTemplates(ctx context.Context, spaceKey string) ([]types.Template, error) {
+	return c.templates[spaceKey], nil
+}
+
+func (c *templateClient) GetTemplate(ctx context.Context, id string) (*types.Template, error) {
+	for _, templates := range c.templates {
+		for _, tmpl := range templates {
+			if tmpl.ID == id {
+				return &tmpl, nil
+			}
+		}
+	}
+	return nil, clierr.New(clierr.KindNotFound, "template %s not found", id)
+}
+
+func TestFindTemplate(t *testing.T) {
+	client := &templateClient{templates: map[string][]types.Template{
+		"":    {{ID: "1", Name: "Postmortem"}, {ID: "2", Name: "ADR"}},
+		"ENG": {{ID: "10", Name: "Postmortem", SpaceKey: "ENG"}, {ID: "11", Name: "Runbook", SpaceKey: "ENG"}, {ID: "12", Name: "runbook", SpaceKey: "ENG"}},
+	}}
+	ctx := context.Background()
+
+	// Space templates come before global ones of the same name
+	tmpl, err := FindTemplate(ctx, client, "ENG", "postmortem")
+	require.NoError(t, err)
+	assert.Equal(t, "10", tmpl.ID)
+
+	tmpl, err = FindTemplate(ctx, client, "", "Postmortem")
+	require.NoError(t, err)
+	assert.Equal(t, "1", tmpl.ID)
+
+	tmpl, err = FindTemplate(ctx, client, "ENG", "ADR")
+	require.NoError(t, err)
+	assert.Equal(t, "2", tmpl.ID)
+
+	tmpl, err = FindTemplate(ctx, client, "OPS", "11")
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook", tmpl.Name)
+
+	_, err = FindTemplate(ctx, client, "ENG", "Runbook")
+	assert.EqualError(t, err, `2 templates in ENG are named "Runbook"; give the template ID instead`)
+
+	_, err = FindTemplate(ctx, client, "ENG", "Onboarding")
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+}
+
+func TestRenderTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	markdownFile := filepath.Join(dir, "adr.md")
+	require.NoError(t, os.WriteFile(markdownFile, []byte("# ADR: {{.title}}\n"), 0o644))
+	storageFile := filepath.Join(dir, "adr.xml")
+	require.NoError(t, os.WriteFile(storageFile, []byte("<h1>ADR: {{.title}}</h1>"), 0o644))
+
+	body, err := renderTemplateFile(markdownFile, "", map[string]string{"title": "Use Postgres"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "<h1>ADR: Use Postgres</h1>")
+
+	body, err = renderTemplateFile(storageFile, "", map[string]string{"title": "Q&A"})
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>ADR: Q&amp;A</h1>", body)
+
+	_, err = renderTemplateFile(storageFile, "", map[string]string{})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestTemplateFlagsValues(t *testing.T) {
+	flags := templateFlags{template: "Postmortem", vars: []string{"service=checkout", "query=a=b", "empty="}}
+	values, err := flags.values()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"service": "checkout", "query": "a=b", "empty": ""}, values)
+
+	flags = templateFlags{template: "Postmortem", vars: []string{"service"}}
+	_, err = flags.values()
+	assert.EqualError(t, err, `invalid --var "service": use key=value`)
+
+	flags = templateFlags{vars: []string{"service=checkout"}}
+	_, err = flags.values()
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
@@ -0,0 +1,363 @@
+package page
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// pageOperation describes a change applied to a set of pages, as reported by
+// delete, restore and purge
+type pageOperation struct {
+	Action string              `json:"action"`
+	DryRun bool                `json:"dryRun"`
+	Pages  []types.Page        `json:"pages"`
+	Result *cmdutil.BulkResult `json:"result,omitempty"`
+}
+
+func newDeleteCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <page-id>",
+		Short: "Move a Confluence page to the trash",
+		Long: `Move a Confluence page to its space's trash. Trashed pages can be brought back
+with "page restore" until they are purged.
+
+Without --recursive the page's children are kept and move up to its parent. With
+--recursive every descendant is trashed as well, deepest pages first.
+
+Examples:
+  # Trash a page after confirming
+  atlassian-cli page delete 123456
+
+  # See which pages a recursive delete would trash
+  atlassian-cli page delete 123456 --recursive --dry-run
+
+  # Trash a page tree without prompting
+  atlassian-cli page delete 123456 --recursive --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			pages, err := pagesToDelete(cmd.Context(), client, args[0], recursive)
+			if err != nil {
+				return err
+			}
+
+			return applyToPages(cmd, "delete", pages,
+				fmt.Sprintf("Move %s to the trash?", describePages(pages)),
+				client.DeletePage)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Also trash every descendant page")
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// pagesToDelete returns the page and, when recursive, its descendants in the order
+// they must be trashed: children before their parents
+func pagesToDelete(ctx context.Context, client confluence.ConfluenceClient, id string, recursive bool) ([]types.Page, error) {
+	page, err := client.GetPage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if !recursive {
+		return []types.Page{*page}, nil
+	}
+
+	descendants, err := client.ListDescendants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Descendants come parents first, so reversing them puts the deepest pages first
+	ordered := make([]types.Page, 0, len(descendants)+1)
+	for i := len(descendants) - 1; i >= 0; i-- {
+		ordered = append(ordered, descendants[i])
+	}
+	return append(ordered, *page), nil
+}
+
+func newTrashCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Inspect the trash of a Confluence space",
+	}
+
+	cmd.AddCommand(newTrashListCmd(tokenManager))
+
+	return cmd
+}
+
+func newTrashListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey   string
+		title      string
+		maxResults int
+		cursor     string
+		all        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List trashed pages in a space",
+		Long: `List the pages in a Confluence space's trash.
+
+Examples:
+  # List the trash of the default space
+  atlassian-cli page trash list
+
+  # List every trashed page in a space
+  atlassian-cli page trash list --space DEV --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolvedSpace, err := config.ResolveSpace(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			opts := &types.PageListOptions{
+				SpaceKey:   resolvedSpace,
+				Title:      title,
+				MaxResults: maxResults,
+				Cursor:     cursor,
+			}
+
+			// List the trash, following the next cursor with --all
+			var response *types.PageListResponse
+			offset := 0
+			pages, streamed, err := cmdutil.FetchPages(cmd, 0, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Page], error) {
+				if response != nil {
+					opts.Cursor = strconv.Itoa(start)
+				}
+				page, err := client.ListTrash(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Page]{}, err
+				}
+				if response == nil {
+					offset = page.StartAt
+				}
+				response = page
+				return cmdutil.CursorPage(page.Pages, page.NextCursor), nil
+			})
+			if err != nil || streamed {
+				return err
+			}
+
+			response.Pages = pages
+			response.StartAt = offset
+			return outputPageList(cmd, response)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+	cmd.Flags().StringVar(&title, "title", "", "Filter by title")
+	cmd.Flags().IntVar(&maxResults, "max-results", 25, "Maximum number of results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for pagination")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, pageColumns.Names())
+
+	return cmd
+}
+
+func newRestoreCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <page-id>",
+		Short: "Restore a page from the trash",
+		Long: `Restore a trashed Confluence page to its space.
+
+Examples:
+  atlassian-cli page restore 123456
+  atlassian-cli page restore 123456 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			if dryRun {
+				page, err := client.GetTrashedPage(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+				return outputPageOperation(cmd, pageOperation{Action: "restore", DryRun: true, Pages: []types.Page{*page}})
+			}
+
+			page, err := client.RestorePage(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return OutputPage(cmd, page)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the page that would be restored without restoring it")
+
+	return cmd
+}
+
+func newPurgeCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		spaceKey string
+		all      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge [page-id...]",
+		Short: "Permanently delete trashed pages",
+		Long: `Permanently delete pages from the trash. Purged pages cannot be restored.
+
+Examples:
+  # Purge specific trashed pages
+  atlassian-cli page purge 123456 123457
+
+  # Preview emptying the trash of a space
+  atlassian-cli page purge --all --space DEV --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) > 0) {
+				return clierr.New(clierr.KindValidation, "specify page IDs or --all, but not both")
+			}
+
+			var resolvedSpace string
+			if all {
+				var err error
+				if resolvedSpace, err = config.ResolveSpace(cmd); err != nil {
+					return err
+				}
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			pages, err := pagesToPurge(cmd.Context(), client, args, resolvedSpace)
+			if err != nil {
+				return err
+			}
+			if all {
+				cmdutil.SetAuditTarget(cmd, resolvedSpace)
+			} else {
+				cmdutil.SetAuditTarget(cmd, args[0])
+			}
+
+			return applyToPages(cmd, "purge", pages,
+				fmt.Sprintf("Permanently delete %s? This cannot be undone.", describePages(pages)),
+				client.PurgePage)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key for --all (overrides default)")
+	cmd.Flags().BoolVar(&all, "all", false, "Purge every trashed page in the space")
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// pagesToPurge looks up the trashed pages named by ids, or every trashed page in
+// spaceKey when no IDs are given
+func pagesToPurge(ctx context.Context, client confluence.ConfluenceClient, ids []string, spaceKey string) ([]types.Page, error) {
+	var pages []types.Page
+	if len(ids) > 0 {
+		for _, id := range ids {
+			page, err := client.GetTrashedPage(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, *page)
+		}
+		return pages, nil
+	}
+
+	opts := &types.PageListOptions{SpaceKey: spaceKey, MaxResults: 100}
+	for {
+		response, err := client.ListTrash(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, response.Pages...)
+		if response.NextCursor == "" {
+			return pages, nil
+		}
+		opts.Cursor = response.NextCursor
+	}
+}
+
+// applyToPages runs fn on each page after confirmation, or only lists the pages
+// with --dry-run
+func applyToPages(cmd *cobra.Command, action string, pages []types.Page, prompt string, fn func(ctx context.Context, id string) error) error {
+	operation := pageOperation{Action: action, DryRun: cmdutil.IsDryRun(cmd), Pages: pages}
+	if operation.DryRun || len(pages) == 0 {
+		return outputPageOperation(cmd, operation)
+	}
+
+	if err := cmdutil.Confirm(cmd, prompt); err != nil {
+		return err
+	}
+
+	ids := make([]string, len(pages))
+	for i, page := range pages {
+		ids[i] = page.ID
+	}
+	operation.Result = cmdutil.RunBulk(cmd.Context(), ids, fn)
+
+	if err := outputPageOperation(cmd, operation); err != nil {
+		return err
+	}
+	return operation.Result.Err()
+}
+
+// outputPageOperation reports the pages an operation affects or would affect
+func outputPageOperation(cmd *cobra.Command, operation pageOperation) error {
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+
+	if operation.Result != nil && !formatter.IsStructured() {
+		operation.Result.PrintSummary(cmd.OutOrStdout())
+		return nil
+	}
+
+	table := output.NewTable("ID", "Title", "Space", "Status")
+	for _, page := range operation.Pages {
+		table.AddRow(page.ID, page.Title, page.SpaceKey, page.Status)
+	}
+	table.Empty = "No pages to " + operation.Action
+	if operation.DryRun && len(operation.Pages) > 0 {
+		table.Footer = fmt.Sprintf("Dry run: %s would be affected by %s", describePages(operation.Pages), operation.Action)
+	}
+
+	return cmdutil.WriteList(cmd, operation, operation.Pages, table)
+}
+
+// describePages names a single page by title, or counts several
+func describePages(pages []types.Page) string {
+	if len(pages) == 1 {
+		return fmt.Sprintf("page %q (%s)", pages[0].Title, pages[0].ID)
+	}
+	return fmt.Sprintf("%d pages", len(pages))
+}
@@ -0,0 +1,154 @@
+package page
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeClient serves a page and its descendants
+type treeClient struct {
+	confluence.ConfluenceClient
+	pages       map[string]types.Page
+	descendants map[string][]types.Page
+}
+
+func (c *treeClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page, ok := c.pages[id]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "page %s not found", id)
+	}
+	return &page, nil
+}
+
+func (c *treeClient) ListDescendants(ctx context.Context, id string) ([]types.Page, error) {
+	return c.descendants[id], nil
+}
+
+func newTreeClient() *treeClient {
+	return &treeClient{
+		pages: map[string]types.Page{"1": {ID: "1", Title: "Root"}},
+		descendants: map[string][]types.Page{
+			"1": {{ID: "2", Title: "Child"}, {ID: "3", Title: "Other child"}, {ID: "4", Title: "Grandchild"}},
+		},
+	}
+}
+
+// trashClient serves the trashed pages of a space, at most two per call to
+// exercise pagination
+type trashClient struct {
+	confluence.ConfluenceClient
+	trash []types.Page
+}
+
+func (c *trashClient) GetTrashedPage(ctx context.Context, id string) (*types.Page, error) {
+	for _, page := range c.trash {
+		if page.ID == id {
+			return &page, nil
+		}
+	}
+	return nil, clierr.New(clierr.KindNotFound, "page %s is not in the trash", id)
+}
+
+func (c *trashClient) ListTrash(ctx context.Context, opts *types.PageListOptions) (*types.PageListResponse, error) {
+	start := 0
+	if opts.Cursor != "" {
+		start = len(opts.Cursor) // cursors are "x", "xx", ...
+	}
+	end := min(start+min(opts.MaxResults, 2), len(c.trash))
+	response := &types.PageListResponse{Pages: c.trash[start:end], StartAt: start}
+	if end < len(c.trash) {
+		response.NextCursor = strings.Repeat("x", end)
+	}
+	return response, nil
+}
+
+func TestPagesToDelete(t *testing.T) {
+	client := newTreeClient()
+
+	pages, err := pagesToDelete(context.Background(), client, "1", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, pageIDs(pages))
+
+	// Deepest pages come first and the root last
+	pages, err = pagesToDelete(context.Background(), client, "1", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"4", "3", "2", "1"}, pageIDs(pages))
+
+	_, err = pagesToDelete(context.Background(), client, "9", true)
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+}
+
+func TestApplyToPages_DryRun(t *testing.T) {
+	cmd, out := newPageTestCmd("json")
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	pages, _ := pagesToDelete(context.Background(), newTreeClient(), "1", true)
+
+	err := applyToPages(cmd, "delete", pages, "Delete?", func(ctx context.Context, id string) error {
+		t.Fatalf("dry run deleted %s", id)
+		return nil
+	})
+	require.NoError(t, err)
+
+	var operation pageOperation
+	require.NoError(t, json.Unmarshal(out.Bytes(), &operation))
+	assert.True(t, operation.DryRun)
+	assert.Equal(t, []string{"4", "3", "2", "1"}, pageIDs(operation.Pages))
+	assert.Nil(t, operation.Result)
+}
+
+func TestApplyToPages_Confirmed(t *testing.T) {
+	cmd, out := newPageTestCmd("table")
+	cmd.SetIn(strings.NewReader("y\n"))
+	pages := []types.Page{{ID: "2"}, {ID: "1"}}
+
+	var deleted []string
+	err := applyToPages(cmd, "delete", pages, "Move 2 pages to the trash?", func(ctx context.Context, id string) error {
+		deleted = append(deleted, id)
+		if id == "1" {
+			return errors.New("forbidden")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "1 of 2 items failed")
+	assert.Equal(t, []string{"2", "1"}, deleted)
+	assert.Contains(t, out.String(), "Move 2 pages to the trash? [y/N]: ")
+	assert.Contains(t, out.String(), "Completed 1 of 2, 1 failed\n  failed 1: forbidden\n")
+}
+
+func TestApplyToPages_Declined(t *testing.T) {
+	cmd, _ := newPageTestCmd("table")
+	cmd.SetIn(strings.NewReader("n\n"))
+
+	err := applyToPages(cmd, "purge", []types.Page{{ID: "1"}}, "Purge?", func(ctx context.Context, id string) error {
+		t.Fatalf("declined purge removed %s", id)
+		return nil
+	})
+	assert.Equal(t, clierr.KindCanceled, clierr.KindOf(err))
+}
+
+func TestPagesToPurge(t *testing.T) {
+	client := &trashClient{}
+	for _, id := range []string{"1", "2", "3"} {
+		client.trash = append(client.trash, types.Page{ID: id, Status: "trashed"})
+	}
+
+	pages, err := pagesToPurge(context.Background(), client, []string{"3"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3"}, pageIDs(pages))
+
+	_, err = pagesToPurge(context.Background(), client, []string{"9"}, "")
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+
+	pages, err = pagesToPurge(context.Background(), client, nil, "DEV")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, pageIDs(pages))
+}
@@ -0,0 +1,18 @@
+package project
+
+import (
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+)
+
+// projectColumns are the columns available to the project list table
+var projectColumns = output.ColumnSet[types.Project]{
+	Columns: []output.ColumnSpec[types.Project]{
+		{Name: "key", Header: "Key", Value: func(p types.Project) interface{} { return p.Key }},
+		{Name: "name", Header: "Name", MaxWidth: 30, Value: func(p types.Project) interface{} { return p.Name }},
+		{Name: "type", Header: "Type", Value: func(p types.Project) interface{} { return p.ProjectType }},
+		{Name: "description", Header: "Description", MaxWidth: 30, Value: func(p types.Project) interface{} { return p.Description }},
+		{Name: "lead", Header: "Lead", MaxWidth: 20, Wide: true, Value: func(p types.Project) interface{} { return p.Lead }},
+		{Name: "id", Header: "ID", Wide: true, Value: func(p types.Project) interface{} { return p.ID }},
+	},
+}
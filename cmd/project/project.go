@@ -4,11 +4,10 @@ import (
 	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/auth"
 	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/output"
 	"atlassian-cli/internal/types"
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -31,6 +30,7 @@ func newListCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
 		maxResults int
 		startAt    int
+		all        bool
 	)
 
 	cmd := &cobra.Command{
@@ -47,13 +47,13 @@ Examples:
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
@@ -63,17 +63,31 @@ Examples:
 				StartAt:    startAt,
 			}
 
-			response, err := client.ListProjects(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to list projects: %w", err)
+			// List projects, following pagination with --all
+			var response *types.ProjectListResponse
+			projects, streamed, err := cmdutil.FetchPages(cmd, startAt, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Project], error) {
+				opts.StartAt = start
+				page, err := client.ListProjects(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Project]{}, fmt.Errorf("failed to list projects: %w", err)
+				}
+				response = page
+				return cmdutil.OffsetPage(page.Projects, page.StartAt, page.Total), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
+			response.Projects = projects
+			response.StartAt = startAt
 			return outputProjectList(cmd, response)
 		},
 	}
 
 	cmd.Flags().IntVar(&maxResults, "max-results", 50, "Maximum number of results")
 	cmd.Flags().IntVar(&startAt, "start-at", 0, "Starting index for pagination")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, projectColumns.Names())
 
 	return cmd
 }
@@ -92,18 +106,18 @@ func newGetCmd(tokenManager auth.TokenManager) *cobra.Command {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
+			creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
 			if err != nil {
 				return fmt.Errorf("not authenticated: %w", err)
 			}
 
 			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetJiraClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := factory.GetJiraClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
 			if err != nil {
 				return fmt.Errorf("failed to get JIRA client: %w", err)
 			}
 
-			project, err := client.GetProject(context.Background(), projectKey)
+			project, err := client.GetProject(cmd.Context(), projectKey)
 			if err != nil {
 				return fmt.Errorf("failed to get project: %w", err)
 			}
@@ -116,62 +130,29 @@ func newGetCmd(tokenManager auth.TokenManager) *cobra.Command {
 }
 
 func outputProject(cmd *cobra.Command, project *types.Project) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(project)
-	default: // table
-		fmt.Fprintf(cmd.OutOrStdout(), "Key:         %s\n", project.Key)
-		fmt.Fprintf(cmd.OutOrStdout(), "Name:        %s\n", project.Name)
-		fmt.Fprintf(cmd.OutOrStdout(), "Description: %s\n", project.Description)
-		fmt.Fprintf(cmd.OutOrStdout(), "Lead:        %s\n", project.Lead)
-		fmt.Fprintf(cmd.OutOrStdout(), "Type:        %s\n", project.ProjectType)
-	}
-
-	return nil
+	record := output.NewRecord().
+		Field("Key", project.Key).
+		Field("Name", project.Name).
+		Field("Description", project.Description).
+		Field("Lead", project.Lead).
+		Field("Type", project.ProjectType)
+
+	return cmdutil.WriteOutput(cmd, project, record)
 }
 
 func outputProjectList(cmd *cobra.Command, response *types.ProjectListResponse) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(response)
-	default: // table
-		if len(response.Projects) == 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "No projects found\n")
-			return nil
-		}
-
-		fmt.Fprintf(cmd.OutOrStdout(), "%-10s %-30s %-15s %-30s\n",
-			"KEY", "NAME", "TYPE", "DESCRIPTION")
-		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 85))
-
-		for _, project := range response.Projects {
-			name := project.Name
-			if len(name) > 27 {
-				name = name[:27] + "..."
-			}
-
-			description := project.Description
-			if len(description) > 27 {
-				description = description[:27] + "..."
-			}
-
-			fmt.Fprintf(cmd.OutOrStdout(), "%-10s %-30s %-15s %-30s\n",
-				project.Key, name, project.ProjectType, description)
-		}
+	table, err := cmdutil.BuildTable(cmd, projectColumns, response.Projects)
+	if err != nil {
+		return err
+	}
+	table.Empty = "No projects found"
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d-%d of %d projects\n",
+	if len(response.Projects) > 0 {
+		table.Footer = fmt.Sprintf("Showing %d-%d of %d projects",
 			response.StartAt+1,
 			response.StartAt+len(response.Projects),
 			response.Total)
 	}
 
-	return nil
+	return cmdutil.WriteList(cmd, response, response.Projects, table)
 }
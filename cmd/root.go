@@ -2,21 +2,34 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"atlassian-cli/cmd/audit"
 	"atlassian-cli/cmd/auth"
+	"atlassian-cli/cmd/blog"
 	"atlassian-cli/cmd/cache"
 	"atlassian-cli/cmd/config"
 	"atlassian-cli/cmd/issue"
 	"atlassian-cli/cmd/page"
 	"atlassian-cli/cmd/project"
 	"atlassian-cli/cmd/space"
+	"atlassian-cli/cmd/template"
+	auditLog "atlassian-cli/internal/audit"
 	authManager "atlassian-cli/internal/auth"
 	"atlassian-cli/internal/client"
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/output"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -36,6 +49,9 @@ func newRootCmd() *cobra.Command {
 	// Create client factory with connection pooling
 	factory := client.NewFactory()
 
+	// Create token manager with tiered fallback
+	tokenManager := createTokenManager()
+
 	cmd := &cobra.Command{
 		Use:   "atlassian-cli",
 		Short: "Developer toolkit for JIRA and Confluence",
@@ -45,19 +61,36 @@ by providing intuitive access to JIRA and Confluence operations.
 Features:
 • Smart default configuration for projects and spaces
 • Secure credential management with OS keychain integration
-• Multi-format output (JSON, table, YAML)
+• Multi-format output (table, JSON, YAML, CSV, TSV, Markdown)
 • Comprehensive JIRA issue and project management
 • Full Confluence page and space operations
 • Enterprise-grade reliability with caching and retry logic`,
-		Version: version,
+		Version:       version,
+		SilenceErrors: true, // Errors are reported by Execute so they can be emitted as JSON
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Initialize config with local viper instance
 			if err := initializeConfigWithViper(v); err != nil {
 				return err
 			}
+			if format := v.GetString("output"); !output.IsValidFormat(format) {
+				return clierr.New(clierr.KindValidation, "invalid output format %q (must be one of %s)",
+					format, strings.Join(output.Formats, ", "))
+			}
+			// Apply the configured unlock period to passphrase-protected credential files
+			if pm, ok := tokenManager.(authManager.PassphraseManager); ok {
+				pm.SetUnlockTimeout(v.GetDuration("credentials_unlock_timeout"))
+			}
+			// Bound each API request by the configured timeout (0 disables it); the
+			// command context is only canceled by signals, so long-running commands
+			// and time spent at prompts do not count against it
+			factory.SetRequestTimeout(v.GetDuration("timeout"))
+			ctx := cmd.Context()
 			// Store viper and factory in context for subcommands
-			ctx := context.WithValue(cmd.Context(), cmdutil.ViperKey, v)
+			ctx = context.WithValue(ctx, cmdutil.ViperKey, v)
 			ctx = context.WithValue(ctx, cmdutil.FactoryKey, factory)
+			if cmdutil.IsAudited(cmd) {
+				ctx = context.WithValue(ctx, cmdutil.AuditKey, newAuditEvent(cmd, args, v))
+			}
 			cmd.SetContext(ctx)
 			return nil
 		},
@@ -65,33 +98,49 @@ Features:
 			if len(args) == 0 {
 				return cmd.Help()
 			}
-			return fmt.Errorf("unknown command %q", args[0])
+			return clierr.New(clierr.KindValidation, "unknown command %q", args[0])
 		},
 	}
 
+	// Flag parsing errors are usage errors
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return clierr.Wrap(clierr.KindValidation, err, "")
+	})
+
 	// Global persistent flags
 	cmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.atlassian-cli/config.yaml)")
-	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (json, table, yaml)")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (table, json, yaml, csv, tsv, markdown, ndjson, template)")
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "debug output")
+	cmd.PersistentFlags().Duration("timeout", client.DefaultRequestTimeout, "maximum time to wait for each API request (0 disables)")
 	// Global project/space flags removed - use command-specific flags instead
 	cmd.PersistentFlags().Bool("no-color", false, "disable colored output")
+	cmd.PersistentFlags().Bool("no-pager", false, "do not pipe long output through $PAGER")
+	cmd.PersistentFlags().String("template", "", "Go template applied to each result (implies --output template)")
+	cmd.PersistentFlags().String("query", "", "jq-style filter applied before output, e.g. '.issues[].key'")
 
 	// Bind flags to local viper instance
 	v.BindPFlag("output", cmd.PersistentFlags().Lookup("output"))
 	v.BindPFlag("verbose", cmd.PersistentFlags().Lookup("verbose"))
 	v.BindPFlag("debug", cmd.PersistentFlags().Lookup("debug"))
+	v.BindPFlag("timeout", cmd.PersistentFlags().Lookup("timeout"))
+	v.BindPFlag("no_color", cmd.PersistentFlags().Lookup("no-color"))
+	v.BindPFlag("no_pager", cmd.PersistentFlags().Lookup("no-pager"))
+	v.BindPFlag("template", cmd.PersistentFlags().Lookup("template"))
+	v.BindPFlag("query", cmd.PersistentFlags().Lookup("query"))
 	// Viper bindings for global project/space flags removed
 
 	// Add subcommands
-	tokenManager := createTokenManager()
 	cmd.AddCommand(auth.NewAuthCmd(tokenManager))
 	cmd.AddCommand(issue.NewIssueCmd(tokenManager))
 	cmd.AddCommand(project.NewProjectCmd(tokenManager))
 	cmd.AddCommand(page.NewPageCmd(tokenManager))
+	cmd.AddCommand(blog.NewBlogCmd(tokenManager))
+	cmd.AddCommand(template.NewTemplateCmd(tokenManager))
 	cmd.AddCommand(space.NewSpaceCmd(tokenManager))
 	cmd.AddCommand(config.NewConfigCmd())
 	cmd.AddCommand(cache.NewCacheCmd())
+	cmd.AddCommand(audit.NewAuditCmd())
 	cmd.AddCommand(newCompletionCmd())
 
 	return cmd
@@ -99,7 +148,132 @@ Features:
 
 // Execute is the main entry point for the CLI
 func Execute() error {
-	return newRootCmd().Execute()
+	// The first SIGINT/SIGTERM cancels the command context so in-flight requests
+	// are aborted and bulk operations stop cleanly; a second one kills the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	root := newRootCmd()
+	root.SetContext(ctx)
+	executed, err := root.ExecuteC()
+	recordAudit(executed, err)
+	if err != nil {
+		err = classifyUsageError(err)
+		reportError(root.ErrOrStderr(), executed, err)
+	}
+	return err
+}
+
+// ExitCode returns the documented process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	return clierr.ExitCode(err)
+}
+
+// cobraUsageErrors are message prefixes of argument errors raised by cobra itself
+var cobraUsageErrors = []string{
+	"unknown command",
+	"required flag(s)",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+	"invalid argument",
+	"if any flags in the group",
+}
+
+// classifyUsageError marks cobra's own argument validation errors as usage errors
+func classifyUsageError(err error) error {
+	if clierr.KindOf(err) != clierr.KindGeneral {
+		return err
+	}
+	for _, prefix := range cobraUsageErrors {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return clierr.Wrap(clierr.KindValidation, err, "")
+		}
+	}
+	return err
+}
+
+// reportError writes the error to stderr, as a JSON object when JSON output is selected
+func reportError(w io.Writer, cmd *cobra.Command, err error) {
+	format := outputFormat
+	if cmd != nil && cmd.Context() != nil && cmd.Context().Value(cmdutil.ViperKey) != nil {
+		format = cmdutil.GetOutputFormat(cmd)
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(clierr.ToJSON(err))
+		return
+	}
+
+	fmt.Fprintf(w, "Error: %v\n", err)
+}
+
+// newAuditEvent prepares the audit record for a mutating command.
+// Flag values are included with secrets redacted; tokens are never recorded.
+func newAuditEvent(cmd *cobra.Command, args []string, v *viper.Viper) *auditLog.Event {
+	profile := v.GetString("profile")
+	if profile == "" {
+		profile = "default"
+	}
+
+	var recorded []string
+	recorded = append(recorded, args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		recorded = append(recorded, fmt.Sprintf("--%s=%s", f.Name, f.Value.String()))
+	})
+
+	event := &auditLog.Event{
+		User:    v.GetString("email"),
+		Profile: profile,
+		Server:  v.GetString("api_endpoint"),
+		Command: strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Args:    auditLog.RedactArgs(recorded),
+	}
+	if len(args) > 0 {
+		event.Target = args[0]
+	}
+
+	return event
+}
+
+// recordAudit writes the outcome of an audited command to the audit log.
+// Audit failures are reported but never change the command result.
+func recordAudit(cmd *cobra.Command, cmdErr error) {
+	if cmd == nil {
+		return
+	}
+	event := cmdutil.GetAuditEvent(cmd)
+	if event == nil {
+		return
+	}
+
+	v := cmdutil.GetViperFromCmd(cmd)
+	if !v.GetBool("audit_enabled") {
+		return
+	}
+
+	logger, err := auditLog.NewLoggerWithOptions(auditLog.Options{
+		Dir:     v.GetString("audit_dir"),
+		MaxSize: v.GetInt64("audit_max_size_mb") * 1024 * 1024,
+		MaxAge:  v.GetDuration("audit_max_age"),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open audit log: %v\n", err)
+		return
+	}
+	defer logger.Close()
+
+	event.Success = cmdErr == nil
+	if cmdErr != nil {
+		event.Error = cmdErr.Error()
+	}
+	logger.Record(*event)
 }
 
 // initializeConfigWithViper reads in config file and ENV variables with a specific viper instance
@@ -131,6 +305,11 @@ func initializeConfigWithViper(v *viper.Viper) error {
 	v.SetDefault("default_confluence_space", "")
 	v.SetDefault("debug", false)
 	v.SetDefault("verbose", false)
+	v.SetDefault("credentials_unlock_timeout", authManager.DefaultUnlockTimeout)
+	v.SetDefault("audit_enabled", true)
+	v.SetDefault("audit_dir", "")
+	v.SetDefault("audit_max_size_mb", 10)
+	v.SetDefault("audit_max_age", auditLog.DefaultMaxAge)
 
 	// Read config file if it exists
 	if err := v.ReadInConfig(); err != nil {
@@ -199,34 +378,39 @@ PowerShell:
 
 // createTokenManager creates a token manager with tiered fallback
 // Priority: Keychain -> Encrypted File -> Memory
+// Setting ATLASSIAN_CREDENTIAL_STORE=file skips the keychain, e.g. to use passphrase mode
 func createTokenManager() authManager.TokenManager {
-	// Try OS keychain first
-	keychainManager := authManager.NewKeychainTokenManager()
-	
-	// Test if keychain is available by attempting a no-op operation
-	// We try to get a non-existent key to see if keychain access works
-	ctx := context.Background()
-	_, err := keychainManager.Get(ctx, "test-availability")
-	
-	// If the error is "not found", keychain is working
-	// If the error is about platform support or access, keychain is not available
-	if err != nil && err.Error() == "credentials not found for server: test-availability" {
-		// Keychain is available
-		if debug || verbose {
-			fmt.Fprintf(os.Stderr, "Using OS keychain for credential storage\n")
+	if os.Getenv("ATLASSIAN_CREDENTIAL_STORE") != "file" {
+		// Try OS keychain first
+		keychainManager := authManager.NewKeychainTokenManager()
+
+		// Test if keychain is available by attempting a no-op operation
+		// We try to get a non-existent key to see if keychain access works
+		ctx := context.Background()
+		_, err := keychainManager.Get(ctx, "test-availability")
+
+		// If the error is "not found", keychain is working
+		// If the error is about platform support or access, keychain is not available
+		if errors.Is(err, authManager.ErrCredentialsNotFound) {
+			// Keychain is available
+			if debug || verbose {
+				fmt.Fprintf(os.Stderr, "Using OS keychain for credential storage\n")
+			}
+			return keychainManager
 		}
-		return keychainManager
 	}
-	
+
 	// Try encrypted file fallback
 	encryptedManager, err := authManager.NewEncryptedFileTokenManager("")
 	if err == nil {
+		// Prompt for the passphrase when the file is passphrase protected and locked
+		encryptedManager.SetPassphraseFunc(authManager.UnlockPassphrase)
 		if debug || verbose {
 			fmt.Fprintf(os.Stderr, "OS keychain unavailable, using encrypted file storage\n")
 		}
 		return encryptedManager
 	}
-	
+
 	// Fallback to memory (with warning)
 	fmt.Fprintf(os.Stderr, "Warning: Using in-memory credential storage. Credentials will not persist across sessions.\n")
 	fmt.Fprintf(os.Stderr, "         Run 'auth login' in each session to authenticate.\n")
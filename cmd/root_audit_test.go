@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"atlassian-cli/internal/audit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutatingCommandsAreAudited(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ATLASSIAN_AUDIT_DIR", dir)
+
+	root := newRootCmd()
+	var output bytes.Buffer
+	root.SetOut(&output)
+	root.SetErr(&output)
+	root.SetArgs([]string{"auth", "login", "--server", "not-a-url", "--email", "me@example.com", "--token", "secret-token"})
+
+	executed, err := root.ExecuteC()
+	require.Error(t, err)
+	recordAudit(executed, err)
+
+	events, err := audit.Query(dir, audit.Filter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, "auth login", event.Command)
+	assert.False(t, event.Success)
+	assert.NotEmpty(t, event.Error)
+	for _, arg := range event.Args {
+		assert.NotContains(t, arg, "secret-token", "tokens must never be audited")
+	}
+}
+
+func TestReadOnlyCommandsAreNotAudited(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ATLASSIAN_AUDIT_DIR", dir)
+
+	root := newRootCmd()
+	var output bytes.Buffer
+	root.SetOut(&output)
+	root.SetErr(&output)
+	root.SetArgs([]string{"auth", "status", "--server", "https://test.atlassian.net"})
+
+	executed, err := root.ExecuteC()
+	require.NoError(t, err)
+	recordAudit(executed, err)
+
+	events, err := audit.Query(dir, audit.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -2,8 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,3 +74,79 @@ func TestRootCommandFlags(t *testing.T) {
 	assert.NotNil(t, cmd.PersistentFlags().Lookup("verbose"))
 	// Global project/space flags were removed - use command-specific flags instead
 }
+
+func TestReportError(t *testing.T) {
+	err := classifyUsageError(errors.New(`required flag(s) "server" not set`))
+	assert.Equal(t, clierr.ExitValidation, ExitCode(err))
+
+	var text bytes.Buffer
+	reportError(&text, nil, err)
+	assert.Equal(t, "Error: required flag(s) \"server\" not set\n", text.String())
+
+	previous := outputFormat
+	outputFormat = "json"
+	defer func() { outputFormat = previous }()
+
+	var jsonOut bytes.Buffer
+	reportError(&jsonOut, nil, clierr.New(clierr.KindNotFound, "page not found"))
+
+	var payload clierr.JSONError
+	assert.NoError(t, json.Unmarshal(jsonOut.Bytes(), &payload))
+	assert.Equal(t, clierr.KindNotFound, payload.Error.Kind)
+	assert.Equal(t, clierr.ExitNotFound, payload.Error.ExitCode)
+	assert.Equal(t, "page not found", payload.Error.Message)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	t.Setenv("ATLASSIAN_AUDIT_ENABLED", "false")
+
+	tests := []struct {
+		name    string
+		args    []string
+		timeout time.Duration
+	}{
+		{"default timeout", []string{"probe"}, 30 * time.Second},
+		{"explicit timeout", []string{"probe", "--timeout", "5s"}, 5 * time.Second},
+		{"timeout disabled", []string{"probe", "--timeout", "0"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var timeout time.Duration
+			var hasDeadline bool
+
+			root := newRootCmd()
+			root.AddCommand(&cobra.Command{
+				Use: "probe",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					_, hasDeadline = cmd.Context().Deadline()
+					timeout = cmdutil.GetFactory(cmd).RequestTimeout()
+					return nil
+				},
+			})
+			root.SetArgs(tt.args)
+
+			assert.NoError(t, root.Execute())
+			assert.False(t, hasDeadline, "the timeout applies to requests, not the whole command")
+			assert.Equal(t, tt.timeout, timeout)
+		})
+	}
+}
+
+func TestCommandContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root := newRootCmd()
+	root.AddCommand(&cobra.Command{
+		Use: "probe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Context().Err()
+		},
+	})
+	root.SetArgs([]string{"probe"})
+
+	err := root.ExecuteContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, clierr.ExitCanceled, ExitCode(err))
+}
@@ -0,0 +1,309 @@
+package space
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// spaceKeyPattern matches the keys Confluence accepts for new spaces
+var spaceKeyPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// spaceOperation is the outcome of deleting a space, or of a dry run
+type spaceOperation struct {
+	Action string       `json:"action"`
+	DryRun bool         `json:"dryRun"`
+	Space  *types.Space `json:"space"`
+}
+
+func newGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <space-key>",
+		Short: "Get a Confluence space",
+		Long: `Show a space with its description, home page and who may do what in it.
+
+Examples:
+  atlassian-cli space get OPS
+
+  # The groups and users with permissions in the space
+  atlassian-cli space get OPS -o json --query '.permissions[].subject'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			space, err := client.GetSpace(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return outputSpace(cmd, space)
+		},
+	}
+
+	return cmd
+}
+
+func newCreateCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		name        string
+		description string
+		private     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <space-key>",
+		Short: "Create a Confluence space",
+		Long: `Create a space. Keys are letters and digits only; Confluence stores them in
+upper case. With --private only you can see the space until you grant others
+permission.
+
+Examples:
+  atlassian-cli space create OPS --name "Operations" --description "Runbooks and on-call notes"
+
+  # A private sandbox
+  atlassian-cli space create SANDBOX --name "Sandbox" --private`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !spaceKeyPattern.MatchString(args[0]) {
+				return clierr.New(clierr.KindValidation, "invalid space key %q: use letters and digits only", args[0])
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			space, err := client.CreateSpace(cmd.Context(), &types.CreateSpaceRequest{
+				Key:         args[0],
+				Name:        name,
+				Description: description,
+				Private:     private,
+			})
+			if err != nil {
+				return err
+			}
+			return outputSpace(cmd, space)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Space name (required)")
+	cmd.Flags().StringVar(&description, "description", "", "Plain-text description")
+	cmd.Flags().BoolVar(&private, "private", false, "Create a space only you can see")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func newUpdateCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		name        string
+		description string
+		homepageID  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <space-key>",
+		Short: "Update a Confluence space",
+		Long: `Change the name, description or home page of a space. Only the given values
+change; --description "" clears the description.
+
+Examples:
+  atlassian-cli space update OPS --name "Operations & SRE"
+  atlassian-cli space update OPS --homepage-id 123456`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &types.UpdateSpaceRequest{}
+			if cmd.Flags().Changed("name") {
+				req.Name = &name
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &description
+			}
+			if cmd.Flags().Changed("homepage-id") {
+				req.HomepageID = &homepageID
+			}
+			if req.Name == nil && req.Description == nil && req.HomepageID == nil {
+				return clierr.New(clierr.KindValidation, "nothing to update: use --name, --description or --homepage-id")
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			space, err := client.UpdateSpace(cmd.Context(), args[0], req)
+			if err != nil {
+				return err
+			}
+			return outputSpace(cmd, space)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "New space name")
+	cmd.Flags().StringVar(&description, "description", "", "New plain-text description")
+	cmd.Flags().StringVar(&homepageID, "homepage-id", "", "ID of the page to make the space's home page")
+
+	return cmd
+}
+
+func newArchiveCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var restore bool
+
+	cmd := &cobra.Command{
+		Use:   "archive <space-key>",
+		Short: "Archive a Confluence space",
+		Long: `Archive a space, or bring an archived space back with --restore. Archived
+spaces keep their content but are left out of search and the space directory.
+
+Examples:
+  atlassian-cli space archive OLDPROJ
+  atlassian-cli space archive OLDPROJ --restore`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			return archiveSpace(cmd, client, args[0], !restore)
+		},
+	}
+
+	cmd.Flags().BoolVar(&restore, "restore", false, "Restore an archived space")
+
+	return cmd
+}
+
+func newDeleteCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <space-key>",
+		Short: "Delete a Confluence space",
+		Long: `Delete a space with all of its pages, blog posts and attachments. Deleted
+spaces cannot be restored; consider "space archive" instead. Confluence
+finishes removing the content in the background.
+
+Examples:
+  # See what would be deleted
+  atlassian-cli space delete OLDPROJ --dry-run
+
+  # Delete without prompting
+  atlassian-cli space delete OLDPROJ --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			return deleteSpace(cmd, client, args[0])
+		},
+	}
+
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// archiveSpace archives or restores a space and writes the result
+func archiveSpace(cmd *cobra.Command, client confluence.ConfluenceClient, key string, archived bool) error {
+	space, err := client.ArchiveSpace(cmd.Context(), key, archived)
+	if err != nil {
+		return err
+	}
+	return outputSpace(cmd, space)
+}
+
+// deleteSpace deletes a space once confirmed, or only reports it for --dry-run
+func deleteSpace(cmd *cobra.Command, client confluence.ConfluenceClient, key string) error {
+	space, err := client.GetSpace(cmd.Context(), key)
+	if err != nil {
+		return err
+	}
+	operation := spaceOperation{Action: "delete", DryRun: cmdutil.IsDryRun(cmd), Space: space}
+	if operation.DryRun {
+		return outputSpaceOperation(cmd, operation)
+	}
+
+	prompt := fmt.Sprintf("Permanently delete space %s (%s) and all of its content?", space.Key, space.Name)
+	if err := cmdutil.Confirm(cmd, prompt); err != nil {
+		return err
+	}
+	if err := client.DeleteSpace(cmd.Context(), space.Key); err != nil {
+		return err
+	}
+	return outputSpaceOperation(cmd, operation)
+}
+
+// outputSpace writes a single space, with one line per group or user that has
+// permissions in it
+func outputSpace(cmd *cobra.Command, space *types.Space) error {
+	record := output.NewRecord().
+		Field("Key", space.Key).
+		Field("Name", space.Name).
+		Field("ID", space.ID).
+		Field("Type", space.Type).
+		Field("Status", space.Status).
+		FieldWithWidth("Description", space.Description, 80)
+	if space.HomepageID != "" {
+		record.Field("Home Page", fmt.Sprintf("%s (%s)", space.HomepageTitle, space.HomepageID))
+	}
+	for _, permission := range space.Permissions {
+		record.FieldWithWidth(permissionSubject(permission), strings.Join(permission.Operations, ", "), 80)
+	}
+
+	return cmdutil.WriteOutput(cmd, space, record)
+}
+
+// permissionSubject labels a permission summary line, e.g. "Group confluence-users"
+func permissionSubject(permission types.SpacePermission) string {
+	switch permission.SubjectType {
+	case "group":
+		return "Group " + permission.Subject
+	case "user":
+		return "User " + permission.Subject
+	}
+	return "Anonymous"
+}
+
+// outputSpaceOperation reports a space that was or would be deleted
+func outputSpaceOperation(cmd *cobra.Command, operation spaceOperation) error {
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	if formatter.IsStructured() {
+		return cmdutil.WriteOutput(cmd, operation, nil)
+	}
+
+	if operation.DryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "Dry run: space %s (%s) and all of its content would be deleted\n",
+			operation.Space.Key, operation.Space.Name)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted space %s (%s); Confluence removes its content in the background\n",
+		operation.Space.Key, operation.Space.Name)
+	return nil
+}
+
+// checkStatus validates the --status filter of space list
+func checkStatus(status string) error {
+	switch status {
+	case "", confluence.SpaceCurrent, confluence.SpaceArchived:
+		return nil
+	}
+	return clierr.New(clierr.KindValidation, "invalid --status %q: must be %s or %s", status, confluence.SpaceCurrent, confluence.SpaceArchived)
+}
@@ -0,0 +1,19 @@
+package space
+
+import (
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+)
+
+// spaceColumns are the columns available to the space list table
+var spaceColumns = output.ColumnSet[types.Space]{
+	Columns: []output.ColumnSpec[types.Space]{
+		{Name: "key", Header: "Key", Value: func(s types.Space) interface{} { return s.Key }},
+		{Name: "name", Header: "Name", MaxWidth: 30, Value: func(s types.Space) interface{} { return s.Name }},
+		{Name: "type", Header: "Type", Value: func(s types.Space) interface{} { return s.Type }},
+		{Name: "description", Header: "Description", MaxWidth: 30, Value: func(s types.Space) interface{} { return s.Description }},
+		{Name: "status", Header: "Status", Wide: true, Value: func(s types.Space) interface{} { return s.Status }},
+		{Name: "homepage", Header: "Home Page ID", Wide: true, Value: func(s types.Space) interface{} { return s.HomepageID }},
+		{Name: "id", Header: "ID", Wide: true, Value: func(s types.Space) interface{} { return s.ID }},
+	},
+}
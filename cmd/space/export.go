@@ -0,0 +1,77 @@
+package space
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/export"
+
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var (
+		format string
+		outDir string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <space-key>",
+		Short: "Export a space to local files",
+		Long: `Export every page of a space to a local directory as Markdown or HTML, with
+their attachments.
+
+The directory mirrors the page tree: a page with children becomes a directory
+holding its index file, other pages a file named after their title.
+Attachments are downloaded to attachments/<page-id>/, and links between pages
+of the space and to attachments are rewritten as relative paths.
+
+A manifest.json records each page's ID, version and file. Exporting again to
+the same directory only writes pages and attachments that changed, and
+removes the files of pages that were deleted.
+
+Examples:
+  # Keep an offline copy of the runbooks space
+  atlassian-cli space export OPS --out ./ops-runbooks
+
+  # Export as HTML
+  atlassian-cli space export OPS --format html --out ./ops-html`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := export.CheckFormat(format); err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			roots, err := client.ListRootPages(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			tree, err := confluence.FetchTree(cmd.Context(), client, roots, 0)
+			if err != nil {
+				return err
+			}
+
+			opts := export.Options{Dir: outDir, Format: format}
+			results, exportErr := export.Run(cmd.Context(), client, tree, "space:"+args[0], opts)
+			if results == nil {
+				return exportErr
+			}
+			result := &export.Result{Dir: outDir, Format: format, Pages: results}
+			if err := cmdutil.WriteList(cmd, result, result.Pages, result.Table()); err != nil {
+				return err
+			}
+			return exportErr
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", export.FormatMarkdown, "File format (markdown, html)")
+	cmd.Flags().StringVar(&outDir, "out", "", "Directory to export to")
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
@@ -0,0 +1,278 @@
+package space
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// permissionChange is the outcome of granting or revoking space permissions,
+// or of a dry run
+type permissionChange struct {
+	Space   string                   `json:"space"`
+	DryRun  bool                     `json:"dryRun"`
+	Changes []confluence.GrantChange `json:"changes"`
+	Result  *cmdutil.BulkResult      `json:"result,omitempty"`
+}
+
+// subjectFlags holds the flags naming the users, groups and operations of a
+// grant or revoke
+type subjectFlags struct {
+	users      []string
+	groups     []string
+	operations []string
+}
+
+// addSubjectFlags adds --user, --group and --operation to cmd
+func addSubjectFlags(cmd *cobra.Command, flags *subjectFlags, verb string) {
+	cmd.Flags().StringSliceVar(&flags.users, "user", nil, "Account ID of a user to "+verb)
+	cmd.Flags().StringSliceVar(&flags.groups, "group", nil, "Name of a group to "+verb)
+	cmd.Flags().StringSliceVar(&flags.operations, "operation", nil, `Operation such as "read", "create:page" or "administer:space"`)
+}
+
+// grants returns a grant for each operation and user or group
+func (f *subjectFlags) grants() ([]types.Grant, error) {
+	if len(f.users) == 0 && len(f.groups) == 0 {
+		return nil, clierr.New(clierr.KindValidation, "give a --user or --group")
+	}
+	if len(f.operations) == 0 {
+		return nil, clierr.New(clierr.KindValidation, "give an --operation")
+	}
+
+	var grants []types.Grant
+	for _, value := range f.operations {
+		operation, err := confluence.ParseSpaceOperation(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range f.groups {
+			grants = append(grants, types.Grant{Operation: operation, SubjectType: confluence.SubjectGroup, SubjectID: group, Subject: group})
+		}
+		for _, user := range f.users {
+			grants = append(grants, types.Grant{Operation: operation, SubjectType: confluence.SubjectUser, SubjectID: user})
+		}
+	}
+	return grants, nil
+}
+
+func newPermissionsCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "permissions",
+		Aliases: []string{"permission"},
+		Short:   "Manage the permissions of a space",
+		Long: `List, grant and revoke what users and groups may do in a space. Operations
+are written as <operation>:<target>, e.g. "create:page"; a bare operation such
+as "read" applies to the space itself. Users are given by account ID and groups
+by name.
+
+Grant and revoke print the changes as a diff and ask for confirmation before
+applying them.`,
+	}
+
+	cmd.AddCommand(newPermissionsListCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newPermissionsGrantCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newPermissionsRevokeCmd(tokenManager)))
+
+	return cmd
+}
+
+func newPermissionsListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list <space-key>",
+		Short: "List the permissions of a space",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			grants, err := client.ListSpacePermissions(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if grants == nil {
+				grants = []types.Grant{}
+			}
+
+			table := output.NewTable("ID", "Operation", "Type", "Name", "Subject ID")
+			for _, grant := range grants {
+				table.AddRow(grant.ID, grant.Operation, grant.SubjectType, grant.Subject, grant.SubjectID)
+			}
+			table.Empty = "No permissions"
+			return cmdutil.WriteList(cmd, grants, grants, table)
+		},
+	}
+
+	return cmd
+}
+
+func newPermissionsGrantCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var subjects subjectFlags
+
+	cmd := &cobra.Command{
+		Use:   "grant <space-key>",
+		Short: "Grant permissions in a space",
+		Long: `Grant each --operation to each --user and --group. Permissions they already
+have are left alone.
+
+Examples:
+  # Let a group read the space and add pages
+  atlassian-cli space permissions grant OPS --group sre --operation read --operation create:page
+
+  # Preview making a user an administrator
+  atlassian-cli space permissions grant OPS --user 5b10ac8d82e05b22cc7d4ef5 --operation administer --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grants, err := subjects.grants()
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			return grantPermissions(cmd, client, args[0], grants)
+		},
+	}
+
+	addSubjectFlags(cmd, &subjects, "grant the operations to")
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+func newPermissionsRevokeCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var subjects subjectFlags
+
+	cmd := &cobra.Command{
+		Use:   "revoke <space-key>",
+		Short: "Revoke permissions in a space",
+		Long: `Revoke each --operation from each --user and --group. Operations they do not
+have are ignored.
+
+Examples:
+  atlassian-cli space permissions revoke OPS --group contractors --operation create:page --operation delete:page`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grants, err := subjects.grants()
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+			cmdutil.SetAuditTarget(cmd, args[0])
+
+			return revokePermissions(cmd, client, args[0], grants)
+		},
+	}
+
+	addSubjectFlags(cmd, &subjects, "revoke the operations from")
+	cmdutil.AddConfirmFlags(cmd)
+
+	return cmd
+}
+
+// grantPermissions grants those of grants that a space lacks
+func grantPermissions(cmd *cobra.Command, client confluence.ConfluenceClient, spaceKey string, grants []types.Grant) error {
+	current, err := client.ListSpacePermissions(cmd.Context(), spaceKey)
+	if err != nil {
+		return err
+	}
+	changes := confluence.DiffGrants(current, append(append([]types.Grant{}, current...), grants...))
+
+	return applyPermissionChanges(cmd, spaceKey, changes, func(ctx context.Context, change confluence.GrantChange) error {
+		return client.GrantSpacePermission(ctx, spaceKey, change.Grant)
+	})
+}
+
+// revokePermissions revokes those of grants that a space has
+func revokePermissions(cmd *cobra.Command, client confluence.ConfluenceClient, spaceKey string, grants []types.Grant) error {
+	current, err := client.ListSpacePermissions(cmd.Context(), spaceKey)
+	if err != nil {
+		return err
+	}
+	changes := confluence.DiffGrants(current, withoutGrants(current, grants))
+
+	return applyPermissionChanges(cmd, spaceKey, changes, func(ctx context.Context, change confluence.GrantChange) error {
+		return client.RevokeSpacePermission(ctx, spaceKey, change.ID)
+	})
+}
+
+// withoutGrants returns the grants of current that are not among revoked
+func withoutGrants(current, revoked []types.Grant) []types.Grant {
+	var kept []types.Grant
+	for _, grant := range current {
+		match := false
+		for _, r := range revoked {
+			if grant.Operation == r.Operation && grant.SubjectType == r.SubjectType && grant.SubjectID == r.SubjectID {
+				match = true
+				break
+			}
+		}
+		if !match {
+			kept = append(kept, grant)
+		}
+	}
+	return kept
+}
+
+// applyPermissionChanges previews permission changes and, once confirmed,
+// applies them one by one with fn
+func applyPermissionChanges(cmd *cobra.Command, spaceKey string, changes []confluence.GrantChange, fn func(ctx context.Context, change confluence.GrantChange) error) error {
+	change := permissionChange{Space: spaceKey, DryRun: cmdutil.IsDryRun(cmd), Changes: changes}
+	lines := make([]string, len(changes))
+	byLine := make(map[string]confluence.GrantChange, len(changes))
+	for i, c := range changes {
+		lines[i] = c.String()
+		byLine[lines[i]] = c
+	}
+
+	apply, err := cmdutil.ConfirmChanges(cmd, lines, fmt.Sprintf("Apply %d %s to the permissions of space %s?",
+		len(lines), pluralChanges(len(lines)), spaceKey))
+	if err != nil {
+		return err
+	}
+	if apply {
+		change.Result = cmdutil.RunBulk(cmd.Context(), lines, func(ctx context.Context, line string) error {
+			return fn(ctx, byLine[line])
+		})
+	}
+
+	formatter, err := cmdutil.GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	if formatter.IsStructured() {
+		if err := cmdutil.WriteOutput(cmd, change, nil); err != nil {
+			return err
+		}
+	} else if change.Result != nil {
+		change.Result.PrintSummary(cmd.OutOrStdout())
+	}
+	if change.Result != nil {
+		return change.Result.Err()
+	}
+	return nil
+}
+
+// pluralChanges returns "change" or "changes" to follow a count
+func pluralChanges(n int) string {
+	if n == 1 {
+		return "change"
+	}
+	return "changes"
+}
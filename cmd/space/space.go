@@ -3,12 +3,10 @@ package space
 import (
 	"atlassian-cli/internal/cmdutil"
 	"atlassian-cli/internal/auth"
-	"atlassian-cli/internal/config"
 	"atlassian-cli/internal/types"
 	"context"
-	"encoding/json"
 	"fmt"
-	"strings"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -22,6 +20,13 @@ func NewSpaceCmd(tokenManager auth.TokenManager) *cobra.Command {
 	}
 
 	cmd.AddCommand(newListCmd(tokenManager))
+	cmd.AddCommand(newGetCmd(tokenManager))
+	cmd.AddCommand(cmdutil.MarkAudited(newCreateCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newUpdateCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newArchiveCmd(tokenManager)))
+	cmd.AddCommand(cmdutil.MarkAudited(newDeleteCmd(tokenManager)))
+	cmd.AddCommand(newPermissionsCmd(tokenManager))
+	cmd.AddCommand(newExportCmd(tokenManager))
 
 	return cmd
 }
@@ -29,9 +34,11 @@ func NewSpaceCmd(tokenManager auth.TokenManager) *cobra.Command {
 func newListCmd(tokenManager auth.TokenManager) *cobra.Command {
 	var (
 		spaceType  string
+		status     string
 		maxResults int
 		startAt    int
 		cursor     string
+		all        bool
 	)
 
 	cmd := &cobra.Command{
@@ -46,92 +53,85 @@ Examples:
   # List only personal spaces
   atlassian-cli space list --type personal
 
+  # List archived spaces
+  atlassian-cli space list --status archived
+
   # Use cursor-based pagination
   atlassian-cli space list --cursor "eyJsaW1pdCI6MjUsIm9mZnNldCI6MjV9"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.LoadConfig(cmdutil.GetConfigPath(cmd))
-			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
-			}
-
-			creds, err := tokenManager.Get(context.Background(), cfg.APIEndpoint)
-			if err != nil {
-				return fmt.Errorf("not authenticated: %w", err)
+			if err := checkStatus(status); err != nil {
+				return err
 			}
 
-			factory := cmdutil.GetFactory(cmd)
-			client, err := factory.GetConfluenceClient(context.Background(), cfg.APIEndpoint, creds.Email, creds.Token)
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
 			if err != nil {
-				return fmt.Errorf("failed to get Confluence client: %w", err)
+				return err
 			}
 
 			opts := &types.SpaceListOptions{
 				Type:       spaceType,
+				Status:     status,
 				MaxResults: maxResults,
 				StartAt:    startAt,
 				Cursor:     cursor,
 			}
 
-			response, err := client.ListSpaces(context.Background(), opts)
-			if err != nil {
-				return fmt.Errorf("failed to list spaces: %w", err)
+			// List spaces, following the next cursor with --all
+			var response *types.SpaceListResponse
+			offset := 0
+			spaces, streamed, err := cmdutil.FetchPages(cmd, startAt, all, func(ctx context.Context, start int) (cmdutil.PageResult[types.Space], error) {
+				if response != nil {
+					opts.Cursor = strconv.Itoa(start)
+				}
+				page, err := client.ListSpaces(ctx, opts)
+				if err != nil {
+					return cmdutil.PageResult[types.Space]{}, fmt.Errorf("failed to list spaces: %w", err)
+				}
+				if response == nil {
+					offset = page.StartAt
+				}
+				response = page
+				return cmdutil.CursorPage(page.Spaces, page.NextCursor), nil
+			})
+			if err != nil || streamed {
+				return err
 			}
 
+			response.Spaces = spaces
+			response.StartAt = offset
 			return outputSpaceList(cmd, response)
 		},
 	}
 
 	cmd.Flags().StringVar(&spaceType, "type", "", "Filter by space type (global, personal)")
+	cmd.Flags().StringVar(&status, "status", "", "Filter by space status (current, archived)")
 	cmd.Flags().IntVar(&maxResults, "max-results", 25, "Maximum number of results")
 	cmd.Flags().IntVar(&startAt, "start-at", 0, "Starting index for pagination (deprecated, use --cursor)")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for pagination (preferred over --start-at)")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch all pages of results (streams with --output ndjson)")
+	cmdutil.AddTableFlags(cmd, spaceColumns.Names())
 
 	return cmd
 }
 
 func outputSpaceList(cmd *cobra.Command, response *types.SpaceListResponse) error {
-	format := cmdutil.GetOutputFormat(cmd)
-
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(cmd.OutOrStdout())
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(response)
-	default: // table
-		if len(response.Spaces) == 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "No spaces found\n")
-			return nil
-		}
-
-		fmt.Fprintf(cmd.OutOrStdout(), "%-10s %-30s %-10s %-30s\n",
-			"KEY", "NAME", "TYPE", "DESCRIPTION")
-		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("-", 80))
-
-		for _, space := range response.Spaces {
-			name := space.Name
-			if len(name) > 27 {
-				name = name[:27] + "..."
-			}
-
-			description := space.Description
-			if len(description) > 27 {
-				description = description[:27] + "..."
-			}
-
-			fmt.Fprintf(cmd.OutOrStdout(), "%-10s %-30s %-10s %-30s\n",
-				space.Key, name, space.Type, description)
-		}
+	table, err := cmdutil.BuildTable(cmd, spaceColumns, response.Spaces)
+	if err != nil {
+		return err
+	}
+	table.Empty = "No spaces found"
 
-		fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d-%d of %d spaces\n",
+	if len(response.Spaces) > 0 {
+		table.Footer = fmt.Sprintf("Showing %d-%d of %d spaces",
 			response.StartAt+1,
 			response.StartAt+len(response.Spaces),
 			response.Total)
 
 		if response.NextCursor != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "\nNext cursor: %s\n", response.NextCursor)
-			fmt.Fprintf(cmd.OutOrStdout(), "Use --cursor \"%s\" to fetch the next page\n", response.NextCursor)
+			table.Footer += fmt.Sprintf("\n\nNext cursor: %s\nUse --cursor \"%s\" to fetch the next page",
+				response.NextCursor, response.NextCursor)
 		}
 	}
 
-	return nil
+	return cmdutil.WriteList(cmd, response, response.Spaces, table)
 }
@@ -0,0 +1,219 @@
+package space
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSpaceTestCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	v := viper.New()
+	v.Set("output", format)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), cmdutil.ViperKey, v))
+	cmdutil.AddConfirmFlags(cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	return cmd, &out
+}
+
+// permissionClient serves and records a space's permissions
+type permissionClient struct {
+	confluence.ConfluenceClient
+	grants  []types.Grant
+	granted []types.Grant
+	revoked []string
+}
+
+func (c *permissionClient) ListSpacePermissions(ctx context.Context, key string) ([]types.Grant, error) {
+	return c.grants, nil
+}
+
+func (c *permissionClient) GrantSpacePermission(ctx context.Context, key string, grant types.Grant) error {
+	c.granted = append(c.granted, grant)
+	return nil
+}
+
+func (c *permissionClient) RevokeSpacePermission(ctx context.Context, key, id string) error {
+	c.revoked = append(c.revoked, id)
+	return nil
+}
+
+func newPermissionClient() *permissionClient {
+	return &permissionClient{grants: []types.Grant{
+		{ID: "10", Operation: "read space", SubjectType: confluence.SubjectGroup, SubjectID: "ops", Subject: "ops"},
+		{ID: "11", Operation: "create page", SubjectType: confluence.SubjectGroup, SubjectID: "ops", Subject: "ops"},
+	}}
+}
+
+func groupGrants(group string, operations ...string) []types.Grant {
+	grants := make([]types.Grant, len(operations))
+	for i, operation := range operations {
+		grants[i] = types.Grant{Operation: operation, SubjectType: confluence.SubjectGroup, SubjectID: group, Subject: group}
+	}
+	return grants
+}
+
+func TestGrantPermissions_DryRunPrintsDiff(t *testing.T) {
+	client := newPermissionClient()
+	cmd, out := newSpaceTestCmd("table")
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+
+	require.NoError(t, grantPermissions(cmd, client, "OPS", groupGrants("ops", "read space", "delete page")))
+	assert.Equal(t, "+ delete page group ops\n", out.String())
+	assert.Empty(t, client.granted)
+}
+
+func TestGrantPermissions_Confirm(t *testing.T) {
+	client := newPermissionClient()
+	cmd, _ := newSpaceTestCmd("table")
+	cmd.SetIn(strings.NewReader("n\n"))
+
+	err := grantPermissions(cmd, client, "OPS", groupGrants("sre", "read space"))
+	assert.Equal(t, clierr.KindCanceled, clierr.KindOf(err))
+	assert.Empty(t, client.granted)
+
+	cmd, out := newSpaceTestCmd("table")
+	cmd.SetIn(strings.NewReader("y\n"))
+	require.NoError(t, grantPermissions(cmd, client, "OPS", groupGrants("sre", "read space")))
+	assert.Contains(t, out.String(), "Apply 1 change to the permissions of space OPS?")
+	assert.Equal(t, groupGrants("sre", "read space"), client.granted)
+}
+
+func TestGrantPermissions_Applies(t *testing.T) {
+	client := newPermissionClient()
+	cmd, out := newSpaceTestCmd("json")
+	require.NoError(t, cmd.Flags().Set("yes", "true"))
+
+	require.NoError(t, grantPermissions(cmd, client, "OPS", groupGrants("sre", "read space", "create page")))
+	assert.Equal(t, groupGrants("sre", "read space", "create page"), client.granted)
+
+	var change permissionChange
+	require.NoError(t, json.Unmarshal(out.Bytes(), &change))
+	assert.Equal(t, "OPS", change.Space)
+	assert.Len(t, change.Changes, 2)
+	require.NotNil(t, change.Result)
+}
+
+func TestRevokePermissions_DryRunPrintsDiff(t *testing.T) {
+	client := newPermissionClient()
+	cmd, out := newSpaceTestCmd("table")
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+
+	require.NoError(t, revokePermissions(cmd, client, "OPS", groupGrants("ops", "create page", "delete page")))
+	assert.Equal(t, "- create page group ops [permission 11]\n", out.String())
+	assert.Empty(t, client.revoked)
+}
+
+func TestRevokePermissions_Applies(t *testing.T) {
+	client := newPermissionClient()
+	cmd, _ := newSpaceTestCmd("table")
+	require.NoError(t, cmd.Flags().Set("yes", "true"))
+
+	require.NoError(t, revokePermissions(cmd, client, "OPS", groupGrants("ops", "create page")))
+	assert.Equal(t, []string{"11"}, client.revoked)
+
+	// Nothing to do for permissions the space does not have
+	cmd, out := newSpaceTestCmd("table")
+	require.NoError(t, revokePermissions(cmd, client, "OPS", groupGrants("sre", "read space")))
+	assert.Equal(t, "No changes\n", out.String())
+	assert.Equal(t, []string{"11"}, client.revoked)
+}
+
+// adminClient serves one space and records archiving and deletion
+type adminClient struct {
+	confluence.ConfluenceClient
+	space   types.Space
+	deleted []string
+}
+
+func (c *adminClient) GetSpace(ctx context.Context, key string) (*types.Space, error) {
+	space := c.space
+	return &space, nil
+}
+
+func (c *adminClient) ArchiveSpace(ctx context.Context, key string, archived bool) (*types.Space, error) {
+	c.space.Status = confluence.SpaceCurrent
+	if archived {
+		c.space.Status = confluence.SpaceArchived
+	}
+	space := c.space
+	return &space, nil
+}
+
+func (c *adminClient) DeleteSpace(ctx context.Context, key string) error {
+	c.deleted = append(c.deleted, key)
+	return nil
+}
+
+func newAdminClient() *adminClient {
+	return &adminClient{space: types.Space{Key: "OLD", Name: "Old project", Status: confluence.SpaceCurrent}}
+}
+
+func TestArchiveSpace(t *testing.T) {
+	client := newAdminClient()
+	cmd, out := newSpaceTestCmd("json")
+
+	require.NoError(t, archiveSpace(cmd, client, "OLD", true))
+	var space types.Space
+	require.NoError(t, json.Unmarshal(out.Bytes(), &space))
+	assert.Equal(t, confluence.SpaceArchived, space.Status)
+
+	cmd, _ = newSpaceTestCmd("json")
+	require.NoError(t, archiveSpace(cmd, client, "OLD", false))
+	assert.Equal(t, confluence.SpaceCurrent, client.space.Status)
+}
+
+func TestDeleteSpace_DryRun(t *testing.T) {
+	client := newAdminClient()
+	cmd, out := newSpaceTestCmd("table")
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+
+	require.NoError(t, deleteSpace(cmd, client, "OLD"))
+	assert.Equal(t, "Dry run: space OLD (Old project) and all of its content would be deleted\n", out.String())
+	assert.Empty(t, client.deleted)
+}
+
+func TestDeleteSpace_Confirm(t *testing.T) {
+	client := newAdminClient()
+	cmd, _ := newSpaceTestCmd("table")
+	cmd.SetIn(strings.NewReader("n\n"))
+
+	err := deleteSpace(cmd, client, "OLD")
+	assert.Equal(t, clierr.KindCanceled, clierr.KindOf(err))
+	assert.Empty(t, client.deleted)
+
+	cmd, out := newSpaceTestCmd("table")
+	cmd.SetIn(strings.NewReader("y\n"))
+	require.NoError(t, deleteSpace(cmd, client, "OLD"))
+	assert.Contains(t, out.String(), "Permanently delete space OLD (Old project) and all of its content?")
+	assert.Equal(t, []string{"OLD"}, client.deleted)
+}
+
+func TestDeleteSpace_Applies(t *testing.T) {
+	client := newAdminClient()
+	cmd, out := newSpaceTestCmd("json")
+	require.NoError(t, cmd.Flags().Set("yes", "true"))
+
+	require.NoError(t, deleteSpace(cmd, client, "OLD"))
+	assert.Equal(t, []string{"OLD"}, client.deleted)
+
+	var operation spaceOperation
+	require.NoError(t, json.Unmarshal(out.Bytes(), &operation))
+	assert.Equal(t, "delete", operation.Action)
+	assert.False(t, operation.DryRun)
+	assert.Equal(t, "OLD", operation.Space.Key)
+}
@@ -0,0 +1,137 @@
+package template
+
+import (
+	"atlassian-cli/cmd/page"
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/cmdutil"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/output"
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// templateSpace returns the space whose templates to use alongside the global
+// ones: the --space flag or default space, or none if neither is set
+func templateSpace(cmd *cobra.Command) (string, error) {
+	spaceKey, err := config.ResolveSpace(cmd)
+	if errors.Is(err, config.ErrNoSpaceConfigured) {
+		return "", nil
+	}
+	return spaceKey, err
+}
+
+// NewTemplateCmd creates the template command with subcommands
+func NewTemplateCmd(tokenManager auth.TokenManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates"},
+		Short:   "Confluence page templates",
+		Long: `List and show the page templates of a space and the global templates. Create
+pages from them with page create --template.`,
+	}
+
+	cmd.AddCommand(newTemplateListCmd(tokenManager))
+	cmd.AddCommand(newTemplateGetCmd(tokenManager))
+
+	return cmd
+}
+
+func newTemplateListCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var spaceKey string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List page templates",
+		Long: `List the page templates of the space given with --space, or of the default space,
+followed by the global templates.
+
+Examples:
+  atlassian-cli template list --space ENG`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			space, err := templateSpace(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			templates, err := client.ListTemplates(cmd.Context(), "")
+			if err != nil {
+				return err
+			}
+			if space != "" {
+				spaceTemplates, err := client.ListTemplates(cmd.Context(), space)
+				if err != nil {
+					return err
+				}
+				templates = append(spaceTemplates, templates...)
+			}
+
+			table := output.NewTable("ID", "Name", "Space", "Description")
+			for _, tmpl := range templates {
+				table.AddRow(tmpl.ID, tmpl.Name, page.TemplateScope(tmpl.SpaceKey), tmpl.Description)
+			}
+			table.Empty = "No templates found"
+			return cmdutil.WriteList(cmd, templates, templates, table)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+
+	return cmd
+}
+
+func newTemplateGetCmd(tokenManager auth.TokenManager) *cobra.Command {
+	var spaceKey string
+
+	cmd := &cobra.Command{
+		Use:   "get <name|id>",
+		Short: "Show a page template",
+		Long: `Show a template with its variables and body. A name is looked up among the
+templates of the space, then the global templates.
+
+Examples:
+  atlassian-cli template get Postmortem --space ENG
+  atlassian-cli template get 98310 -o json --query '.variables'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			space, err := templateSpace(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetConfluenceClient(cmd, tokenManager)
+			if err != nil {
+				return err
+			}
+
+			tmpl, err := page.FindTemplate(cmd.Context(), client, space, args[0])
+			if err != nil {
+				return err
+			}
+
+			record := output.NewRecord().
+				Field("ID", tmpl.ID).
+				Field("Name", tmpl.Name).
+				Field("Space", page.TemplateScope(tmpl.SpaceKey)).
+				Field("Description", tmpl.Description)
+			if len(tmpl.Labels) > 0 {
+				record.Field("Labels", strings.Join(tmpl.Labels, ", "))
+			}
+			if len(tmpl.Variables) > 0 {
+				record.Field("Variables", strings.Join(tmpl.Variables, ", "))
+			}
+			record.FieldWithWidth("Content", tmpl.Content, 103)
+			return cmdutil.WriteOutput(cmd, tmpl, record)
+		},
+	}
+
+	cmd.Flags().StringVar(&spaceKey, "space", "", "Confluence space key (overrides default)")
+
+	return cmd
+}
@@ -5,66 +5,142 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// LogFileName is the name of the active audit log file
+	LogFileName = "audit.log"
+	// DefaultMaxSize is the size in bytes at which the audit log is rotated
+	DefaultMaxSize = 10 * 1024 * 1024
+	// DefaultMaxAge is how long rotated audit logs are kept
+	DefaultMaxAge = 90 * 24 * time.Hour
+)
+
 // Event represents an audit log entry
 type Event struct {
 	Timestamp time.Time `json:"timestamp"`
 	User      string    `json:"user"`
+	Profile   string    `json:"profile,omitempty"`
+	Server    string    `json:"server,omitempty"`
 	Command   string    `json:"command"`
+	Target    string    `json:"target,omitempty"`
 	Args      []string  `json:"args"`
 	Success   bool      `json:"success"`
 	Error     string    `json:"error,omitempty"`
 }
 
+// Options configures audit log location and rotation
+type Options struct {
+	Dir     string        // Directory holding audit logs (default ~/.atlassian-cli/logs)
+	MaxSize int64         // Rotate when the active log exceeds this many bytes (0 disables)
+	MaxAge  time.Duration // Remove rotated logs older than this (0 keeps them forever)
+}
+
 // Logger handles audit logging with thread-safe operations
 type Logger struct {
 	file *os.File
+	path string
+	opts Options
 	mu   sync.Mutex
 }
 
-// NewLogger creates a new audit logger
-func NewLogger() (*Logger, error) {
+// DefaultDir returns the default audit log directory
+func DefaultDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".atlassian-cli", "logs"), nil
+}
+
+// NewLogger creates a new audit logger
+func NewLogger() (*Logger, error) {
+	return NewLoggerWithOptions(Options{
+		MaxSize: DefaultMaxSize,
+		MaxAge:  DefaultMaxAge,
+	})
+}
+
+// NewLoggerWithOptions creates a new audit logger with custom location and rotation
+func NewLoggerWithOptions(opts Options) (*Logger, error) {
+	if opts.Dir == "" {
+		dir, err := DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		opts.Dir = dir
 	}
 
-	logDir := filepath.Join(home, ".atlassian-cli", "logs")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	logFile := filepath.Join(logDir, "audit.log")
-	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	l := &Logger{
+		path: filepath.Join(opts.Dir, LogFileName),
+		opts: opts,
 	}
 
-	return &Logger{file: file}, nil
+	if err := l.rotateIfNeeded(); err != nil {
+		return nil, err
+	}
+	l.removeExpired()
+
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// open opens the active log file for appending
+func (l *Logger) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l.file = file
+	return nil
 }
 
 // Log records an audit event with thread-safe locking
 func (l *Logger) Log(user, command string, args []string, success bool, err error) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
 	event := Event{
-		Timestamp: time.Now(),
-		User:      user,
-		Command:   command,
-		Args:      args,
-		Success:   success,
+		User:    user,
+		Command: command,
+		Args:    args,
+		Success: success,
 	}
 
 	if err != nil {
 		event.Error = err.Error()
 	}
 
+	l.Record(event)
+}
+
+// Record writes a fully populated audit event, rotating the log when it grows too large
+func (l *Logger) Record(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
 	data, _ := json.Marshal(event)
 	l.file.WriteString(string(data) + "\n")
+
+	if l.opts.MaxSize > 0 && l.path != "" {
+		if info, err := l.file.Stat(); err == nil && info.Size() >= l.opts.MaxSize {
+			l.file.Close()
+			l.rotateIfNeeded()
+			l.open()
+		}
+	}
 }
 
 // Close closes the audit logger with thread-safe locking
@@ -73,3 +149,70 @@ func (l *Logger) Close() error {
 	defer l.mu.Unlock()
 	return l.file.Close()
 }
+
+// rotateIfNeeded renames the active log to a timestamped file once it reaches MaxSize
+func (l *Logger) rotateIfNeeded() error {
+	if l.opts.MaxSize <= 0 {
+		return nil
+	}
+
+	info, err := os.Stat(l.path)
+	if err != nil || info.Size() < l.opts.MaxSize {
+		return nil
+	}
+
+	rotated := fmt.Sprintf("%s.%s", l.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(l.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+
+	l.removeExpired()
+	return nil
+}
+
+// removeExpired deletes rotated logs older than MaxAge
+func (l *Logger) removeExpired() {
+	if l.opts.MaxAge <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-l.opts.MaxAge)
+	for _, file := range rotatedFiles(l.opts.Dir) {
+		if info, err := os.Stat(file); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(file)
+		}
+	}
+}
+
+// rotatedFiles lists rotated audit logs in dir, oldest first
+func rotatedFiles(dir string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, LogFileName+".*"))
+	sort.Strings(matches)
+	return matches
+}
+
+// sensitiveFlags lists flags whose values must never reach the audit log
+var sensitiveFlags = map[string]bool{
+	"--token":      true,
+	"--password":   true,
+	"--passphrase": true,
+}
+
+// RedactArgs replaces the values of sensitive flags so secrets are never logged
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, _, found := strings.Cut(arg, "="); found && sensitiveFlags[name] {
+			redacted[i] = name + "=[REDACTED]"
+			continue
+		}
+
+		redacted[i] = arg
+		if sensitiveFlags[arg] && i+1 < len(args) {
+			i++
+			redacted[i] = "[REDACTED]"
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+
+	logger, err := NewLoggerWithOptions(Options{Dir: dir, MaxSize: 512})
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		logger.Record(Event{Command: "issue update", Target: fmt.Sprintf("DEMO-%d", i), Success: true})
+	}
+	require.NoError(t, logger.Close())
+
+	assert.NotEmpty(t, rotatedFiles(dir), "log should have been rotated")
+
+	// All events remain queryable across rotated files
+	events, err := Query(dir, Filter{})
+	require.NoError(t, err)
+	require.Len(t, events, 20)
+	assert.Equal(t, "DEMO-0", events[0].Target)
+	assert.Equal(t, "DEMO-19", events[19].Target)
+}
+
+func TestLoggerRemovesExpiredRotations(t *testing.T) {
+	dir := t.TempDir()
+
+	old := filepath.Join(dir, LogFileName+".20000101T000000.000000000")
+	require.NoError(t, os.WriteFile(old, []byte("{}\n"), 0600))
+	past := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(old, past, past))
+
+	logger, err := NewLoggerWithOptions(Options{Dir: dir, MaxAge: 24 * time.Hour})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	_, err = os.Stat(old)
+	assert.True(t, os.IsNotExist(err), "expired rotated log should be removed")
+}
+
+func TestQueryFilters(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewLoggerWithOptions(Options{Dir: dir})
+	require.NoError(t, err)
+
+	base := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	logger.Record(Event{Timestamp: base, Command: "issue create", Target: "DEMO-1", Success: true})
+	logger.Record(Event{Timestamp: base.Add(time.Hour), Command: "issue update", Target: "DEMO-1", Success: false, Error: "boom"})
+	logger.Record(Event{Timestamp: base.Add(48 * time.Hour), Command: "page update", Target: "12345", Success: true})
+	require.NoError(t, logger.Close())
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		targets []string
+	}{
+		{"all", Filter{}, []string{"DEMO-1", "DEMO-1", "12345"}},
+		{"by command prefix", Filter{Command: "issue"}, []string{"DEMO-1", "DEMO-1"}},
+		{"failures", Filter{Status: "failure"}, []string{"DEMO-1"}},
+		{"since", Filter{Since: base.Add(24 * time.Hour)}, []string{"12345"}},
+		{"until", Filter{Until: base.Add(time.Hour)}, []string{"DEMO-1"}},
+		{"target", Filter{Target: "12345"}, []string{"12345"}},
+		{"limit keeps most recent", Filter{Limit: 1}, []string{"12345"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := Query(dir, tt.filter)
+			require.NoError(t, err)
+
+			var targets []string
+			for _, e := range events {
+				targets = append(targets, e.Target)
+			}
+			assert.Equal(t, tt.targets, targets)
+		})
+	}
+
+	_, err = Query(dir, Filter{Status: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := []string{"--server=https://x.atlassian.net", "--token=abc", "--token", "def", "--email", "me@example.com"}
+	redacted := RedactArgs(args)
+
+	assert.Equal(t, []string{"--server=https://x.atlassian.net", "--token=[REDACTED]", "--token", "[REDACTED]", "--email", "me@example.com"}, redacted)
+	for _, arg := range redacted {
+		assert.NotContains(t, arg, "abc")
+		assert.NotContains(t, arg, "def")
+	}
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Filter selects audit events when querying the log
+type Filter struct {
+	Since   time.Time // Only events at or after this time
+	Until   time.Time // Only events before this time
+	Command string    // Only events whose command starts with this prefix
+	Status  string    // "success", "failure" or empty for both
+	Target  string    // Only events for this target key
+	Limit   int       // Return at most this many (most recent) events
+}
+
+// Matches reports whether the event satisfies the filter
+func (f Filter) Matches(event Event) bool {
+	if !f.Since.IsZero() && event.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.Timestamp.Before(f.Until) {
+		return false
+	}
+	if f.Command != "" && !strings.HasPrefix(event.Command, f.Command) {
+		return false
+	}
+	if f.Target != "" && event.Target != f.Target {
+		return false
+	}
+	switch f.Status {
+	case "success":
+		return event.Success
+	case "failure":
+		return !event.Success
+	}
+	return true
+}
+
+// Query reads the active and rotated audit logs in dir and returns matching events
+// in chronological order
+func Query(dir string, filter Filter) ([]Event, error) {
+	if filter.Status != "" && filter.Status != "success" && filter.Status != "failure" {
+		return nil, fmt.Errorf("invalid status %q (must be success or failure)", filter.Status)
+	}
+
+	files := append(rotatedFiles(dir), filepath.Join(dir, LogFileName))
+
+	var events []Event
+	for _, path := range files {
+		fileEvents, err := readEvents(path, filter)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, fileEvents...)
+	}
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[len(events)-filter.Limit:]
+	}
+
+	return events, nil
+}
+
+// readEvents parses one JSON-lines audit file, skipping malformed lines
+func readEvents(path string, filter Filter) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return events, nil
+}
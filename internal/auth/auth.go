@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/types"
 	"context"
 	"encoding/json"
@@ -15,6 +16,9 @@ import (
 
 var validate = validator.New()
 
+// ErrCredentialsNotFound is returned when no credentials are stored for a server
+var ErrCredentialsNotFound = clierr.New(clierr.KindAuth, "credentials not found for server")
+
 // TokenManager defines the interface for storing and retrieving authentication tokens
 type TokenManager interface {
 	Store(ctx context.Context, creds *types.AuthCredentials) error
@@ -57,7 +61,7 @@ func (m *MemoryTokenManager) Get(ctx context.Context, serverURL string) (*types.
 
 	creds, exists := m.credentials[serverURL]
 	if !exists {
-		return nil, fmt.Errorf("credentials not found for server: %s", serverURL)
+		return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
 	}
 
 	return creds, nil
@@ -80,11 +84,11 @@ func (m *MemoryTokenManager) Validate(ctx context.Context, serverURL, email, tok
 // ValidateCredentials validates authentication credentials format
 func ValidateCredentials(creds *types.AuthCredentials) error {
 	if creds == nil {
-		return fmt.Errorf("credentials cannot be nil")
+		return clierr.New(clierr.KindValidation, "credentials cannot be nil")
 	}
 
 	if err := validate.Struct(creds); err != nil {
-		return fmt.Errorf("credential validation failed: %w", err)
+		return clierr.Wrap(clierr.KindValidation, err, "credential validation failed")
 	}
 
 	return nil
@@ -113,7 +117,7 @@ func ValidateToken(ctx context.Context, serverURL, email, token string) (*types.
 	// Execute request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("cannot reach %s. Check the URL and your network connection: %w", serverURL, err)
+		return nil, clierr.Wrap(clierr.KindNetwork, err, "cannot reach %s. Check the URL and your network connection", serverURL)
 	}
 	defer resp.Body.Close()
 
@@ -125,12 +129,15 @@ func ValidateToken(ctx context.Context, serverURL, email, token string) (*types.
 
 	// Check for authentication failure
 	if resp.StatusCode == 401 {
-		return nil, fmt.Errorf("authentication failed: invalid email or API token. Generate a new token at https://id.atlassian.com/manage/api-tokens")
+		return nil, clierr.New(clierr.KindAuth, "authentication failed: invalid email or API token. Generate a new token at https://id.atlassian.com/manage/api-tokens")
 	}
 
 	// Check for other HTTP errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &clierr.Error{
+			Kind:    clierr.KindFromStatus(resp.StatusCode),
+			Message: fmt.Sprintf("API request failed with status %d: %s", resp.StatusCode, string(body)),
+		}
 	}
 
 	// Parse the user info from response
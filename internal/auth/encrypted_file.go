@@ -2,6 +2,7 @@ package auth
 
 import (
 	"atlassian-cli/internal/types"
+	"bytes"
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"golang.org/x/crypto/pbkdf2"
 )
@@ -27,10 +29,19 @@ const (
 )
 
 // EncryptedFileTokenManager implements TokenManager using AES-256-GCM encrypted file
+//
+// Credentials are encrypted with a random data key. The data key is wrapped either
+// with a machine-derived key (the default) or, in passphrase mode, with a key
+// derived from a user passphrase via scrypt.
 type EncryptedFileTokenManager struct {
-	filePath string
-	key      []byte
-	mutex    sync.RWMutex
+	filePath       string
+	key            []byte
+	mutex          sync.RWMutex
+	keyMutex       sync.Mutex
+	dataKey        []byte
+	dataKeySalt    []byte
+	passphraseFunc PassphraseFunc
+	unlockTimeout  time.Duration
 }
 
 // NewEncryptedFileTokenManager creates a new encrypted file-based token manager
@@ -57,11 +68,26 @@ func NewEncryptedFileTokenManager(filePath string) (*EncryptedFileTokenManager,
 	}
 
 	return &EncryptedFileTokenManager{
-		filePath: filePath,
-		key:      key,
+		filePath:      filePath,
+		key:           key,
+		unlockTimeout: DefaultUnlockTimeout,
 	}, nil
 }
 
+// SetPassphraseFunc sets the function used to obtain the passphrase when the file is locked
+func (e *EncryptedFileTokenManager) SetPassphraseFunc(fn PassphraseFunc) {
+	e.keyMutex.Lock()
+	defer e.keyMutex.Unlock()
+	e.passphraseFunc = fn
+}
+
+// SetUnlockTimeout sets how long a passphrase unlock is cached; zero disables caching
+func (e *EncryptedFileTokenManager) SetUnlockTimeout(timeout time.Duration) {
+	e.keyMutex.Lock()
+	defer e.keyMutex.Unlock()
+	e.unlockTimeout = timeout
+}
+
 // Store saves credentials in an encrypted file
 func (e *EncryptedFileTokenManager) Store(ctx context.Context, creds *types.AuthCredentials) error {
 	if err := ValidateCredentials(creds); err != nil {
@@ -72,7 +98,7 @@ func (e *EncryptedFileTokenManager) Store(ctx context.Context, creds *types.Auth
 	defer e.mutex.Unlock()
 
 	// Load existing credentials
-	allCreds, err := e.loadAll()
+	allCreds, header, dataKey, err := e.load()
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to load existing credentials: %w", err)
 	}
@@ -84,7 +110,7 @@ func (e *EncryptedFileTokenManager) Store(ctx context.Context, creds *types.Auth
 	allCreds[creds.ServerURL] = creds
 
 	// Save all credentials
-	return e.saveAll(allCreds)
+	return e.saveAll(allCreds, header, dataKey)
 }
 
 // Get retrieves credentials from the encrypted file
@@ -95,14 +121,14 @@ func (e *EncryptedFileTokenManager) Get(ctx context.Context, serverURL string) (
 	allCreds, err := e.loadAll()
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("credentials not found for server: %s", serverURL)
+			return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
 		}
 		return nil, fmt.Errorf("failed to load credentials: %w", err)
 	}
 
 	creds, exists := allCreds[serverURL]
 	if !exists {
-		return nil, fmt.Errorf("credentials not found for server: %s", serverURL)
+		return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
 	}
 
 	return creds, nil
@@ -113,7 +139,7 @@ func (e *EncryptedFileTokenManager) Delete(ctx context.Context, serverURL string
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
-	allCreds, err := e.loadAll()
+	allCreds, header, dataKey, err := e.load()
 	if err != nil {
 		if os.IsNotExist(err) {
 			// File doesn't exist, nothing to delete
@@ -132,7 +158,7 @@ func (e *EncryptedFileTokenManager) Delete(ctx context.Context, serverURL string
 		return nil
 	}
 
-	return e.saveAll(allCreds)
+	return e.saveAll(allCreds, header, dataKey)
 }
 
 // Validate validates credentials against the Atlassian API
@@ -140,31 +166,280 @@ func (e *EncryptedFileTokenManager) Validate(ctx context.Context, serverURL, ema
 	return ValidateToken(ctx, serverURL, email, token)
 }
 
+// PassphraseEnabled reports whether the credentials file is protected by a passphrase
+func (e *EncryptedFileTokenManager) PassphraseEnabled() (bool, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	data, err := os.ReadFile(e.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	header, _, err := parseHeader(data)
+	if err != nil {
+		return false, err
+	}
+
+	return header != nil && header.mode == keyModePassphrase, nil
+}
+
+// ChangePassphrase re-wraps the data key with a new passphrase.
+// An empty newPassphrase reverts the file to machine-key protection.
+// The current passphrase is required when the file is passphrase protected.
+func (e *EncryptedFileTokenManager) ChangePassphrase(oldPassphrase, newPassphrase []byte) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	allCreds := make(map[string]*types.AuthCredentials)
+	var dataKey []byte
+
+	data, err := os.ReadFile(e.filePath)
+	switch {
+	case os.IsNotExist(err):
+		// No credentials yet; protect an empty store
+	case err != nil:
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	default:
+		header, payload, err := parseHeader(data)
+		if err != nil {
+			return err
+		}
+
+		var plaintext []byte
+		switch {
+		case header == nil:
+			plaintext, err = openAESGCM(e.key, payload, nil)
+		case header.mode == keyModePassphrase:
+			if dataKey, err = e.unwrapWithPassphrase(header, oldPassphrase); err != nil {
+				return err
+			}
+			plaintext, err = openAESGCM(dataKey, payload, header.salt)
+		default:
+			if dataKey, err = header.unwrap(e.key); err != nil {
+				return fmt.Errorf("failed to unwrap data key: %w", err)
+			}
+			plaintext, err = openAESGCM(dataKey, payload, header.salt)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decrypt credentials: %w", err)
+		}
+
+		if err := json.Unmarshal(plaintext, &allCreds); err != nil {
+			return fmt.Errorf("failed to unmarshal credentials: %w", err)
+		}
+	}
+
+	if dataKey == nil {
+		if dataKey, err = newDataKey(); err != nil {
+			return fmt.Errorf("failed to generate data key: %w", err)
+		}
+	}
+
+	header, err := newHeader(dataKey, e.key, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	// Any cached unlock refers to the old wrapping and must not survive the change
+	e.clearUnlock()
+
+	return e.writeFile(header, dataKey, allCreds)
+}
+
+// Unlock verifies the passphrase and caches the unwrapped data key for the given duration
+func (e *EncryptedFileTokenManager) Unlock(passphrase []byte, timeout time.Duration) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	data, err := os.ReadFile(e.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no credentials file found at %s", e.filePath)
+		}
+		return fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	header, _, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	if header == nil || header.mode != keyModePassphrase {
+		return fmt.Errorf("credentials file is not passphrase protected")
+	}
+
+	dataKey, err := e.unwrapWithPassphrase(header, passphrase)
+	if err != nil {
+		return err
+	}
+
+	e.keyMutex.Lock()
+	e.dataKey, e.dataKeySalt = dataKey, header.salt
+	e.keyMutex.Unlock()
+
+	if timeout > 0 {
+		if err := writeUnlockCache(e.unlockPath(), e.key, dataKey, header.salt, time.Now().Add(timeout)); err != nil {
+			return fmt.Errorf("failed to cache unlock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Lock discards any cached data key so the passphrase is required again
+func (e *EncryptedFileTokenManager) Lock() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.clearUnlock()
+}
+
+// clearUnlock removes the in-memory and on-disk unlock state
+func (e *EncryptedFileTokenManager) clearUnlock() error {
+	e.keyMutex.Lock()
+	e.dataKey, e.dataKeySalt = nil, nil
+	e.keyMutex.Unlock()
+
+	if err := os.Remove(e.unlockPath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove unlock cache: %w", err)
+	}
+	return nil
+}
+
+// unlockPath returns the path of the cached unlock file
+func (e *EncryptedFileTokenManager) unlockPath() string {
+	return e.filePath + ".unlock"
+}
+
+// unwrapWithPassphrase derives the passphrase key and unwraps the data key
+func (e *EncryptedFileTokenManager) unwrapWithPassphrase(header *fileHeader, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrInvalidPassphrase
+	}
+
+	wrappingKey, err := header.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := header.unwrap(wrappingKey)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	return dataKey, nil
+}
+
+// resolveDataKey returns the data key for a passphrase-protected header, trying the
+// in-memory key, then the unlock cache, then the passphrase function
+func (e *EncryptedFileTokenManager) resolveDataKey(header *fileHeader) ([]byte, error) {
+	e.keyMutex.Lock()
+	defer e.keyMutex.Unlock()
+
+	if e.dataKey != nil && bytes.Equal(e.dataKeySalt, header.salt) {
+		return e.dataKey, nil
+	}
+
+	if dataKey, ok := readUnlockCache(e.unlockPath(), e.key, header.salt); ok {
+		e.dataKey, e.dataKeySalt = dataKey, header.salt
+		return dataKey, nil
+	}
+
+	if e.passphraseFunc == nil {
+		return nil, ErrCredentialsLocked
+	}
+
+	passphrase, err := e.passphraseFunc("Credentials passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := e.unwrapWithPassphrase(header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	e.dataKey, e.dataKeySalt = dataKey, header.salt
+	if e.unlockTimeout > 0 {
+		// Failing to cache only means the next command prompts again
+		writeUnlockCache(e.unlockPath(), e.key, dataKey, header.salt, time.Now().Add(e.unlockTimeout))
+	}
+
+	return dataKey, nil
+}
+
 // loadAll loads all credentials from the encrypted file
 func (e *EncryptedFileTokenManager) loadAll() (map[string]*types.AuthCredentials, error) {
+	allCreds, _, _, err := e.load()
+	return allCreds, err
+}
+
+// load reads and decrypts the credentials file, returning the header and data key
+// needed to write it back. Legacy files return a nil header and data key.
+func (e *EncryptedFileTokenManager) load() (map[string]*types.AuthCredentials, *fileHeader, []byte, error) {
 	// Read encrypted data
 	encryptedData, err := os.ReadFile(e.filePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+
+	header, payload, err := parseHeader(encryptedData)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse credentials file: %w", err)
 	}
 
 	// Decrypt data
-	plaintext, err := e.decrypt(encryptedData)
+	var plaintext, dataKey []byte
+	if header == nil {
+		// Legacy format: payload encrypted directly with the machine key
+		plaintext, err = openAESGCM(e.key, payload, nil)
+	} else {
+		if header.mode == keyModePassphrase {
+			dataKey, err = e.resolveDataKey(header)
+		} else {
+			dataKey, err = header.unwrap(e.key)
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to unlock credentials: %w", err)
+		}
+		plaintext, err = openAESGCM(dataKey, payload, header.salt)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt credentials: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to decrypt credentials: %w", err)
 	}
 
 	// Unmarshal credentials
 	var allCreds map[string]*types.AuthCredentials
 	if err := json.Unmarshal(plaintext, &allCreds); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+
+	return allCreds, header, dataKey, nil
+}
+
+// saveAll saves all credentials to the encrypted file, preserving the key protection
+// described by header. A nil header writes a new machine-key protected file.
+func (e *EncryptedFileTokenManager) saveAll(allCreds map[string]*types.AuthCredentials, header *fileHeader, dataKey []byte) error {
+	var err error
+
+	// New and legacy files are (re)written in the current format with machine-key wrapping
+	if header == nil {
+		if dataKey, err = newDataKey(); err != nil {
+			return fmt.Errorf("failed to generate data key: %w", err)
+		}
+		if header, err = newHeader(dataKey, e.key, nil); err != nil {
+			return err
+		}
 	}
 
-	return allCreds, nil
+	return e.writeFile(header, dataKey, allCreds)
 }
 
-// saveAll saves all credentials to the encrypted file
-func (e *EncryptedFileTokenManager) saveAll(allCreds map[string]*types.AuthCredentials) error {
+// writeFile encrypts the credentials with the data key and atomically writes the file
+func (e *EncryptedFileTokenManager) writeFile(header *fileHeader, dataKey []byte, allCreds map[string]*types.AuthCredentials) error {
 	// Marshal credentials
 	plaintext, err := json.Marshal(allCreds)
 	if err != nil {
@@ -172,10 +447,11 @@ func (e *EncryptedFileTokenManager) saveAll(allCreds map[string]*types.AuthCrede
 	}
 
 	// Encrypt data
-	encryptedData, err := e.encrypt(plaintext)
+	payload, err := sealAESGCM(dataKey, plaintext, header.salt)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt credentials: %w", err)
 	}
+	encryptedData := append(header.marshal(), payload...)
 
 	// Write to temp file first (atomic write)
 	tempFile := e.filePath + ".tmp"
@@ -192,9 +468,9 @@ func (e *EncryptedFileTokenManager) saveAll(allCreds map[string]*types.AuthCrede
 	return nil
 }
 
-// encrypt encrypts data using AES-256-GCM
-func (e *EncryptedFileTokenManager) encrypt(plaintext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(e.key)
+// sealAESGCM encrypts data using AES-256-GCM and prepends the nonce
+func sealAESGCM(key, plaintext, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -211,13 +487,13 @@ func (e *EncryptedFileTokenManager) encrypt(plaintext []byte) ([]byte, error) {
 	}
 
 	// Encrypt and prepend nonce
-	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, additionalData)
 	return ciphertext, nil
 }
 
-// decrypt decrypts data using AES-256-GCM
-func (e *EncryptedFileTokenManager) decrypt(ciphertext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(e.key)
+// openAESGCM decrypts data produced by sealAESGCM
+func openAESGCM(key, ciphertext, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +512,7 @@ func (e *EncryptedFileTokenManager) decrypt(ciphertext []byte) ([]byte, error) {
 	ciphertext = ciphertext[gcm.NonceSize():]
 
 	// Decrypt
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +52,7 @@ func (k *KeychainTokenManager) Get(ctx context.Context, serverURL string) (*type
 	data, err := keyring.Get(k.serviceName, serverURL)
 	if err != nil {
 		if err == keyring.ErrNotFound {
-			return nil, fmt.Errorf("credentials not found for server: %s", serverURL)
+			return nil, fmt.Errorf("%w: %s", ErrCredentialsNotFound, serverURL)
 		}
 		return nil, fmt.Errorf("failed to retrieve credentials from keychain: %w", err)
 	}
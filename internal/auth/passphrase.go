@@ -0,0 +1,307 @@
+package auth
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	// PassphraseEnvVar supplies the credentials passphrase for non-interactive use
+	PassphraseEnvVar = "ATLASSIAN_CREDENTIALS_PASSPHRASE"
+	// DefaultUnlockTimeout is how long an unlocked credentials file stays unlocked
+	DefaultUnlockTimeout = 15 * time.Minute
+
+	// fileMagic identifies versioned credential files; legacy files have no header
+	fileMagic = "ACLI"
+	// fileFormatVersion is the current credential file format version
+	fileFormatVersion = 2
+
+	// keyModeMachine wraps the data key with the machine-derived key
+	keyModeMachine byte = 0
+	// keyModePassphrase wraps the data key with a scrypt-derived passphrase key
+	keyModePassphrase byte = 1
+
+	// scrypt parameters (N = 2^15, r = 8, p = 1) as recommended for interactive logins
+	scryptLogN = 15
+	scryptR    = 8
+	scryptP    = 1
+
+	// Bounds on the scrypt parameters accepted from a file header, so a damaged
+	// header cannot make key derivation exhaust memory
+	minScryptLogN = scryptLogN - 5
+	maxScryptLogN = scryptLogN + 5
+	maxScryptR    = 4 * scryptR
+	maxScryptP    = 4
+
+	saltSize = 16
+)
+
+var (
+	// ErrCredentialsLocked is returned when a passphrase is required but none can be obtained
+	ErrCredentialsLocked = clierr.New(clierr.KindAuth, "credentials file is locked: run 'atlassian-cli auth unlock' or set "+PassphraseEnvVar)
+	// ErrInvalidPassphrase is returned when the passphrase does not unwrap the data key
+	ErrInvalidPassphrase = clierr.New(clierr.KindAuth, "invalid passphrase")
+	// ErrNoTerminal is returned when a passphrase must be typed but stdin is not a terminal
+	ErrNoTerminal = clierr.New(clierr.KindValidation, "no terminal to read the passphrase from: run the command interactively")
+)
+
+// PassphraseFunc supplies the passphrase used to unlock the credentials file
+type PassphraseFunc func(prompt string) ([]byte, error)
+
+// PassphraseManager is implemented by token managers that support passphrase protection
+type PassphraseManager interface {
+	PassphraseEnabled() (bool, error)
+	ChangePassphrase(oldPassphrase, newPassphrase []byte) error
+	Unlock(passphrase []byte, timeout time.Duration) error
+	Lock() error
+	SetUnlockTimeout(timeout time.Duration)
+}
+
+// fileHeader describes how the data key of a credentials file is protected
+//
+// Layout (all integers big-endian):
+//
+//	magic "ACLI" | version (1) | mode (1) | logN (1) | r (1) | p (1) |
+//	salt (16) | wrapped key length (2) | wrapped key (nonce + sealed data key)
+type fileHeader struct {
+	mode       byte
+	logN       byte
+	r          byte
+	p          byte
+	salt       []byte
+	wrappedKey []byte
+}
+
+// marshal encodes the header in its on-disk form
+func (h *fileHeader) marshal() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(fileMagic)
+	buf.WriteByte(fileFormatVersion)
+	buf.WriteByte(h.mode)
+	buf.WriteByte(h.logN)
+	buf.WriteByte(h.r)
+	buf.WriteByte(h.p)
+	buf.Write(h.salt)
+	binary.Write(&buf, binary.BigEndian, uint16(len(h.wrappedKey)))
+	buf.Write(h.wrappedKey)
+	return buf.Bytes()
+}
+
+// parseHeader splits a credentials file into its header and payload.
+// A nil header means the file uses the legacy headerless format.
+func parseHeader(data []byte) (*fileHeader, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(fileMagic)) {
+		return nil, data, nil
+	}
+
+	r := bytes.NewReader(data[len(fileMagic):])
+	fixed := make([]byte, 5)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, nil, fmt.Errorf("credentials file header is truncated")
+	}
+	if fixed[0] != fileFormatVersion {
+		return nil, nil, fmt.Errorf("unsupported credentials file version %d", fixed[0])
+	}
+
+	h := &fileHeader{
+		mode: fixed[1],
+		logN: fixed[2],
+		r:    fixed[3],
+		p:    fixed[4],
+		salt: make([]byte, saltSize),
+	}
+	if h.mode == keyModePassphrase && !h.validScrypt() {
+		return nil, nil, fmt.Errorf("credentials file header has invalid key derivation parameters")
+	}
+	if _, err := io.ReadFull(r, h.salt); err != nil {
+		return nil, nil, fmt.Errorf("credentials file header is truncated")
+	}
+
+	var wrappedLen uint16
+	if err := binary.Read(r, binary.BigEndian, &wrappedLen); err != nil {
+		return nil, nil, fmt.Errorf("credentials file header is truncated")
+	}
+	h.wrappedKey = make([]byte, wrappedLen)
+	if _, err := io.ReadFull(r, h.wrappedKey); err != nil {
+		return nil, nil, fmt.Errorf("credentials file header is truncated")
+	}
+
+	payload := data[len(data)-r.Len():]
+	return h, payload, nil
+}
+
+// newHeader creates a header that wraps dataKey with either the machine key or a passphrase
+func newHeader(dataKey, machineKey, passphrase []byte) (*fileHeader, error) {
+	h := &fileHeader{
+		mode: keyModeMachine,
+		salt: make([]byte, saltSize),
+	}
+	if _, err := io.ReadFull(rand.Reader, h.salt); err != nil {
+		return nil, err
+	}
+
+	wrappingKey := machineKey
+	if len(passphrase) > 0 {
+		h.mode = keyModePassphrase
+		h.logN, h.r, h.p = scryptLogN, scryptR, scryptP
+
+		var err error
+		wrappingKey, err = h.deriveKey(passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	wrapped, err := sealAESGCM(wrappingKey, dataKey, h.salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	h.wrappedKey = wrapped
+
+	return h, nil
+}
+
+// validScrypt reports whether the header's scrypt parameters are within bounds
+func (h *fileHeader) validScrypt() bool {
+	return h.logN >= minScryptLogN && h.logN <= maxScryptLogN &&
+		h.r >= 1 && h.r <= maxScryptR &&
+		h.p >= 1 && h.p <= maxScryptP
+}
+
+// deriveKey derives the key-encryption key from a passphrase using scrypt
+func (h *fileHeader) deriveKey(passphrase []byte) ([]byte, error) {
+	if !h.validScrypt() {
+		return nil, fmt.Errorf("invalid key derivation parameters")
+	}
+	key, err := scrypt.Key(passphrase, h.salt, 1<<h.logN, int(h.r), int(h.p), KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive passphrase key: %w", err)
+	}
+	return key, nil
+}
+
+// unwrap recovers the data key using the given key-encryption key
+func (h *fileHeader) unwrap(wrappingKey []byte) ([]byte, error) {
+	return openAESGCM(wrappingKey, h.wrappedKey, h.salt)
+}
+
+// newDataKey generates a random data-encryption key
+func newDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// writeUnlockCache stores the data key, sealed with the machine key, until expiry.
+// The header salt is bound into the cache so it is invalidated by a passphrase change.
+func writeUnlockCache(path string, machineKey, dataKey, salt []byte, expiry time.Time) error {
+	stamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(stamp, uint64(expiry.Unix()))
+
+	sealed, err := sealAESGCM(machineKey, dataKey, unlockCacheAAD(stamp, salt))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(stamp, sealed...), 0600)
+}
+
+// readUnlockCache returns the cached data key if the cache exists and has not expired
+func readUnlockCache(path string, machineKey, salt []byte) ([]byte, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	expiry := time.Unix(int64(binary.BigEndian.Uint64(data[:8])), 0)
+	if time.Now().After(expiry) {
+		os.Remove(path)
+		return nil, false
+	}
+
+	dataKey, err := openAESGCM(machineKey, data[8:], unlockCacheAAD(data[:8], salt))
+	if err != nil {
+		return nil, false
+	}
+
+	return dataKey, true
+}
+
+// unlockCacheAAD binds an unlock cache entry to its expiry and the credentials file salt
+func unlockCacheAAD(stamp, salt []byte) []byte {
+	aad := make([]byte, 0, len(stamp)+len(salt))
+	aad = append(aad, stamp...)
+	return append(aad, salt...)
+}
+
+// TerminalPassphrase reads a passphrase from the environment or, failing that,
+// prompts for it on the controlling terminal without echo. Without a terminal
+// it returns ErrNoTerminal.
+func TerminalPassphrase(prompt string) ([]byte, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	return promptPassphrase(prompt)
+}
+
+// UnlockPassphrase is TerminalPassphrase for unlocking the credentials file
+// before a command runs, where a missing terminal means the file stays locked
+func UnlockPassphrase(prompt string) ([]byte, error) {
+	passphrase, err := TerminalPassphrase(prompt)
+	if errors.Is(err, ErrNoTerminal) {
+		return nil, ErrCredentialsLocked
+	}
+	return passphrase, err
+}
+
+// promptPassphrase prompts for a passphrase on the controlling terminal without echo
+func promptPassphrase(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, ErrNoTerminal
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return bytes.TrimRight(passphrase, "\r\n"), nil
+}
+
+// ReadNewPassphrase prompts for a new passphrase twice and checks that both
+// entries match. It always prompts: the passphrase in the environment is the
+// current one, not a new one.
+func ReadNewPassphrase() ([]byte, error) {
+	first, err := promptPassphrase("New passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	second, err := promptPassphrase("Confirm new passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(first, second) {
+		return nil, fmt.Errorf("passphrases do not match")
+	}
+	if len(strings.TrimSpace(string(first))) == 0 {
+		return nil, fmt.Errorf("passphrase cannot be empty")
+	}
+
+	return first, nil
+}
@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEncryptedManager(t *testing.T, filePath string) *EncryptedFileTokenManager {
+	t.Helper()
+	manager, err := NewEncryptedFileTokenManager(filePath)
+	require.NoError(t, err)
+	manager.SetUnlockTimeout(0)
+	return manager
+}
+
+func staticPassphrase(passphrase string) PassphraseFunc {
+	return func(prompt string) ([]byte, error) {
+		return []byte(passphrase), nil
+	}
+}
+
+func TestEncryptedFileTokenManager_PassphraseMode(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials.enc")
+	creds := &types.AuthCredentials{
+		ServerURL: "https://test.atlassian.net",
+		Email:     "test@example.com",
+		Token:     "secret-token",
+	}
+
+	manager := newTestEncryptedManager(t, filePath)
+	require.NoError(t, manager.Store(context.Background(), creds))
+	require.NoError(t, manager.ChangePassphrase(nil, []byte("correct horse")))
+
+	enabled, err := manager.PassphraseEnabled()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	// Without a passphrase source the file cannot be opened
+	locked := newTestEncryptedManager(t, filePath)
+	_, err = locked.Get(context.Background(), creds.ServerURL)
+	assert.ErrorIs(t, err, ErrCredentialsLocked)
+
+	// A wrong passphrase is rejected
+	wrong := newTestEncryptedManager(t, filePath)
+	wrong.SetPassphraseFunc(staticPassphrase("battery staple"))
+	_, err = wrong.Get(context.Background(), creds.ServerURL)
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	// The right passphrase opens it
+	right := newTestEncryptedManager(t, filePath)
+	right.SetPassphraseFunc(staticPassphrase("correct horse"))
+	retrieved, err := right.Get(context.Background(), creds.ServerURL)
+	require.NoError(t, err)
+	assert.Equal(t, creds.Token, retrieved.Token)
+}
+
+func TestEncryptedFileTokenManager_ChangePassphrase(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials.enc")
+	creds := &types.AuthCredentials{
+		ServerURL: "https://test.atlassian.net",
+		Email:     "test@example.com",
+		Token:     "secret-token",
+	}
+
+	manager := newTestEncryptedManager(t, filePath)
+	require.NoError(t, manager.Store(context.Background(), creds))
+	require.NoError(t, manager.ChangePassphrase(nil, []byte("first")))
+
+	// Changing requires the current passphrase
+	err := manager.ChangePassphrase([]byte("wrong"), []byte("second"))
+	assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	require.NoError(t, manager.ChangePassphrase([]byte("first"), []byte("second")))
+
+	reader := newTestEncryptedManager(t, filePath)
+	reader.SetPassphraseFunc(staticPassphrase("second"))
+	retrieved, err := reader.Get(context.Background(), creds.ServerURL)
+	require.NoError(t, err)
+	assert.Equal(t, creds.Token, retrieved.Token)
+
+	// Removing the passphrase reverts to machine-key protection
+	require.NoError(t, manager.ChangePassphrase([]byte("second"), nil))
+	enabled, err := manager.PassphraseEnabled()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	retrieved, err = newTestEncryptedManager(t, filePath).Get(context.Background(), creds.ServerURL)
+	require.NoError(t, err)
+	assert.Equal(t, creds.Token, retrieved.Token)
+}
+
+func TestEncryptedFileTokenManager_UnlockCache(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials.enc")
+	creds := &types.AuthCredentials{
+		ServerURL: "https://test.atlassian.net",
+		Email:     "test@example.com",
+		Token:     "secret-token",
+	}
+
+	manager := newTestEncryptedManager(t, filePath)
+	require.NoError(t, manager.Store(context.Background(), creds))
+	require.NoError(t, manager.ChangePassphrase(nil, []byte("pass")))
+
+	assert.ErrorIs(t, manager.Unlock([]byte("nope"), time.Minute), ErrInvalidPassphrase)
+	require.NoError(t, manager.Unlock([]byte("pass"), time.Minute))
+
+	// A fresh manager (a later command) reuses the cached unlock
+	_, err := newTestEncryptedManager(t, filePath).Get(context.Background(), creds.ServerURL)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filePath + ".unlock")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	require.NoError(t, manager.Lock())
+	_, err = newTestEncryptedManager(t, filePath).Get(context.Background(), creds.ServerURL)
+	assert.ErrorIs(t, err, ErrCredentialsLocked)
+}
+
+func TestEncryptedFileTokenManager_ExpiredUnlockCache(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials.enc")
+	manager := newTestEncryptedManager(t, filePath)
+	require.NoError(t, manager.ChangePassphrase(nil, []byte("pass")))
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	header, _, err := parseHeader(data)
+	require.NoError(t, err)
+
+	unlockPath := filePath + ".unlock"
+	require.NoError(t, writeUnlockCache(unlockPath, manager.key, make([]byte, KeySize), header.salt, time.Now().Add(-time.Second)))
+
+	_, ok := readUnlockCache(unlockPath, manager.key, header.salt)
+	assert.False(t, ok)
+	_, err = os.Stat(unlockPath)
+	assert.True(t, os.IsNotExist(err), "expired unlock cache should be removed")
+}
+
+func TestEncryptedFileTokenManager_LegacyFormatMigration(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials.enc")
+	manager := newTestEncryptedManager(t, filePath)
+
+	// Write a file in the original headerless format
+	legacy := map[string]*types.AuthCredentials{
+		"https://legacy.atlassian.net": {
+			ServerURL: "https://legacy.atlassian.net",
+			Email:     "legacy@example.com",
+			Token:     "legacy-token",
+		},
+	}
+	plaintext, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	ciphertext, err := sealAESGCM(manager.key, plaintext, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filePath, ciphertext, 0600))
+
+	retrieved, err := manager.Get(context.Background(), "https://legacy.atlassian.net")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", retrieved.Token)
+
+	// The next write upgrades the file to the versioned format
+	require.NoError(t, manager.Store(context.Background(), &types.AuthCredentials{
+		ServerURL: "https://new.atlassian.net",
+		Email:     "new@example.com",
+		Token:     "new-token",
+	}))
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	header, _, err := parseHeader(data)
+	require.NoError(t, err)
+	require.NotNil(t, header)
+	assert.Equal(t, keyModeMachine, header.mode)
+
+	retrieved, err = manager.Get(context.Background(), "https://legacy.atlassian.net")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", retrieved.Token)
+}
+
+func TestParseHeader_RejectsScryptParameters(t *testing.T) {
+	dataKey, err := newDataKey()
+	require.NoError(t, err)
+	header, err := newHeader(dataKey, nil, []byte("correct horse"))
+	require.NoError(t, err)
+
+	parsed, _, err := parseHeader(header.marshal())
+	require.NoError(t, err)
+	assert.Equal(t, byte(scryptLogN), parsed.logN)
+
+	for _, damage := range []func(h *fileHeader){
+		func(h *fileHeader) { h.logN = 63 },
+		func(h *fileHeader) { h.logN = 1 },
+		func(h *fileHeader) { h.r = 255 },
+		func(h *fileHeader) { h.p = 0 },
+	} {
+		damaged := *header
+		damage(&damaged)
+		_, _, err := parseHeader(damaged.marshal())
+		assert.ErrorContains(t, err, "invalid key derivation parameters")
+	}
+}
+
+func TestPassphrasePrompts_WithoutTerminal(t *testing.T) {
+	// Tests run without a terminal on stdin
+	t.Setenv(PassphraseEnvVar, "")
+
+	_, err := TerminalPassphrase("Passphrase: ")
+	assert.ErrorIs(t, err, ErrNoTerminal)
+	_, err = UnlockPassphrase("Passphrase: ")
+	assert.ErrorIs(t, err, ErrCredentialsLocked)
+
+	// The passphrase in the environment is not taken as a new one
+	t.Setenv(PassphraseEnvVar, "current")
+	passphrase, err := TerminalPassphrase("Passphrase: ")
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(passphrase))
+	_, err = ReadNewPassphrase()
+	assert.ErrorIs(t, err, ErrNoTerminal)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -23,28 +24,50 @@ type Factory struct {
 	confluenceClients map[ClientKey]confluence.ConfluenceClient
 	mu                sync.RWMutex
 	httpClient        *http.Client
+	transport         *http.Transport
+	requestTimeout    time.Duration
 }
 
+// DefaultRequestTimeout bounds each request until SetRequestTimeout is called
+const DefaultRequestTimeout = 30 * time.Second
+
 // NewFactory creates a new client factory with shared HTTP transport
 func NewFactory() *Factory {
-	// Create shared HTTP client with connection pooling
+	// Create shared HTTP client with connection pooling. There is no overall
+	// client timeout, which would cut off long downloads and uploads; the
+	// transport bounds each request instead.
 	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
 		IdleConnTimeout:     90 * time.Second,
 		DisableCompression:  false,
 	}
 
-	httpClient := &http.Client{
-		Transport: transport,
-		Timeout:   30 * time.Second,
-	}
-
-	return &Factory{
+	f := &Factory{
 		jiraClients:       make(map[ClientKey]jira.JiraClient),
 		confluenceClients: make(map[ClientKey]confluence.ConfluenceClient),
-		httpClient:        httpClient,
+		httpClient:        &http.Client{Transport: transport},
+		transport:         transport,
 	}
+	f.SetRequestTimeout(DefaultRequestTimeout)
+	return f
+}
+
+// SetRequestTimeout bounds how long each request may take to connect and to
+// receive the response headers; reading the body is not limited, so large
+// transfers can take as long as they need. Zero disables the limit. It must be
+// called before any client makes a request.
+func (f *Factory) SetRequestTimeout(timeout time.Duration) {
+	f.requestTimeout = timeout
+	f.transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+	f.transport.TLSHandshakeTimeout = timeout
+	f.transport.ResponseHeaderTimeout = timeout
+}
+
+// RequestTimeout returns the limit set by SetRequestTimeout
+func (f *Factory) RequestTimeout() time.Duration {
+	return f.requestTimeout
 }
 
 // GetJiraClient returns a cached or new JIRA client
@@ -72,7 +95,7 @@ func (f *Factory) GetJiraClient(ctx context.Context, serverURL, email, token str
 	}
 
 	// Create new JIRA client
-	client, err := jira.NewAtlassianJiraClient(serverURL, email, token)
+	client, err := jira.NewAtlassianJiraClientWithHTTP(f.httpClient, serverURL, email, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JIRA client: %w", err)
 	}
@@ -106,7 +129,7 @@ func (f *Factory) GetConfluenceClient(ctx context.Context, serverURL, email, tok
 	}
 
 	// Create new Confluence client
-	client, err := confluence.NewAtlassianConfluenceClient(serverURL, email, token)
+	client, err := confluence.NewAtlassianConfluenceClientWithHTTP(f.httpClient, serverURL, email, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Confluence client: %w", err)
 	}
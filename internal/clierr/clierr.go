@@ -0,0 +1,274 @@
+package clierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Kind classifies an error so scripts can react to it without parsing messages
+type Kind string
+
+const (
+	KindGeneral    Kind = "general"
+	KindValidation Kind = "validation"
+	KindAuth       Kind = "auth"
+	KindPermission Kind = "permission"
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+	KindRateLimit  Kind = "rate_limit"
+	KindNetwork    Kind = "network"
+	KindCanceled   Kind = "canceled"
+)
+
+// Exit codes returned by the CLI. These values are part of the public interface
+// and must not change between releases.
+const (
+	ExitOK         = 0
+	ExitGeneral    = 1
+	ExitValidation = 2
+	ExitAuth       = 3
+	ExitPermission = 4
+	ExitNotFound   = 5
+	ExitConflict   = 6
+	ExitRateLimit  = 7
+	ExitNetwork    = 8
+	ExitCanceled   = 130 // Conventional shell status for termination by SIGINT
+)
+
+// exitCodes maps each kind to its documented exit code
+var exitCodes = map[Kind]int{
+	KindGeneral:    ExitGeneral,
+	KindValidation: ExitValidation,
+	KindAuth:       ExitAuth,
+	KindPermission: ExitPermission,
+	KindNotFound:   ExitNotFound,
+	KindConflict:   ExitConflict,
+	KindRateLimit:  ExitRateLimit,
+	KindNetwork:    ExitNetwork,
+	KindCanceled:   ExitCanceled,
+}
+
+// Error is a classified CLI error
+type Error struct {
+	Kind       Kind
+	Message    string
+	StatusCode int           // HTTP status code, if the error came from an API response
+	RetryAfter time.Duration // Server-provided back-off for rate limit errors
+	Err        error
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if e.StatusCode > 0 {
+		msg = fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+	}
+	if e.Err != nil {
+		if msg == "" {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates a classified error
+func New(kind Kind, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap classifies an existing error with additional context
+func Wrap(kind Kind, err error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// FromResponse classifies an error returned by a go-atlassian call using the HTTP
+// response when available, and the transport error otherwise
+func FromResponse(response *models.ResponseScheme, err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf(format, args...)
+
+	if response == nil || response.Code == 0 {
+		return &Error{Kind: kindFromTransport(err), Message: message, Err: err}
+	}
+
+	apiErr := &Error{
+		Kind:       KindFromStatus(response.Code),
+		Message:    message,
+		StatusCode: response.Code,
+		Err:        err,
+	}
+
+	// Prefer the server's explanation over go-atlassian's generic sentinel errors
+	if detail := apiErrorDetail(response.Bytes.Bytes()); detail != "" {
+		apiErr.Err = errors.New(detail)
+	}
+
+	if response.Response != nil && apiErr.Kind == KindRateLimit {
+		if seconds, convErr := strconv.Atoi(response.Header.Get("Retry-After")); convErr == nil {
+			apiErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return apiErr
+}
+
+// FromStatus classifies a raw HTTP status code and body
+func FromStatus(statusCode int, body []byte, format string, args ...interface{}) *Error {
+	apiErr := &Error{
+		Kind:       KindFromStatus(statusCode),
+		Message:    fmt.Sprintf(format, args...),
+		StatusCode: statusCode,
+	}
+	if detail := apiErrorDetail(body); detail != "" {
+		apiErr.Err = errors.New(detail)
+	}
+	return apiErr
+}
+
+// KindFromStatus maps an HTTP status code to an error kind
+func KindFromStatus(statusCode int) Kind {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return KindAuth
+	case statusCode == http.StatusForbidden:
+		return KindPermission
+	case statusCode == http.StatusNotFound || statusCode == http.StatusGone:
+		return KindNotFound
+	case statusCode == http.StatusConflict || statusCode == http.StatusPreconditionFailed:
+		return KindConflict
+	case statusCode == http.StatusTooManyRequests:
+		return KindRateLimit
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		return KindValidation
+	case statusCode == http.StatusBadGateway || statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout:
+		return KindNetwork
+	default:
+		return KindGeneral
+	}
+}
+
+// kindFromTransport classifies errors that occurred before a response was received
+func kindFromTransport(err error) Kind {
+	if errors.Is(err, context.Canceled) {
+		return KindCanceled
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &netErr) || errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded) {
+		return KindNetwork
+	}
+	return KindGeneral
+}
+
+// apiErrorDetail extracts the human-readable message from an Atlassian error body
+func apiErrorDetail(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+		Message       string            `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+
+	parts := append([]string{}, payload.ErrorMessages...)
+	fields := make([]string, 0, len(payload.Errors))
+	for field := range payload.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, payload.Errors[field]))
+	}
+	if payload.Message != "" {
+		parts = append(parts, payload.Message)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// KindOf returns the kind of the first classified error in the chain. Unclassified
+// context errors are reported as cancellations and timeouts.
+func KindOf(err error) Kind {
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		return cliErr.Kind
+	}
+	if errors.Is(err, context.Canceled) {
+		return KindCanceled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindNetwork
+	}
+	return KindGeneral
+}
+
+// StatusCodeOf returns the HTTP status code of the first classified error in the chain
+func StatusCodeOf(err error) int {
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		return cliErr.StatusCode
+	}
+	return 0
+}
+
+// ExitCode returns the documented process exit code for an error
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if code, ok := exitCodes[KindOf(err)]; ok {
+		return code
+	}
+	return ExitGeneral
+}
+
+// JSONError is the machine-readable error object written to stderr
+type JSONError struct {
+	Error struct {
+		Kind       Kind   `json:"kind"`
+		Message    string `json:"message"`
+		ExitCode   int    `json:"exit_code"`
+		StatusCode int    `json:"status_code,omitempty"`
+		RetryAfter int    `json:"retry_after_seconds,omitempty"`
+	} `json:"error"`
+}
+
+// ToJSON builds the machine-readable representation of an error
+func ToJSON(err error) JSONError {
+	var out JSONError
+	out.Error.Kind = KindOf(err)
+	out.Error.Message = err.Error()
+	out.Error.ExitCode = ExitCode(err)
+	out.Error.StatusCode = StatusCodeOf(err)
+
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		out.Error.RetryAfter = int(cliErr.RetryAfter.Seconds())
+	}
+
+	return out
+}
@@ -0,0 +1,90 @@
+package clierr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindFromStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		kind   Kind
+		exit   int
+	}{
+		{http.StatusUnauthorized, KindAuth, ExitAuth},
+		{http.StatusForbidden, KindPermission, ExitPermission},
+		{http.StatusNotFound, KindNotFound, ExitNotFound},
+		{http.StatusConflict, KindConflict, ExitConflict},
+		{http.StatusTooManyRequests, KindRateLimit, ExitRateLimit},
+		{http.StatusBadRequest, KindValidation, ExitValidation},
+		{http.StatusServiceUnavailable, KindNetwork, ExitNetwork},
+		{http.StatusInternalServerError, KindGeneral, ExitGeneral},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.kind, KindFromStatus(tt.status))
+
+			err := FromStatus(tt.status, nil, "request failed")
+			assert.Equal(t, tt.exit, ExitCode(err))
+		})
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	response := &models.ResponseScheme{
+		Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}},
+		Code:     http.StatusTooManyRequests,
+	}
+	response.Bytes.WriteString(`{"errorMessages":["Rate limit exceeded"]}`)
+
+	err := FromResponse(response, errors.New("client: invalid status code"), "failed to get issue %s", "DEMO-1")
+
+	var cliErr *Error
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, KindRateLimit, cliErr.Kind)
+	assert.Equal(t, "failed to get issue DEMO-1 (status 429): Rate limit exceeded", err.Error())
+
+	out := ToJSON(err)
+	assert.Equal(t, 30, out.Error.RetryAfter)
+	assert.Equal(t, ExitRateLimit, out.Error.ExitCode)
+	assert.Equal(t, 429, out.Error.StatusCode)
+}
+
+func TestFromResponseWithoutResponse(t *testing.T) {
+	assert.NoError(t, FromResponse(nil, nil, "unused"))
+
+	err := FromResponse(nil, errors.New("boom"), "failed to list pages")
+	assert.Equal(t, KindGeneral, KindOf(err))
+	assert.Equal(t, "failed to list pages: boom", err.Error())
+}
+
+func TestExitCodeThroughWrapping(t *testing.T) {
+	base := New(KindNotFound, "issue not found")
+	wrapped := fmt.Errorf("failed to get issue: %w", base)
+
+	assert.Equal(t, ExitNotFound, ExitCode(wrapped))
+	assert.Equal(t, ExitGeneral, ExitCode(errors.New("plain")))
+	assert.Equal(t, ExitOK, ExitCode(nil))
+}
+
+func TestContextErrors(t *testing.T) {
+	canceled := FromResponse(nil, fmt.Errorf("Get \"https://x\": %w", context.Canceled), "failed to get page")
+	assert.Equal(t, KindCanceled, KindOf(canceled))
+	assert.Equal(t, ExitCanceled, ExitCode(canceled))
+
+	assert.Equal(t, KindCanceled, KindOf(fmt.Errorf("interrupted: %w", context.Canceled)))
+	assert.Equal(t, KindNetwork, KindOf(fmt.Errorf("slow: %w", context.DeadlineExceeded)))
+}
+
+func TestAPIErrorDetail(t *testing.T) {
+	body := []byte(`{"errorMessages":["Issue does not exist"],"errors":{"summary":"required","assignee":"invalid"}}`)
+	assert.Equal(t, "Issue does not exist; assignee: invalid; summary: required", apiErrorDetail(body))
+	assert.Equal(t, "", apiErrorDetail([]byte("<html>")))
+}
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/audit"
+
+	"github.com/spf13/cobra"
+)
+
+// AuditAnnotation marks commands that modify remote or local state and must be audited
+const AuditAnnotation = "audit"
+
+// MarkAudited flags a command as mutating so its execution is written to the audit log
+func MarkAudited(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[AuditAnnotation] = "true"
+	return cmd
+}
+
+// IsAudited reports whether a command was marked with MarkAudited
+func IsAudited(cmd *cobra.Command) bool {
+	return cmd.Annotations[AuditAnnotation] == "true"
+}
+
+// GetAuditEvent retrieves the pending audit event from the command context
+func GetAuditEvent(cmd *cobra.Command) *audit.Event {
+	if cmd.Context() == nil {
+		return nil
+	}
+	if e := cmd.Context().Value(AuditKey); e != nil {
+		return e.(*audit.Event)
+	}
+	return nil
+}
+
+// SetAuditTarget records the key or ID of the object a command modified,
+// e.g. the key of a newly created issue
+func SetAuditTarget(cmd *cobra.Command, target string) {
+	if event := GetAuditEvent(cmd); event != nil {
+		event.Target = target
+	}
+}
@@ -0,0 +1,90 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"context"
+	"fmt"
+	"io"
+)
+
+// BulkFailure records an item that could not be processed
+type BulkFailure struct {
+	Item  string `json:"item"`
+	Error string `json:"error"`
+}
+
+// BulkResult summarizes a bulk operation that may have been interrupted
+type BulkResult struct {
+	Total     int           `json:"total"`
+	Completed []string      `json:"completed"`
+	Failed    []BulkFailure `json:"failed,omitempty"`
+	Skipped   []string      `json:"skipped,omitempty"`
+	Canceled  bool          `json:"canceled,omitempty"`
+
+	cause error
+}
+
+// RunBulk applies fn to each item in order. Failures are recorded and processing
+// continues; once ctx is cancelled or times out the remaining items are skipped.
+func RunBulk(ctx context.Context, items []string, fn func(ctx context.Context, item string) error) *BulkResult {
+	result := &BulkResult{Total: len(items), Completed: []string{}}
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			result.stop(err, items[i:])
+			return result
+		}
+
+		if err := fn(ctx, item); err != nil {
+			// An item interrupted mid-request was not processed, so it is skipped rather than failed
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				result.stop(ctxErr, items[i:])
+				return result
+			}
+			result.Failed = append(result.Failed, BulkFailure{Item: item, Error: err.Error()})
+			continue
+		}
+
+		result.Completed = append(result.Completed, item)
+	}
+
+	return result
+}
+
+// stop marks the remaining items as skipped because of a context error
+func (r *BulkResult) stop(err error, remaining []string) {
+	r.Canceled = true
+	r.cause = err
+	r.Skipped = append(r.Skipped, remaining...)
+}
+
+// Err returns the error the command should exit with, if any
+func (r *BulkResult) Err() error {
+	if r.Canceled {
+		return clierr.Wrap(clierr.KindOf(r.cause), r.cause,
+			"stopped after %d of %d items", len(r.Completed)+len(r.Failed), r.Total)
+	}
+	if len(r.Failed) > 0 {
+		return clierr.New(clierr.KindGeneral, "%d of %d items failed", len(r.Failed), r.Total)
+	}
+	return nil
+}
+
+// PrintSummary writes a human-readable account of what completed
+func (r *BulkResult) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "Completed %d of %d", len(r.Completed), r.Total)
+	if len(r.Failed) > 0 {
+		fmt.Fprintf(w, ", %d failed", len(r.Failed))
+	}
+	if len(r.Skipped) > 0 {
+		fmt.Fprintf(w, ", %d skipped", len(r.Skipped))
+	}
+	fmt.Fprintln(w)
+
+	for _, failure := range r.Failed {
+		fmt.Fprintf(w, "  failed %s: %s\n", failure.Item, failure.Error)
+	}
+	if r.Canceled {
+		fmt.Fprintf(w, "Interrupted (%v); not processed: %v\n", r.cause, r.Skipped)
+	}
+}
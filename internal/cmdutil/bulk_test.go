@@ -0,0 +1,49 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBulk_CollectsFailures(t *testing.T) {
+	result := RunBulk(context.Background(), []string{"1", "2", "3"}, func(ctx context.Context, item string) error {
+		if item == "2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	assert.Equal(t, []string{"1", "3"}, result.Completed)
+	assert.Equal(t, []BulkFailure{{Item: "2", Error: "boom"}}, result.Failed)
+	assert.False(t, result.Canceled)
+	assert.EqualError(t, result.Err(), "1 of 3 items failed")
+}
+
+func TestRunBulk_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result := RunBulk(ctx, []string{"1", "2", "3", "4"}, func(ctx context.Context, item string) error {
+		if item == "2" {
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	assert.Equal(t, []string{"1"}, result.Completed)
+	assert.Empty(t, result.Failed)
+	assert.Equal(t, []string{"2", "3", "4"}, result.Skipped)
+	assert.True(t, result.Canceled)
+	assert.Equal(t, clierr.ExitCanceled, clierr.ExitCode(result.Err()))
+
+	var out bytes.Buffer
+	result.PrintSummary(&out)
+	assert.Contains(t, out.String(), "Completed 1 of 4, 3 skipped")
+	assert.Contains(t, out.String(), "not processed: [2 3 4]")
+}
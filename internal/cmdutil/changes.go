@@ -0,0 +1,35 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ConfirmChanges previews the changes a command is about to make, one per line,
+// and asks to apply them. The preview goes to stdout unless the output format
+// is structured, so it is kept with the command's output in logs. It reports
+// false, without asking, when nothing would change or for --dry-run.
+func ConfirmChanges(cmd *cobra.Command, changes []string, prompt string) (bool, error) {
+	formatter, err := GetFormatter(cmd)
+	if err != nil {
+		return false, err
+	}
+	if !formatter.IsStructured() {
+		out := cmd.OutOrStdout()
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "No changes")
+		}
+		for _, change := range changes {
+			fmt.Fprintln(out, change)
+		}
+	}
+
+	if len(changes) == 0 || IsDryRun(cmd) {
+		return false, nil
+	}
+	if err := Confirm(cmd, prompt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
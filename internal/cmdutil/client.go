@@ -0,0 +1,29 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/auth"
+	"atlassian-cli/internal/config"
+	"atlassian-cli/internal/confluence"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// GetConfluenceClient returns the Confluence client for the configured server
+func GetConfluenceClient(cmd *cobra.Command, tokenManager auth.TokenManager) (confluence.ConfluenceClient, error) {
+	cfg, err := config.LoadConfig(GetConfigPath(cmd))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	creds, err := tokenManager.Get(cmd.Context(), cfg.APIEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("not authenticated: %w", err)
+	}
+
+	client, err := GetFactory(cmd).GetConfluenceClient(cmd.Context(), cfg.APIEndpoint, creds.Email, creds.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Confluence client: %w", err)
+	}
+	return client, nil
+}
@@ -0,0 +1,48 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// AddConfirmFlags adds --yes and --dry-run to a destructive command
+func AddConfirmFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().Bool("dry-run", false, "List what would be affected without changing anything")
+}
+
+// IsDryRun reports whether --dry-run was given
+func IsDryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return dryRun
+}
+
+// Confirm asks the user to confirm a destructive action unless --yes was given.
+// Without a terminal to ask on, --yes is required. Declining returns a canceled error.
+func Confirm(cmd *cobra.Command, prompt string) error {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return nil
+	}
+
+	in := cmd.InOrStdin()
+	if file, ok := in.(*os.File); ok && !term.IsTerminal(int(file.Fd())) {
+		return clierr.New(clierr.KindValidation, "confirmation required: pass --yes to run non-interactively")
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return clierr.New(clierr.KindCanceled, "aborted")
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return clierr.New(clierr.KindCanceled, "aborted")
+}
@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConfirmCmd(input string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	AddConfirmFlags(cmd)
+	cmd.SetIn(strings.NewReader(input))
+	var prompt bytes.Buffer
+	cmd.SetErr(&prompt)
+	return cmd, &prompt
+}
+
+func TestConfirm(t *testing.T) {
+	cmd, prompt := newConfirmCmd("y\n")
+	assert.NoError(t, Confirm(cmd, "Delete 2 pages?"))
+	assert.Equal(t, "Delete 2 pages? [y/N]: ", prompt.String())
+
+	cmd, _ = newConfirmCmd("yes\n")
+	assert.NoError(t, Confirm(cmd, "Delete?"))
+
+	for _, answer := range []string{"n\n", "\n", ""} {
+		cmd, _ = newConfirmCmd(answer)
+		assert.Equal(t, clierr.KindCanceled, clierr.KindOf(Confirm(cmd, "Delete?")), "answer %q", answer)
+	}
+}
+
+func TestConfirm_Yes(t *testing.T) {
+	cmd, prompt := newConfirmCmd("")
+	assert.NoError(t, cmd.Flags().Set("yes", "true"))
+	assert.NoError(t, Confirm(cmd, "Delete?"))
+	assert.Empty(t, prompt.String())
+}
+
+func TestConfirmChanges(t *testing.T) {
+	v := viper.New()
+	v.Set("output", "table")
+	cmd, _ := newConfirmCmd("y\n")
+	cmd.SetContext(context.WithValue(context.Background(), ViperKey, v))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	apply, err := ConfirmChanges(cmd, nil, "Apply?")
+	assert.NoError(t, err)
+	assert.False(t, apply)
+	assert.Equal(t, "No changes\n", out.String())
+
+	out.Reset()
+	apply, err = ConfirmChanges(cmd, []string{"+ read space group ops"}, "Apply 1 change?")
+	assert.NoError(t, err)
+	assert.True(t, apply)
+	assert.Equal(t, "+ read space group ops\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	apply, err = ConfirmChanges(cmd, []string{"+ read space group ops"}, "Apply 1 change?")
+	assert.NoError(t, err)
+	assert.False(t, apply)
+	assert.Equal(t, "+ read space group ops\n", out.String())
+}
@@ -0,0 +1,69 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/output"
+	"bytes"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// GetFormatter returns the formatter for the output format, --template and --query
+// selected for the command
+func GetFormatter(cmd *cobra.Command) (*output.Formatter, error) {
+	v := GetViperFromCmd(cmd)
+	formatter := output.NewFormatter(GetOutputFormat(cmd))
+	formatter.SetColor(ColorEnabled(cmd))
+	formatter.SetTerminal(IsTerminal(cmd))
+
+	if text := v.GetString("template"); text != "" {
+		if err := formatter.SetTemplate(text); err != nil {
+			return nil, clierr.Wrap(clierr.KindValidation, err, "invalid --template")
+		}
+	}
+	if expr := v.GetString("query"); expr != "" {
+		if err := formatter.SetQuery(expr); err != nil {
+			return nil, clierr.Wrap(clierr.KindValidation, err, "invalid --query")
+		}
+	}
+
+	return formatter, nil
+}
+
+// WriteOutput writes data to the command's stdout in the selected format. JSON and
+// YAML serialize data; table, csv, tsv and markdown render table (derived from data
+// when nil).
+func WriteOutput(cmd *cobra.Command, data interface{}, table *output.Table) error {
+	return WriteList(cmd, data, nil, table)
+}
+
+// WriteList is WriteOutput for list responses, where items holds the individual
+// records that --template renders one at a time. Output longer than the terminal
+// is shown through the pager.
+func WriteList(cmd *cobra.Command, data interface{}, items interface{}, table *output.Table) error {
+	formatter, err := GetFormatter(cmd)
+	if err != nil {
+		return err
+	}
+
+	pager := newPager(cmd)
+	if pager == nil {
+		return formatter.WriteList(cmd.OutOrStdout(), data, items, table)
+	}
+	var buf bytes.Buffer
+	if err := formatter.WriteList(&buf, data, items, table); err != nil {
+		return err
+	}
+	return pager.Show(buf.Bytes())
+}
+
+// WriteText writes preformatted text to the command's stdout, through the pager
+// when it is longer than the terminal
+func WriteText(cmd *cobra.Command, text string) error {
+	if pager := newPager(cmd); pager != nil {
+		return pager.Show([]byte(text))
+	}
+	_, err := io.WriteString(cmd.OutOrStdout(), text)
+	return err
+}
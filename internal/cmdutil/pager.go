@@ -0,0 +1,91 @@
+package cmdutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// pager pipes output longer than the terminal through an external program
+type pager struct {
+	command string
+	out     *os.File
+	errOut  io.Writer
+	height  int
+}
+
+// pagerCommand returns the pager program to use, or "" when paging is disabled.
+// The pager config key (or ATLASSIAN_PAGER) takes precedence over $PAGER.
+func pagerCommand(v *viper.Viper) string {
+	if v.GetBool("no_pager") {
+		return ""
+	}
+	command := v.GetString("pager")
+	if command == "" {
+		var set bool
+		if command, set = os.LookupEnv("PAGER"); !set {
+			command = "less"
+		}
+	}
+	if command = strings.TrimSpace(command); command == "cat" {
+		return ""
+	}
+	return command
+}
+
+// newPager returns a pager for the command's output, or nil when output is not a
+// terminal or paging is disabled
+func newPager(cmd *cobra.Command) *pager {
+	file, ok := terminalFile(cmd.OutOrStdout())
+	if !ok {
+		return nil
+	}
+	command := pagerCommand(GetViperFromCmd(cmd))
+	if command == "" {
+		return nil
+	}
+	_, height, err := term.GetSize(int(file.Fd()))
+	if err != nil || height <= 0 {
+		return nil
+	}
+	return &pager{command: command, out: file, errOut: cmd.ErrOrStderr(), height: height}
+}
+
+// needsPaging reports whether content is too long to fit on one screen
+func needsPaging(content []byte, height int) bool {
+	// Leave a line for the shell prompt
+	return bytes.Count(content, []byte("\n")) >= height
+}
+
+// Show writes content through the pager when it does not fit on the screen, and
+// directly otherwise or when the pager cannot be started
+func (p *pager) Show(content []byte) error {
+	if !needsPaging(content, p.height) {
+		_, err := p.out.Write(content)
+		return err
+	}
+
+	args := strings.Fields(p.command)
+	pagerCmd := exec.Command(args[0], args[1:]...)
+	pagerCmd.Stdin = bytes.NewReader(content)
+	pagerCmd.Stdout = p.out
+	pagerCmd.Stderr = p.errOut
+	if _, set := os.LookupEnv("LESS"); !set {
+		// Quit if one screen, pass colors through, keep output on screen after exit
+		pagerCmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	if err := pagerCmd.Start(); err != nil {
+		_, err := p.out.Write(content)
+		return err
+	}
+	// The pager exiting early (e.g. the user quitting) is not a command failure
+	_ = pagerCmd.Wait()
+	return nil
+}
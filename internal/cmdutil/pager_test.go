@@ -0,0 +1,51 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "more")
+	v := viper.New()
+	assert.Equal(t, "more", pagerCommand(v))
+
+	v.Set("pager", "less -S")
+	assert.Equal(t, "less -S", pagerCommand(v))
+
+	v.Set("no_pager", true)
+	assert.Equal(t, "", pagerCommand(v))
+
+	// cat and an empty $PAGER disable paging
+	v = viper.New()
+	t.Setenv("PAGER", "cat")
+	assert.Equal(t, "", pagerCommand(v))
+	t.Setenv("PAGER", "")
+	assert.Equal(t, "", pagerCommand(v))
+}
+
+func TestNeedsPaging(t *testing.T) {
+	assert.False(t, needsPaging([]byte("a\nb\n"), 3))
+	assert.True(t, needsPaging([]byte("a\nb\nc\n"), 3))
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	cmd, _ := newPagingCmd("table")
+
+	// Output to a buffer is not a terminal
+	assert.False(t, ColorEnabled(cmd))
+
+	t.Setenv("CLICOLOR_FORCE", "1")
+	assert.True(t, ColorEnabled(cmd))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, ColorEnabled(cmd))
+
+	t.Setenv("NO_COLOR", "")
+	GetViperFromCmd(cmd).Set("no_color", true)
+	assert.False(t, ColorEnabled(cmd))
+}
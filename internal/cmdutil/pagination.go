@@ -0,0 +1,70 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// PageResult is one page of a paginated API call
+type PageResult[T any] struct {
+	Items []T
+	Next  int  // Offset of the following page
+	More  bool // Whether another page is available
+}
+
+// PageFunc fetches the page of results starting at offset startAt
+type PageFunc[T any] func(ctx context.Context, startAt int) (PageResult[T], error)
+
+// FetchPages fetches the page at startAt and, when all is set, every following
+// page. With NDJSON output each page's records are written as soon as they
+// arrive and streamed is true; otherwise the records are returned for the caller
+// to output.
+func FetchPages[T any](cmd *cobra.Command, startAt int, all bool, fetch PageFunc[T]) (items []T, streamed bool, err error) {
+	formatter, err := GetFormatter(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+
+	streaming := formatter.IsStreaming()
+	if streaming && GetTableOptions(cmd).SortBy != "" {
+		return nil, false, clierr.New(clierr.KindValidation, "--sort-by cannot be used with ndjson output, which is written as results arrive")
+	}
+
+	ctx := cmd.Context()
+	for {
+		page, err := fetch(ctx, startAt)
+		if err != nil {
+			return items, streaming, err
+		}
+
+		if streaming {
+			if err := formatter.WriteList(cmd.OutOrStdout(), nil, page.Items, nil); err != nil {
+				return nil, true, err
+			}
+		} else {
+			items = append(items, page.Items...)
+		}
+
+		if !all || !page.More || page.Next <= startAt {
+			return items, streaming, nil
+		}
+		startAt = page.Next
+	}
+}
+
+// OffsetPage describes a page from an API that reports its offset and the total
+// number of results, as JIRA searches and Confluence CQL searches do
+func OffsetPage[T any](items []T, startAt, total int) PageResult[T] {
+	next := startAt + len(items)
+	return PageResult[T]{Items: items, Next: next, More: len(items) > 0 && next < total}
+}
+
+// CursorPage describes a page from an API that returns the next offset as a
+// cursor string, as the Confluence v1 list endpoints do
+func CursorPage[T any](items []T, nextCursor string) PageResult[T] {
+	next, err := strconv.Atoi(nextCursor)
+	return PageResult[T]{Items: items, Next: next, More: err == nil && nextCursor != ""}
+}
@@ -0,0 +1,102 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPagingCmd creates a command writing format output to a buffer
+func newPagingCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	v := viper.New()
+	v.Set("output", format)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), ViperKey, v))
+	AddTableFlags(cmd, []string{"key"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+// fakePages serves items in pages of two and records the offsets requested
+func fakePages(items []string, calls *[]int) PageFunc[string] {
+	return func(ctx context.Context, startAt int) (PageResult[string], error) {
+		*calls = append(*calls, startAt)
+		end := min(startAt+2, len(items))
+		return OffsetPage(items[startAt:end], startAt, len(items)), nil
+	}
+}
+
+func TestFetchPages_SinglePage(t *testing.T) {
+	cmd, out := newPagingCmd("table")
+	var calls []int
+
+	items, streamed, err := FetchPages(cmd, 0, false, fakePages([]string{"a", "b", "c"}, &calls))
+	assert.NoError(t, err)
+	assert.False(t, streamed)
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.Equal(t, []int{0}, calls)
+	assert.Empty(t, out.String())
+}
+
+func TestFetchPages_All(t *testing.T) {
+	cmd, _ := newPagingCmd("json")
+	var calls []int
+
+	items, streamed, err := FetchPages(cmd, 1, true, fakePages([]string{"a", "b", "c", "d", "e"}, &calls))
+	assert.NoError(t, err)
+	assert.False(t, streamed)
+	assert.Equal(t, []string{"b", "c", "d", "e"}, items)
+	assert.Equal(t, []int{1, 3}, calls)
+}
+
+func TestFetchPages_StreamsNDJSON(t *testing.T) {
+	cmd, out := newPagingCmd("ndjson")
+	var calls []int
+
+	items, streamed, err := FetchPages(cmd, 0, true, fakePages([]string{"a", "b", "c"}, &calls))
+	assert.NoError(t, err)
+	assert.True(t, streamed)
+	assert.Empty(t, items)
+	assert.Equal(t, "\"a\"\n\"b\"\n\"c\"\n", out.String())
+}
+
+func TestFetchPages_StopsOnError(t *testing.T) {
+	cmd, out := newPagingCmd("ndjson")
+
+	_, _, err := FetchPages(cmd, 0, true, func(ctx context.Context, startAt int) (PageResult[string], error) {
+		if startAt > 0 {
+			return PageResult[string]{}, errors.New("boom")
+		}
+		return PageResult[string]{Items: []string{"a"}, Next: 1, More: true}, nil
+	})
+	assert.EqualError(t, err, "boom")
+	// Records already streamed stay written
+	assert.Equal(t, "\"a\"\n", out.String())
+}
+
+func TestFetchPages_RejectsSortWithNDJSON(t *testing.T) {
+	cmd, _ := newPagingCmd("ndjson")
+	assert.NoError(t, cmd.Flags().Set("sort-by", "key"))
+
+	_, _, err := FetchPages(cmd, 0, false, func(ctx context.Context, startAt int) (PageResult[string], error) {
+		t.Fatal("fetch should not be called")
+		return PageResult[string]{}, nil
+	})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestCursorPage(t *testing.T) {
+	page := CursorPage([]string{"a"}, "25")
+	assert.True(t, page.More)
+	assert.Equal(t, 25, page.Next)
+
+	assert.False(t, CursorPage([]string{"a"}, "").More)
+}
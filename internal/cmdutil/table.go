@@ -0,0 +1,63 @@
+package cmdutil
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/output"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// AddTableFlags adds --columns, --sort-by and --wide to a list command
+func AddTableFlags(cmd *cobra.Command, columnNames []string) {
+	cmd.Flags().StringSlice("columns", nil,
+		"Comma-separated columns to show (available: "+strings.Join(columnNames, ", ")+")")
+	cmd.Flags().String("sort-by", "", "Column to sort by; prefix with - for descending (e.g. -updated)")
+	cmd.Flags().Bool("wide", false, "Show additional columns without truncation")
+}
+
+// GetTableOptions reads the table flags added by AddTableFlags and the terminal width
+func GetTableOptions(cmd *cobra.Command) output.TableOptions {
+	opts := output.TableOptions{Width: TerminalWidth(cmd)}
+	if flag := cmd.Flags().Lookup("columns"); flag != nil {
+		opts.Columns, _ = cmd.Flags().GetStringSlice("columns")
+	}
+	if flag := cmd.Flags().Lookup("sort-by"); flag != nil {
+		opts.SortBy = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup("wide"); flag != nil {
+		opts.Wide, _ = cmd.Flags().GetBool("wide")
+	}
+	return opts
+}
+
+// TerminalWidth returns the width of the terminal the command writes to, falling
+// back to $COLUMNS, or 0 when output is not a terminal
+func TerminalWidth(cmd *cobra.Command) int {
+	if file, ok := terminalFile(cmd.OutOrStdout()); ok {
+		if width, _, err := term.GetSize(int(file.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return 0
+}
+
+// BuildTable sorts items and renders them with the columns selected by the table
+// flags. Sorting happens in place so every output format sees the same order.
+func BuildTable[T any](cmd *cobra.Command, columns output.ColumnSet[T], items []T) (*output.Table, error) {
+	opts := GetTableOptions(cmd)
+	if err := columns.Sort(items, opts.SortBy); err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "invalid --sort-by")
+	}
+	table, err := columns.Build(items, opts)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "invalid --columns")
+	}
+	return table, nil
+}
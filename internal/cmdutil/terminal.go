@@ -0,0 +1,37 @@
+package cmdutil
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// terminalFile returns w as a file when it is an interactive terminal
+func terminalFile(w io.Writer) (*os.File, bool) {
+	file, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return nil, false
+	}
+	return file, true
+}
+
+// IsTerminal reports whether the command writes to an interactive terminal
+func IsTerminal(cmd *cobra.Command) bool {
+	_, ok := terminalFile(cmd.OutOrStdout())
+	return ok
+}
+
+// ColorEnabled reports whether output should be colored. Colors are used on
+// terminals unless --no-color, NO_COLOR or TERM=dumb is set; CLICOLOR_FORCE
+// enables them when output is redirected.
+func ColorEnabled(cmd *cobra.Command) bool {
+	if GetViperFromCmd(cmd).GetBool("no_color") || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return IsTerminal(cmd) && os.Getenv("TERM") != "dumb"
+}
@@ -13,6 +13,7 @@ type contextKey string
 const (
 	ViperKey   contextKey = "viper"
 	FactoryKey contextKey = "factory"
+	AuditKey   contextKey = "audit"
 )
 
 // GetViperFromCmd retrieves the viper instance from the command context
@@ -68,6 +68,24 @@ output: "table"
 			},
 			expectError: false,
 		},
+		{
+			name: "template output",
+			configContent: `
+api_endpoint: "https://test.atlassian.net"
+output: "template"
+`,
+			expectedConfig: &types.Config{
+				APIEndpoint: "https://test.atlassian.net",
+				Output:      "template",
+			},
+		},
+		{
+			name: "unknown output",
+			configContent: `
+output: "xml"
+`,
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
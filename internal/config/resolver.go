@@ -1,16 +1,17 @@
 package config
 
 import (
-	"errors"
 	"os"
 
+	"atlassian-cli/internal/clierr"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	ErrNoProjectConfigured = errors.New("no JIRA project configured")
-	ErrNoSpaceConfigured   = errors.New("no Confluence space configured")
+	ErrNoProjectConfigured = clierr.New(clierr.KindValidation, "no JIRA project configured")
+	ErrNoSpaceConfigured   = clierr.New(clierr.KindValidation, "no Confluence space configured")
 )
 
 // ResolveProject resolves JIRA project using command flag > env var > config > error
@@ -0,0 +1,214 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// attachmentsPageSize is the number of attachments requested per call
+const attachmentsPageSize = 100
+
+// sniffLength is how much of a file media type detection looks at
+const sniffLength = 512
+
+// errorBodyLimit caps how much of a failed download's response is read
+const errorBodyLimit = 64 << 10
+
+// quoteEscaper escapes a file name for a Content-Disposition header
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// ListAttachments returns the current attachments of a page
+func (c *AtlassianConfluenceClient) ListAttachments(ctx context.Context, pageID string) ([]types.Attachment, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	options := &models.GetContentAttachmentsOptionsScheme{Expand: []string{"version"}}
+	var attachments []types.Attachment
+	for startAt := 0; ; startAt += attachmentsPageSize {
+		result, response, err := c.client.Content.Attachment.Gets(ctx, pageID, startAt, attachmentsPageSize, options)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list attachments of page %s", pageID)
+		}
+		for _, content := range result.Results {
+			attachments = append(attachments, *convertAttachment(content, pageID))
+		}
+		if result.Size < attachmentsPageSize {
+			return attachments, nil
+		}
+	}
+}
+
+// GetAttachment returns the current attachment of a page with the given file name
+func (c *AtlassianConfluenceClient) GetAttachment(ctx context.Context, pageID, fileName string) (*types.Attachment, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if fileName == "" {
+		return nil, clierr.New(clierr.KindValidation, "file name is required")
+	}
+
+	options := &models.GetContentAttachmentsOptionsScheme{Expand: []string{"version"}, FileName: fileName}
+	result, response, err := c.client.Content.Attachment.Gets(ctx, pageID, 0, 1, options)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get attachment %s of page %s", fileName, pageID)
+	}
+	if len(result.Results) == 0 {
+		return nil, clierr.New(clierr.KindNotFound, "page %s has no attachment named %s", pageID, fileName)
+	}
+	return convertAttachment(result.Results[0], pageID), nil
+}
+
+// DownloadAttachment streams the content of an attachment to w
+func (c *AtlassianConfluenceClient) DownloadAttachment(ctx context.Context, attachment *types.Attachment, w io.Writer) error {
+	if attachment == nil || attachment.DownloadURL == "" {
+		return clierr.New(clierr.KindValidation, "attachment download link is required")
+	}
+
+	request, err := c.client.NewRequest(ctx, http.MethodGet, attachment.DownloadURL, "", nil)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to download %s", attachment.Title)
+	}
+	request.Header.Set("Accept", "*/*")
+
+	// Call would read the whole file into memory, so the response is handled here
+	response, err := c.client.HTTP.Do(request)
+	if err != nil {
+		return clierr.FromResponse(nil, err, "failed to download %s", attachment.Title)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, errorBodyLimit))
+		return clierr.FromStatus(response.StatusCode, body, "failed to download %s", attachment.Title)
+	}
+	if _, err := io.Copy(w, response.Body); err != nil {
+		return clierr.Wrap(clierr.KindOf(err), err, "failed to download %s", attachment.Title)
+	}
+	return nil
+}
+
+// UploadAttachment attaches a file to a page, adding a new version of the
+// attachment when the page already has a file with that name. The file is
+// streamed rather than read into memory, with its media type detected from the
+// file name or, failing that, its content.
+func (c *AtlassianConfluenceClient) UploadAttachment(ctx context.Context, pageID, fileName string, file io.Reader) (*types.Attachment, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if fileName == "" {
+		return nil, clierr.New(clierr.KindValidation, "file name is required")
+	}
+
+	query := url.Values{}
+	query.Set("status", "current")
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/child/attachment?%s", url.PathEscape(pageID), query.Encode())
+
+	body, contentType := multipartFile(fileName, file)
+	defer body.Close()
+	request, err := c.client.NewRequest(ctx, http.MethodPut, endpoint, contentType, nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	request.Body = body
+	request.GetBody = nil
+	request.ContentLength = -1
+
+	result := new(models.ContentPageScheme)
+	response, err := c.client.Call(request, result)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to upload %s to page %s", fileName, pageID)
+	}
+	if len(result.Results) == 0 {
+		return nil, clierr.New(clierr.KindGeneral, "upload of %s to page %s returned no attachment", fileName, pageID)
+	}
+	return convertAttachment(result.Results[0], pageID), nil
+}
+
+// DeleteAttachment moves an attachment to the space trash
+func (c *AtlassianConfluenceClient) DeleteAttachment(ctx context.Context, id string) error {
+	if id == "" {
+		return clierr.New(clierr.KindValidation, "attachment ID is required")
+	}
+
+	response, err := c.client.Content.Delete(ctx, id, "")
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to delete attachment %s", id)
+	}
+	return nil
+}
+
+// multipartFile returns a multipart form body holding file, written as the body
+// is read, and the body's content type
+func multipartFile(fileName string, file io.Reader) (io.ReadCloser, string) {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeMultipartFile(form, fileName, file))
+	}()
+	return reader, form.FormDataContentType()
+}
+
+// writeMultipartFile writes the form fields of an attachment upload
+func writeMultipartFile(form *multipart.Writer, fileName string, file io.Reader) error {
+	content := bufio.NewReaderSize(file, sniffLength)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(fileName)))
+	header.Set("Content-Type", detectMediaType(fileName, content))
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	if err := form.WriteField("minorEdit", "true"); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// detectMediaType returns the media type of a file from its extension, or else
+// by sniffing the start of its content, which stays available to read
+func detectMediaType(fileName string, content *bufio.Reader) string {
+	if mediaType := mime.TypeByExtension(filepath.Ext(fileName)); mediaType != "" {
+		return mediaType
+	}
+	head, _ := content.Peek(sniffLength)
+	return http.DetectContentType(head)
+}
+
+// convertAttachment converts an attachment's content scheme to our type
+func convertAttachment(scheme *models.ContentScheme, pageID string) *types.Attachment {
+	attachment := &types.Attachment{
+		ID:     scheme.ID,
+		Title:  scheme.Title,
+		PageID: pageID,
+	}
+	if scheme.Extensions != nil {
+		attachment.MediaType = scheme.Extensions.MediaType
+		attachment.FileSize = scheme.Extensions.FileSize
+	}
+	if scheme.Version != nil {
+		attachment.Version = scheme.Version.Number
+	}
+	// Download links are relative to the wiki, which the client's site URL is not
+	if scheme.Links != nil && scheme.Links.Download != "" {
+		attachment.DownloadURL = "wiki/" + strings.TrimPrefix(scheme.Links.Download, "/")
+	}
+	return attachment
+}
@@ -0,0 +1,79 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *AtlassianConfluenceClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewAtlassianConfluenceClient(server.URL, "user@example.com", "token")
+	require.NoError(t, err)
+	return client
+}
+
+func TestDetectMediaType(t *testing.T) {
+	assert.Equal(t, "image/png", detectMediaType("diagram.png", bufio.NewReader(strings.NewReader(""))))
+
+	content := bufio.NewReader(strings.NewReader("%PDF-1.7 report"))
+	assert.Equal(t, "application/pdf", detectMediaType("report", content))
+	rest, _ := io.ReadAll(content)
+	assert.Equal(t, "%PDF-1.7 report", string(rest), "sniffing leaves the content to read")
+}
+
+func TestUploadAttachment_StreamsMultipartFile(t *testing.T) {
+	var fileName, mediaType, content string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/wiki/rest/api/content/123/child/attachment", r.URL.Path)
+		assert.Equal(t, "no-check", r.Header.Get("X-Atlassian-Token"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		fileName, mediaType, content = header.Filename, header.Header.Get("Content-Type"), string(data)
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"results":[{"id":"att9","title":"notes.txt","version":{"number":2},
+			"extensions":{"mediaType":"text/plain","fileSize":5},"_links":{"download":"/download/attachments/123/notes.txt"}}],"size":1}`)
+	})
+
+	attachment, err := client.UploadAttachment(context.Background(), "123", "notes.txt", strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "notes.txt", fileName)
+	assert.Equal(t, "text/plain; charset=utf-8", mediaType)
+	assert.Equal(t, "hello", content)
+	assert.Equal(t, &types.Attachment{ID: "att9", Title: "notes.txt", MediaType: "text/plain", FileSize: 5, Version: 2,
+		PageID: "123", DownloadURL: "wiki/download/attachments/123/notes.txt"}, attachment)
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wiki/download/attachments/123/missing.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, "PNGDATA")
+	})
+
+	var out bytes.Buffer
+	err := client.DownloadAttachment(context.Background(), &types.Attachment{Title: "a.png", DownloadURL: "wiki/download/attachments/123/a.png"}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "PNGDATA", out.String())
+
+	err = client.DownloadAttachment(context.Background(), &types.Attachment{Title: "missing.png", DownloadURL: "wiki/download/attachments/123/missing.png"}, &out)
+	assert.Equal(t, clierr.KindNotFound, clierr.KindOf(err))
+}
@@ -0,0 +1,70 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlogPostCQL(t *testing.T) {
+	assert.Equal(t, "type = blogpost ORDER BY created DESC", blogPostCQL(&types.BlogPostListOptions{}))
+
+	opts := &types.BlogPostListOptions{
+		SpaceKey: "ENG",
+		Title:    `Release "4.2"`,
+		Since:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+		Until:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local),
+	}
+	assert.Equal(t, `type = blogpost AND space = "ENG" AND title = "Release \"4.2\"" AND created >= "2026-09-01" AND created < "2026-10-01" ORDER BY created DESC`,
+		blogPostCQL(opts))
+}
+
+func TestCreateBlogPost_SendsPublishDate(t *testing.T) {
+	var payload map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/space/ENG":
+			w.Write([]byte(`{"id": 42, "key": "ENG"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/wiki/api/v2/blogposts":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.Write([]byte(`{"id": "777"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/content/777":
+			w.Write([]byte(`{"id": "777", "type": "blogpost", "title": "Release 4.1", "space": {"key": "ENG"},
+				"version": {"number": 1}, "history": {"createdDate": "2026-10-05T00:00:00.000Z"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	date := time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)
+	post, err := client.CreateBlogPost(context.Background(), &types.CreateBlogPostRequest{
+		SpaceKey: "ENG", Title: "Release 4.1", Content: "<p>Notes</p>", PublishDate: &date,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", payload["spaceId"])
+	assert.Equal(t, "2026-10-05", payload["createdAt"])
+	assert.Equal(t, map[string]interface{}{"representation": "storage", "value": "<p>Notes</p>"}, payload["body"])
+	assert.Equal(t, "777", post.ID)
+	require.NotNil(t, post.Published)
+	assert.Equal(t, "2026-10-05", post.Published.Format("2006-01-02"))
+}
+
+func TestConvertContentSchemeToPage_PublishedOnlyForBlogPosts(t *testing.T) {
+	history := &models.ContentHistoryScheme{CreatedDate: "2026-10-05T09:30:00.000Z"}
+
+	post := convertContentSchemeToPage(&models.ContentScheme{ID: "1", Type: ContentBlogPost, History: history})
+	require.NotNil(t, post.Published)
+	assert.Equal(t, time.Date(2026, 10, 5, 9, 30, 0, 0, time.UTC), post.Published.UTC())
+
+	page := convertContentSchemeToPage(&models.ContentScheme{ID: "2", Type: "page", History: history})
+	assert.Nil(t, page.Published)
+}
@@ -1,10 +1,16 @@
 package confluence
 
 import (
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/types"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	confluence "github.com/ctreminiom/go-atlassian/confluence"
@@ -14,11 +20,58 @@ import (
 // ConfluenceClient defines the interface for Confluence operations
 type ConfluenceClient interface {
 	CreatePage(ctx context.Context, req *types.CreatePageRequest) (*types.Page, error)
+	CreateBlogPost(ctx context.Context, req *types.CreateBlogPostRequest) (*types.Page, error)
 	GetPage(ctx context.Context, id string) (*types.Page, error)
 	UpdatePage(ctx context.Context, id string, req *types.UpdatePageRequest) (*types.Page, error)
 	ListPages(ctx context.Context, opts *types.PageListOptions) (*types.PageListResponse, error)
+	ListBlogPosts(ctx context.Context, opts *types.BlogPostListOptions) (*types.PageListResponse, error)
 	SearchPages(ctx context.Context, opts *types.PageSearchOptions) (*types.PageSearchResponse, error)
 	ListSpaces(ctx context.Context, opts *types.SpaceListOptions) (*types.SpaceListResponse, error)
+	GetSpace(ctx context.Context, key string) (*types.Space, error)
+	CreateSpace(ctx context.Context, req *types.CreateSpaceRequest) (*types.Space, error)
+	UpdateSpace(ctx context.Context, key string, req *types.UpdateSpaceRequest) (*types.Space, error)
+	ArchiveSpace(ctx context.Context, key string, archived bool) (*types.Space, error)
+	DeleteSpace(ctx context.Context, key string) error
+	ListSpacePermissions(ctx context.Context, key string) ([]types.Grant, error)
+	GrantSpacePermission(ctx context.Context, key string, grant types.Grant) error
+	RevokeSpacePermission(ctx context.Context, key, id string) error
+	GetRestrictions(ctx context.Context, pageID string) ([]types.Grant, error)
+	SetRestrictions(ctx context.Context, pageID string, grants []types.Grant) error
+	ListTemplates(ctx context.Context, spaceKey string) ([]types.Template, error)
+	GetTemplate(ctx context.Context, id string) (*types.Template, error)
+	ListProperties(ctx context.Context, pageID string) ([]types.ContentProperty, error)
+	GetProperty(ctx context.Context, pageID, key string) (*types.ContentProperty, error)
+	SetProperty(ctx context.Context, pageID, key string, value json.RawMessage) (*types.ContentProperty, error)
+	DeleteProperty(ctx context.Context, pageID, key string) error
+	DeletePage(ctx context.Context, id string) error
+	ListDescendants(ctx context.Context, id string) ([]types.Page, error)
+	ListTrash(ctx context.Context, opts *types.PageListOptions) (*types.PageListResponse, error)
+	GetTrashedPage(ctx context.Context, id string) (*types.Page, error)
+	RestorePage(ctx context.Context, id string) (*types.Page, error)
+	PurgePage(ctx context.Context, id string) error
+	ListChildren(ctx context.Context, id string) ([]types.Page, error)
+	ListAncestors(ctx context.Context, id string) ([]types.Page, error)
+	ListRootPages(ctx context.Context, spaceKey string) ([]types.Page, error)
+	MovePage(ctx context.Context, id, position, targetID string) error
+	CopyPage(ctx context.Context, id string, req *types.CopyPageRequest) (*types.Page, error)
+	GetSpaceHomepage(ctx context.Context, spaceKey string) (*types.Page, error)
+	ListVersions(ctx context.Context, id string) ([]types.PageVersion, error)
+	GetPageVersion(ctx context.Context, id string, version int) (*types.Page, error)
+	RestoreVersion(ctx context.Context, id string, version int, message string) (*types.Page, error)
+	UploadAttachment(ctx context.Context, pageID, fileName string, file io.Reader) (*types.Attachment, error)
+	ListAttachments(ctx context.Context, pageID string) ([]types.Attachment, error)
+	GetAttachment(ctx context.Context, pageID, fileName string) (*types.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+	ListLabels(ctx context.Context, pageID string) ([]types.Label, error)
+	AddLabels(ctx context.Context, pageID string, names []string) ([]types.Label, error)
+	RemoveLabel(ctx context.Context, pageID, name string) error
+	ListComments(ctx context.Context, pageID, location string) ([]types.Comment, error)
+	GetComment(ctx context.Context, id string) (*types.Comment, error)
+	AddComment(ctx context.Context, req *types.CreateCommentRequest) (*types.Comment, error)
+	ReplyComment(ctx context.Context, parentID, content string) (*types.Comment, error)
+	ResolveComment(ctx context.Context, id string, resolved bool) (*types.Comment, error)
+	DownloadAttachment(ctx context.Context, attachment *types.Attachment, w io.Writer) error
+	GetPageHTML(ctx context.Context, id string) (*types.Page, error)
 }
 
 // AtlassianConfluenceClient implements ConfluenceClient using the go-atlassian v1 library
@@ -28,18 +81,30 @@ type AtlassianConfluenceClient struct {
 
 // NewAtlassianConfluenceClient creates a new Confluence client using v1 API
 func NewAtlassianConfluenceClient(baseURL, email, token string) (*AtlassianConfluenceClient, error) {
+	return NewAtlassianConfluenceClientWithHTTP(nil, baseURL, email, token)
+}
+
+// NewAtlassianConfluenceClientWithHTTP creates a Confluence client that sends its
+// requests through httpClient, or the default HTTP client when it is nil
+func NewAtlassianConfluenceClientWithHTTP(httpClient *http.Client, baseURL, email, token string) (*AtlassianConfluenceClient, error) {
 	if baseURL == "" {
-		return nil, fmt.Errorf("base URL is required")
+		return nil, clierr.New(clierr.KindValidation, "base URL is required")
 	}
 	if email == "" {
-		return nil, fmt.Errorf("email is required")
+		return nil, clierr.New(clierr.KindValidation, "email is required")
 	}
 	if token == "" {
-		return nil, fmt.Errorf("token is required")
+		return nil, clierr.New(clierr.KindValidation, "token is required")
 	}
 
 	// Create the client instance using v1 API
-	instance, err := confluence.New(nil, baseURL)
+	var instance *confluence.Client
+	var err error
+	if httpClient != nil {
+		instance, err = confluence.New(httpClient, baseURL)
+	} else {
+		instance, err = confluence.New(nil, baseURL)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Confluence client: %w", err)
 	}
@@ -55,7 +120,7 @@ func NewAtlassianConfluenceClient(baseURL, email, token string) (*AtlassianConfl
 // CreatePage creates a new Confluence page
 func (c *AtlassianConfluenceClient) CreatePage(ctx context.Context, req *types.CreatePageRequest) (*types.Page, error) {
 	if req == nil {
-		return nil, fmt.Errorf("create page request cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "create page request cannot be nil")
 	}
 
 	// Build the page creation payload
@@ -85,10 +150,7 @@ func (c *AtlassianConfluenceClient) CreatePage(ctx context.Context, req *types.C
 	// Create the page using the v1 API
 	result, response, err := c.client.Content.Create(ctx, payload)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to create page (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to create page: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to create page")
 	}
 
 	// Convert the response to our internal type
@@ -98,45 +160,67 @@ func (c *AtlassianConfluenceClient) CreatePage(ctx context.Context, req *types.C
 // GetPage retrieves a Confluence page by ID
 func (c *AtlassianConfluenceClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
 	if id == "" {
-		return nil, fmt.Errorf("page ID is required")
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
 	}
 
-	// Get the page with body content and version
-	result, response, err := c.client.Content.Get(ctx, id, []string{"body.storage", "version", "space"}, 0)
+	// Get the page with body content, version and labels, and the history that
+	// dates blog posts
+	result, response, err := c.client.Content.Get(ctx, id, []string{"body.storage", "version", "space", "ancestors", "metadata.labels", "history"}, 0)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to get page (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to get page: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to get page")
 	}
 
 	return convertContentSchemeToPage(result), nil
 }
 
+// GetPageHTML retrieves a page with its content rendered as HTML, as Confluence
+// exports it, in place of the storage format
+func (c *AtlassianConfluenceClient) GetPageHTML(ctx context.Context, id string) (*types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	result, response, err := c.client.Content.Get(ctx, id, []string{"body.export_view", "version", "space", "ancestors"}, 0)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get page")
+	}
+
+	page := convertContentSchemeToPage(result)
+	if result.Body != nil && result.Body.ExportView != nil {
+		page.Content = result.Body.ExportView.Value
+	}
+	return page, nil
+}
+
 // UpdatePage updates an existing Confluence page
 func (c *AtlassianConfluenceClient) UpdatePage(ctx context.Context, id string, req *types.UpdatePageRequest) (*types.Page, error) {
 	if id == "" {
-		return nil, fmt.Errorf("page ID is required")
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
 	}
 	if req == nil {
-		return nil, fmt.Errorf("update page request cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "update page request cannot be nil")
 	}
 
 	// Get current page to retrieve version and existing data
 	currentPage, response, err := c.client.Content.Get(ctx, id, []string{"body.storage", "version", "space"}, 0)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to get current page (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to get current page: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to get current page")
+	}
+
+	// Refuse to overwrite changes made after the version the caller edited
+	if req.ExpectedVersion > 0 && currentPage.Version.Number != req.ExpectedVersion {
+		return nil, clierr.New(clierr.KindConflict, "page %s has changed: expected version %d but the current version is %d",
+			id, req.ExpectedVersion, currentPage.Version.Number)
 	}
 
-	// Build update payload with incremented version
+	// Build update payload with incremented version, keeping the content type so
+	// blog posts can be updated too
 	payload := &models.ContentScheme{
-		Type:  "page",
+		Type:  currentPage.Type,
 		Title: currentPage.Title,
 		Version: &models.ContentVersionScheme{
-			Number: currentPage.Version.Number + 1,
+			Number:  currentPage.Version.Number + 1,
+			Message: req.Message,
 		},
 	}
 
@@ -161,10 +245,7 @@ func (c *AtlassianConfluenceClient) UpdatePage(ctx context.Context, id string, r
 	// Update the page
 	result, response, err := c.client.Content.Update(ctx, id, payload)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to update page (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to update page: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to update page")
 	}
 
 	return convertContentSchemeToPage(result), nil
@@ -187,11 +268,18 @@ func (c *AtlassianConfluenceClient) ListPages(ctx context.Context, opts *types.P
 		startAt = 0
 	}
 
-	// Note: Confluence v1 API doesn't support cursor pagination natively
-	// Cursor is ignored for now; we use offset-based pagination
+	// The v1 API paginates by offset; cursors we hand out are encoded offsets
 	if opts.Cursor != "" {
-		// Could decode cursor and extract offset, but for simplicity we ignore it
-		// and rely on startAt parameter
+		offset, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		startAt = offset
+	}
+
+	// Content.Gets cannot filter by label, so labelled pages are found with CQL
+	if len(opts.Labels) > 0 {
+		return c.listPagesByLabel(ctx, opts, startAt, maxResults)
 	}
 
 	// Build query options
@@ -210,10 +298,7 @@ func (c *AtlassianConfluenceClient) ListPages(ctx context.Context, opts *types.P
 	// Get pages
 	result, response, err := c.client.Content.Gets(ctx, options, startAt, maxResults)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to list pages (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to list pages: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to list pages")
 	}
 
 	// Convert results - filter by type=page since API doesn't support type filter directly
@@ -251,14 +336,57 @@ func (c *AtlassianConfluenceClient) ListPages(ctx context.Context, opts *types.P
 	}, nil
 }
 
+// listPagesByLabel lists the pages of ListPages with a CQL search, which can
+// filter by label
+func (c *AtlassianConfluenceClient) listPagesByLabel(ctx context.Context, opts *types.PageListOptions, startAt, maxResults int) (*types.PageListResponse, error) {
+	conditions := []string{"type = page"}
+	if opts.SpaceKey != "" {
+		conditions = append(conditions, "space = "+CQLString(opts.SpaceKey))
+	}
+	if opts.Title != "" {
+		conditions = append(conditions, "title = "+CQLString(opts.Title))
+	}
+	conditions = append(conditions, LabelCQL(opts.Labels))
+	cql := strings.Join(conditions, " AND ")
+
+	searchOptions := &models.SearchContentOptions{
+		Limit:  maxResults,
+		Start:  startAt,
+		Expand: []string{"content.space", "content.version", "content.metadata.labels"},
+	}
+	result, response, err := c.client.Search.Content(ctx, cql, searchOptions)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to list pages")
+	}
+
+	pages := make([]types.Page, 0, len(result.Results))
+	for _, searchResult := range result.Results {
+		if searchResult.Content != nil {
+			pages = append(pages, *convertContentSchemeToPage(searchResult.Content))
+		}
+	}
+
+	var nextCursor string
+	if startAt+len(result.Results) < result.TotalSize {
+		nextCursor = strconv.Itoa(startAt + maxResults)
+	}
+	return &types.PageListResponse{
+		Pages:      pages,
+		Total:      result.TotalSize,
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		NextCursor: nextCursor,
+	}, nil
+}
+
 // SearchPages searches pages using CQL
 func (c *AtlassianConfluenceClient) SearchPages(ctx context.Context, opts *types.PageSearchOptions) (*types.PageSearchResponse, error) {
 	if opts == nil {
-		return nil, fmt.Errorf("search options cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "search options cannot be nil")
 	}
 
 	if opts.CQL == "" {
-		return nil, fmt.Errorf("CQL query is required")
+		return nil, clierr.New(clierr.KindValidation, "CQL query is required")
 	}
 
 	// Set defaults
@@ -281,10 +409,7 @@ func (c *AtlassianConfluenceClient) SearchPages(ctx context.Context, opts *types
 	// Execute CQL search
 	result, response, err := c.client.Search.Content(ctx, opts.CQL, searchOptions)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to search pages (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to search pages: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to search pages")
 	}
 
 	// Convert results
@@ -320,40 +445,40 @@ func (c *AtlassianConfluenceClient) ListSpaces(ctx context.Context, opts *types.
 		startAt = 0
 	}
 
-	// Note: Cursor is ignored for v1 API (uses offset-based pagination)
+	// The v1 API paginates by offset; cursors we hand out are encoded offsets
 	if opts.Cursor != "" {
-		// Could decode cursor and extract offset, but for simplicity we ignore it
+		offset, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		startAt = offset
 	}
 
-	// Build query options
-	options := &models.GetSpacesOptionScheme{}
-
-	// Add type filter if provided
+	// Request spaces directly: go-atlassian's space model has no description
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(startAt))
+	query.Set("limit", strconv.Itoa(maxResults))
+	query.Set("expand", strings.Join(spaceListExpand, ","))
 	if opts.Type != "" {
-		options.SpaceType = opts.Type
+		query.Set("type", opts.Type)
 	}
-
-	// Get spaces
-	result, response, err := c.client.Space.Gets(ctx, options, startAt, maxResults)
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	request, err := c.client.NewRequest(ctx, http.MethodGet, "wiki/rest/api/space?"+query.Encode(), "", nil)
 	if err != nil {
-		if response != nil {
-			return nil, fmt.Errorf("failed to list spaces (status %d): %w", response.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to list spaces: %w", err)
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	result := new(spacePage)
+	response, err := c.client.Call(request, result)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to list spaces")
 	}
 
 	// Convert results
 	spaces := make([]types.Space, 0, len(result.Results))
-	for _, spaceScheme := range result.Results {
-		space := types.Space{
-			ID:          strconv.Itoa(spaceScheme.ID),
-			Key:         spaceScheme.Key,
-			Name:        spaceScheme.Name,
-			Type:        spaceScheme.Type,
-			Description: "", // v1 API SpaceScheme doesn't include description by default
-		}
-
-		spaces = append(spaces, space)
+	for i := range result.Results {
+		spaces = append(spaces, convertSpace(&result.Results[i]))
 	}
 
 	// Generate next cursor if there are more results
@@ -389,9 +514,10 @@ func convertContentSchemeToPage(scheme *models.ContentScheme) *types.Page {
 	}
 
 	page := &types.Page{
-		ID:    scheme.ID,
-		Title: scheme.Title,
-		Type:  scheme.Type,
+		ID:     scheme.ID,
+		Title:  scheme.Title,
+		Type:   scheme.Type,
+		Status: scheme.Status,
 	}
 
 	// Extract space key if available
@@ -399,11 +525,30 @@ func convertContentSchemeToPage(scheme *models.ContentScheme) *types.Page {
 		page.SpaceKey = scheme.Space.Key
 	}
 
+	// The closest ancestor is the parent
+	if len(scheme.Ancestors) > 0 {
+		page.ParentID = scheme.Ancestors[len(scheme.Ancestors)-1].ID
+	}
+
 	// Extract content if available
 	if scheme.Body != nil && scheme.Body.Storage != nil {
 		page.Content = scheme.Body.Storage.Value
 	}
 
+	// Extract labels if they were expanded
+	if scheme.Metadata != nil && scheme.Metadata.Labels != nil {
+		for _, label := range scheme.Metadata.Labels.Results {
+			page.Labels = append(page.Labels, label.Name)
+		}
+	}
+
+	// Blog posts are published under their creation date
+	if scheme.Type == ContentBlogPost && scheme.History != nil && scheme.History.CreatedDate != "" {
+		if t, err := time.Parse(time.RFC3339, scheme.History.CreatedDate); err == nil {
+			page.Published = &t
+		}
+	}
+
 	// Extract version if available
 	if scheme.Version != nil {
 		page.Version = scheme.Version.Number
@@ -418,3 +563,12 @@ func convertContentSchemeToPage(scheme *models.ContentScheme) *types.Page {
 
 	return page
 }
+
+// decodeCursor converts a NextCursor value returned by a list call back into an offset
+func decodeCursor(cursor string) (int, error) {
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 {
+		return 0, clierr.New(clierr.KindValidation, "invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
@@ -0,0 +1,374 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commentsPageSize is the number of comments requested per call
+const commentsPageSize = 100
+
+// Comment locations
+const (
+	CommentFooter = "footer"
+	CommentInline = "inline"
+)
+
+// commentExpand is what comment requests to the v1 API expand: go-atlassian's
+// content model has no fields for inline properties or resolution, so comments
+// are read into commentContent instead
+var commentExpand = []string{"body.storage", "version", "history", "container", "ancestors",
+	"extensions.inlineProperties", "extensions.resolution"}
+
+// storageTag matches the tags of storage-format XML
+var storageTag = regexp.MustCompile(`<[^>]*>`)
+
+// commentContent is a comment as the v1 content API returns it
+type commentContent struct {
+	ID        string `json:"id"`
+	Container *struct {
+		ID string `json:"id"`
+	} `json:"container"`
+	Ancestors []struct {
+		ID string `json:"id"`
+	} `json:"ancestors"`
+	History *struct {
+		CreatedBy *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"createdBy"`
+		CreatedDate string `json:"createdDate"`
+	} `json:"history"`
+	Version *struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Body *struct {
+		Storage *struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Extensions *struct {
+		Location         string `json:"location"`
+		InlineProperties *struct {
+			OriginalSelection string `json:"originalSelection"`
+		} `json:"inlineProperties"`
+		Resolution *struct {
+			Status string `json:"status"`
+		} `json:"resolution"`
+	} `json:"extensions"`
+}
+
+// commentV2 is a comment as the v2 comments API returns it
+type commentV2 struct {
+	ID               string `json:"id"`
+	PageID           string `json:"pageId"`
+	ParentCommentID  string `json:"parentCommentId"`
+	ResolutionStatus string `json:"resolutionStatus"`
+	Version          struct {
+		Number    int    `json:"number"`
+		AuthorID  string `json:"authorId"`
+		CreatedAt string `json:"createdAt"`
+	} `json:"version"`
+	Body struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Properties struct {
+		OriginalSelection string `json:"inlineOriginalSelection"`
+	} `json:"properties"`
+}
+
+// commentBody is the body of a comment written with the v2 API
+type commentBody struct {
+	Representation string `json:"representation"`
+	Value          string `json:"value"`
+}
+
+// ListComments returns the comments on a page, each followed by its replies.
+// location limits them to footer or inline comments ("" for both).
+func (c *AtlassianConfluenceClient) ListComments(ctx context.Context, pageID, location string) ([]types.Comment, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if location != "" && location != CommentFooter && location != CommentInline {
+		return nil, clierr.New(clierr.KindValidation, "invalid comment location %q: must be footer or inline", location)
+	}
+
+	var comments []types.Comment
+	for startAt := 0; ; startAt += commentsPageSize {
+		query := url.Values{}
+		query.Set("expand", strings.Join(commentExpand, ","))
+		query.Set("depth", "all")
+		query.Set("start", strconv.Itoa(startAt))
+		query.Set("limit", strconv.Itoa(commentsPageSize))
+		if location != "" {
+			query.Set("location", location)
+		}
+		endpoint := fmt.Sprintf("wiki/rest/api/content/%s/child/comment?%s", url.PathEscape(pageID), query.Encode())
+
+		request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+		}
+		var result struct {
+			Results []*commentContent `json:"results"`
+			Size    int               `json:"size"`
+		}
+		response, err := c.client.Call(request, &result)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list comments of page %s", pageID)
+		}
+		for _, content := range result.Results {
+			comments = append(comments, convertComment(content, pageID))
+		}
+		if result.Size < commentsPageSize {
+			return threadComments(comments), nil
+		}
+	}
+}
+
+// GetComment retrieves a comment by ID
+func (c *AtlassianConfluenceClient) GetComment(ctx context.Context, id string) (*types.Comment, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "comment ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("expand", strings.Join(commentExpand, ","))
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s?%s", url.PathEscape(id), query.Encode())
+
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	content := new(commentContent)
+	response, err := c.client.Call(request, content)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get comment %s", id)
+	}
+	comment := convertComment(content, "")
+	return &comment, nil
+}
+
+// AddComment adds a footer comment to a page, or an inline comment when the
+// request has a selection. The selection must occur in the page's text.
+func (c *AtlassianConfluenceClient) AddComment(ctx context.Context, req *types.CreateCommentRequest) (*types.Comment, error) {
+	if req == nil || req.PageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, clierr.New(clierr.KindValidation, "comment body is required")
+	}
+
+	body := commentBody{Representation: "storage", Value: req.Content}
+	if req.Selection == "" {
+		payload := map[string]interface{}{"pageId": req.PageID, "body": body}
+		return c.createComment(ctx, "wiki/api/v2/footer-comments", payload, CommentFooter)
+	}
+
+	page, err := c.GetPage(ctx, req.PageID)
+	if err != nil {
+		return nil, err
+	}
+	matches := strings.Count(StorageText(page.Content), req.Selection)
+	if matches == 0 {
+		return nil, clierr.New(clierr.KindValidation, "%q does not appear in the text of page %s", req.Selection, req.PageID)
+	}
+	if req.SelectionIndex < 0 || req.SelectionIndex >= matches {
+		return nil, clierr.New(clierr.KindValidation, "%q appears %d times in page %s; match index must be 0 to %d",
+			req.Selection, matches, req.PageID, matches-1)
+	}
+
+	payload := map[string]interface{}{
+		"pageId": req.PageID,
+		"body":   body,
+		"inlineCommentProperties": map[string]interface{}{
+			"textSelection":           req.Selection,
+			"textSelectionMatchCount": matches,
+			"textSelectionMatchIndex": req.SelectionIndex,
+		},
+	}
+	return c.createComment(ctx, "wiki/api/v2/inline-comments", payload, CommentInline)
+}
+
+// ReplyComment replies to a footer or inline comment
+func (c *AtlassianConfluenceClient) ReplyComment(ctx context.Context, parentID, content string) (*types.Comment, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, clierr.New(clierr.KindValidation, "comment body is required")
+	}
+
+	parent, err := c.GetComment(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{
+		"parentCommentId": parentID,
+		"body":            commentBody{Representation: "storage", Value: content},
+	}
+	comment, err := c.createComment(ctx, "wiki/api/v2/"+parent.Location+"-comments", payload, parent.Location)
+	if err != nil {
+		return nil, err
+	}
+	comment.PageID = parent.PageID
+	comment.ParentID = parentID
+	return comment, nil
+}
+
+// ResolveComment resolves an inline comment, or reopens it when resolved is false
+func (c *AtlassianConfluenceClient) ResolveComment(ctx context.Context, id string, resolved bool) (*types.Comment, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "comment ID is required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/api/v2/inline-comments/%s", url.PathEscape(id))
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint+"?body-format=storage", "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	current := new(commentV2)
+	response, err := c.client.Call(request, current)
+	if err != nil {
+		if response != nil && response.Code == http.StatusNotFound {
+			return nil, clierr.New(clierr.KindNotFound, "inline comment %s not found; only inline comments can be resolved", id)
+		}
+		return nil, clierr.FromResponse(response, err, "failed to get comment %s", id)
+	}
+
+	// The API requires the body and the next version number with every update
+	payload := map[string]interface{}{
+		"version":  map[string]int{"number": current.Version.Number + 1},
+		"body":     commentBody{Representation: "storage", Value: current.Body.Storage.Value},
+		"resolved": resolved,
+	}
+	request, err = c.client.NewRequest(ctx, http.MethodPut, endpoint, "", payload)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	updated := new(commentV2)
+	response, err = c.client.Call(request, updated)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to update comment %s", id)
+	}
+	comment := convertCommentV2(updated, CommentInline)
+	if comment.Content == "" {
+		comment.Content = current.Body.Storage.Value
+	}
+	return &comment, nil
+}
+
+// createComment posts a comment to a v2 comments endpoint
+func (c *AtlassianConfluenceClient) createComment(ctx context.Context, endpoint string, payload interface{}, location string) (*types.Comment, error) {
+	request, err := c.client.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	created := new(commentV2)
+	response, err := c.client.Call(request, created)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to add comment")
+	}
+	comment := convertCommentV2(created, location)
+	return &comment, nil
+}
+
+// StorageText returns the text of storage-format content, without its markup
+func StorageText(storage string) string {
+	return html.UnescapeString(storageTag.ReplaceAllString(storage, ""))
+}
+
+// threadComments orders comments so that replies follow the comment they reply
+// to, keeping the API's order otherwise
+func threadComments(comments []types.Comment) []types.Comment {
+	ids := make(map[string]bool, len(comments))
+	replies := map[string][]types.Comment{}
+	var roots []types.Comment
+	for _, comment := range comments {
+		ids[comment.ID] = true
+	}
+	for _, comment := range comments {
+		if comment.ParentID != "" && ids[comment.ParentID] {
+			replies[comment.ParentID] = append(replies[comment.ParentID], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	threaded := make([]types.Comment, 0, len(comments))
+	var add func(comment types.Comment)
+	add = func(comment types.Comment) {
+		threaded = append(threaded, comment)
+		for _, reply := range replies[comment.ID] {
+			add(reply)
+		}
+	}
+	for _, root := range roots {
+		add(root)
+	}
+	return threaded
+}
+
+// convertComment converts a v1 comment to our type
+func convertComment(content *commentContent, pageID string) types.Comment {
+	comment := types.Comment{ID: content.ID, PageID: pageID, Location: CommentFooter}
+	if content.Container != nil && content.Container.ID != "" {
+		comment.PageID = content.Container.ID
+	}
+	// The closest ancestor is the comment replied to
+	if len(content.Ancestors) > 0 {
+		comment.ParentID = content.Ancestors[len(content.Ancestors)-1].ID
+	}
+	if content.History != nil {
+		if content.History.CreatedBy != nil {
+			comment.Author = content.History.CreatedBy.DisplayName
+		}
+		if t, err := time.Parse(time.RFC3339, content.History.CreatedDate); err == nil {
+			comment.Created = t
+		}
+	}
+	if content.Version != nil {
+		comment.Version = content.Version.Number
+	}
+	if content.Body != nil && content.Body.Storage != nil {
+		comment.Content = content.Body.Storage.Value
+	}
+	if ext := content.Extensions; ext != nil {
+		if ext.Location != "" {
+			comment.Location = ext.Location
+		}
+		if ext.InlineProperties != nil {
+			comment.Selection = ext.InlineProperties.OriginalSelection
+		}
+		if ext.Resolution != nil {
+			comment.Resolution = ext.Resolution.Status
+		}
+	}
+	return comment
+}
+
+// convertCommentV2 converts a v2 comment to our type
+func convertCommentV2(created *commentV2, location string) types.Comment {
+	comment := types.Comment{
+		ID:         created.ID,
+		PageID:     created.PageID,
+		ParentID:   created.ParentCommentID,
+		Location:   location,
+		Author:     created.Version.AuthorID,
+		Version:    created.Version.Number,
+		Content:    created.Body.Storage.Value,
+		Selection:  created.Properties.OriginalSelection,
+		Resolution: created.ResolutionStatus,
+	}
+	if t, err := time.Parse(time.RFC3339, created.Version.CreatedAt); err == nil {
+		comment.Created = t
+	}
+	return comment
+}
@@ -0,0 +1,92 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListComments_ThreadsReplies(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/content/1/child/comment", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("depth"))
+		io.WriteString(w, `{"size":3,"results":[
+			{"id":"10","body":{"storage":{"value":"<p>Top</p>"}},"history":{"createdBy":{"displayName":"Ana"},"createdDate":"2026-10-01T10:00:00.000Z"},
+			 "extensions":{"location":"footer"}},
+			{"id":"20","extensions":{"location":"inline","inlineProperties":{"originalSelection":"retry"},"resolution":{"status":"open"}}},
+			{"id":"11","ancestors":[{"id":"10"}],"extensions":{"location":"footer"}}]}`)
+	})
+
+	comments, err := client.ListComments(context.Background(), "1", "")
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Equal(t, []string{"10", "11", "20"}, []string{comments[0].ID, comments[1].ID, comments[2].ID})
+	assert.Equal(t, "Ana", comments[0].Author)
+	assert.Equal(t, "<p>Top</p>", comments[0].Content)
+	assert.Equal(t, "10", comments[1].ParentID)
+	assert.Equal(t, types.Comment{ID: "20", PageID: "1", Location: CommentInline, Selection: "retry", Resolution: "open"}, comments[2])
+
+	_, err = client.ListComments(context.Background(), "1", "sidebar")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestAddComment_InlineSelection(t *testing.T) {
+	var payload map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/content/1":
+			io.WriteString(w, `{"id":"1","body":{"storage":{"value":"<p>retry once, then <b>retry</b> &amp; wait</p>"}}}`)
+		case "/wiki/api/v2/inline-comments":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			io.WriteString(w, `{"id":"30","pageId":"1","resolutionStatus":"open","version":{"number":1},"body":{"storage":{"value":"<p>Why?</p>"}}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	comment, err := client.AddComment(context.Background(), &types.CreateCommentRequest{
+		PageID: "1", Content: "<p>Why?</p>", Selection: "retry", SelectionIndex: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "30", comment.ID)
+	assert.Equal(t, CommentInline, comment.Location)
+	assert.Equal(t, map[string]interface{}{
+		"textSelection": "retry", "textSelectionMatchCount": float64(2), "textSelectionMatchIndex": float64(1),
+	}, payload["inlineCommentProperties"])
+
+	_, err = client.AddComment(context.Background(), &types.CreateCommentRequest{PageID: "1", Content: "<p>x</p>", Selection: "missing"})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+	_, err = client.AddComment(context.Background(), &types.CreateCommentRequest{PageID: "1", Content: "<p>x</p>", Selection: "retry", SelectionIndex: 2})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestResolveComment_BumpsVersion(t *testing.T) {
+	var update map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/api/v2/inline-comments/30", r.URL.Path)
+		if r.Method == http.MethodGet {
+			io.WriteString(w, `{"id":"30","version":{"number":3},"body":{"storage":{"value":"<p>Why?</p>"}}}`)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		io.WriteString(w, `{"id":"30","pageId":"1","resolutionStatus":"resolved","version":{"number":4}}`)
+	})
+
+	comment, err := client.ResolveComment(context.Background(), "30", true)
+	require.NoError(t, err)
+	assert.Equal(t, "resolved", comment.Resolution)
+	assert.Equal(t, "<p>Why?</p>", comment.Content)
+	assert.Equal(t, map[string]interface{}{"number": float64(4)}, update["version"])
+	assert.Equal(t, true, update["resolved"])
+}
+
+func TestStorageText(t *testing.T) {
+	assert.Equal(t, "Fish & chips now", StorageText(`<p>Fish &amp; <strong>chips</strong> now</p>`))
+}
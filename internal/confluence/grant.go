@@ -0,0 +1,105 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Grant change actions
+const (
+	GrantAdd    = "add"
+	GrantRemove = "remove"
+)
+
+// Subject types of grants
+const (
+	SubjectUser      = "user"
+	SubjectGroup     = "group"
+	SubjectAnonymous = "anonymous"
+)
+
+// spaceOperationKeys and spaceOperationTargets are the parts of the operations
+// space permissions grant, e.g. "create page"
+var (
+	spaceOperationKeys    = []string{"read", "create", "delete", "export", "administer", "archive", "restrict_content"}
+	spaceOperationTargets = []string{"space", "page", "blogpost", "comment", "attachment"}
+)
+
+// GrantChange is a grant to add or remove
+type GrantChange struct {
+	Action string `json:"action"`
+	types.Grant
+}
+
+// String describes the change on one line, e.g. "+ read space group ops",
+// naming the permission a removal deletes
+func (c GrantChange) String() string {
+	if c.Action != GrantRemove {
+		return fmt.Sprintf("+ %s %s", c.Operation, DescribeSubject(c.Grant))
+	}
+	if c.ID != "" {
+		return fmt.Sprintf("- %s %s [permission %s]", c.Operation, DescribeSubject(c.Grant), c.ID)
+	}
+	return fmt.Sprintf("- %s %s", c.Operation, DescribeSubject(c.Grant))
+}
+
+// DescribeSubject names who a grant is for, e.g. "user Ana Lima (5b10ac8d)"
+func DescribeSubject(grant types.Grant) string {
+	switch {
+	case grant.SubjectType == SubjectAnonymous:
+		return SubjectAnonymous
+	case grant.Subject != "" && grant.Subject != grant.SubjectID:
+		return fmt.Sprintf("%s %s (%s)", grant.SubjectType, grant.Subject, grant.SubjectID)
+	}
+	return grant.SubjectType + " " + grant.SubjectID
+}
+
+// DiffGrants returns the changes that turn the grants before into the grants
+// after: removals first, each in the order given
+func DiffGrants(before, after []types.Grant) []GrantChange {
+	beforeKeys := make(map[string]bool, len(before))
+	for _, grant := range before {
+		beforeKeys[grantKey(grant)] = true
+	}
+	afterKeys := make(map[string]bool, len(after))
+	for _, grant := range after {
+		afterKeys[grantKey(grant)] = true
+	}
+
+	changes := []GrantChange{}
+	for _, grant := range before {
+		if !afterKeys[grantKey(grant)] {
+			changes = append(changes, GrantChange{Action: GrantRemove, Grant: grant})
+		}
+	}
+	for _, grant := range after {
+		key := grantKey(grant)
+		if !beforeKeys[key] {
+			changes = append(changes, GrantChange{Action: GrantAdd, Grant: grant})
+			beforeKeys[key] = true
+		}
+	}
+	return changes
+}
+
+// grantKey identifies a grant by what it allows and to whom
+func grantKey(grant types.Grant) string {
+	return grant.Operation + "\x00" + grant.SubjectType + "\x00" + grant.SubjectID
+}
+
+// ParseSpaceOperation parses a space permission operation written as "create page",
+// "create:page" or, for the space itself, just "read", returning it as "create page"
+func ParseSpaceOperation(value string) (string, error) {
+	fields := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool { return r == ':' || r == ' ' })
+	if len(fields) == 1 {
+		fields = append(fields, "space")
+	}
+	if len(fields) != 2 || !slices.Contains(spaceOperationKeys, fields[0]) || !slices.Contains(spaceOperationTargets, fields[1]) {
+		return "", clierr.New(clierr.KindValidation, "invalid operation %q: use <%s>:<%s>", value,
+			strings.Join(spaceOperationKeys, "|"), strings.Join(spaceOperationTargets, "|"))
+	}
+	return fields[0] + " " + fields[1], nil
+}
@@ -0,0 +1,56 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffGrants(t *testing.T) {
+	ops := types.Grant{ID: "1", Operation: "read space", SubjectType: SubjectGroup, SubjectID: "ops", Subject: "ops"}
+	ana := types.Grant{ID: "2", Operation: "create page", SubjectType: SubjectUser, SubjectID: "5b10ac8d", Subject: "Ana Lima"}
+	sre := types.Grant{Operation: "read space", SubjectType: SubjectGroup, SubjectID: "sre"}
+
+	assert.Empty(t, DiffGrants([]types.Grant{ops, ana}, []types.Grant{ana, ops}))
+
+	// Removals come first and repeated grants are added once
+	changes := DiffGrants([]types.Grant{ops, ana}, []types.Grant{ana, sre, sre})
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	assert.Equal(t, []string{"- read space group ops [permission 1]", "+ read space group sre"}, lines)
+
+	// A grant is matched by operation and subject, not by name or ID
+	renamed := ana
+	renamed.ID, renamed.Subject = "", ""
+	assert.Empty(t, DiffGrants([]types.Grant{ana}, []types.Grant{renamed}))
+}
+
+func TestDescribeSubject(t *testing.T) {
+	assert.Equal(t, "user Ana Lima (5b10ac8d)", DescribeSubject(types.Grant{SubjectType: SubjectUser, SubjectID: "5b10ac8d", Subject: "Ana Lima"}))
+	assert.Equal(t, "user 5b10ac8d", DescribeSubject(types.Grant{SubjectType: SubjectUser, SubjectID: "5b10ac8d"}))
+	assert.Equal(t, "group ops", DescribeSubject(types.Grant{SubjectType: SubjectGroup, SubjectID: "ops", Subject: "ops"}))
+	assert.Equal(t, "anonymous", DescribeSubject(types.Grant{SubjectType: SubjectAnonymous}))
+}
+
+func TestParseSpaceOperation(t *testing.T) {
+	for value, want := range map[string]string{
+		"read":             "read space",
+		"create:page":      "create page",
+		"Delete Blogpost":  "delete blogpost",
+		"administer:space": "administer space",
+	} {
+		operation, err := ParseSpaceOperation(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, operation)
+	}
+
+	for _, value := range []string{"", "write", "create:folder", "create:page:now"} {
+		_, err := ParseSpaceOperation(value)
+		assert.Equal(t, clierr.KindValidation, clierr.KindOf(err), value)
+	}
+}
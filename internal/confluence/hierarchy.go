@@ -0,0 +1,168 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"sync"
+)
+
+// childrenPageSize is the number of child pages requested per call
+const childrenPageSize = 100
+
+// treeConcurrency caps the child listings FetchTree runs at once
+const treeConcurrency = 8
+
+// PageNode is a page with its child pages, as built by FetchTree
+type PageNode struct {
+	types.Page
+	Children []*PageNode `json:"children,omitempty"`
+}
+
+// ListChildren returns the direct child pages of a page in their sibling order
+func (c *AtlassianConfluenceClient) ListChildren(ctx context.Context, id string) ([]types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	var pages []types.Page
+	for startAt := 0; ; startAt += childrenPageSize {
+		result, response, err := c.client.Content.ChildrenDescendant.ChildrenByType(ctx, id, "page", 0,
+			[]string{"version", "space"}, startAt, childrenPageSize)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list children of page %s", id)
+		}
+		for _, content := range result.Results {
+			page := convertContentSchemeToPage(content)
+			page.ParentID = id
+			page.Position = len(pages)
+			pages = append(pages, *page)
+		}
+		if result.Size < childrenPageSize {
+			return pages, nil
+		}
+	}
+}
+
+// ListAncestors returns the pages above a page, starting from the top of the space
+func (c *AtlassianConfluenceClient) ListAncestors(ctx context.Context, id string) ([]types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	result, response, err := c.client.Content.Get(ctx, id, []string{"ancestors", "ancestors.space", "ancestors.version"}, 0)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get ancestors of page %s", id)
+	}
+
+	pages := make([]types.Page, 0, len(result.Ancestors))
+	for i, ancestor := range result.Ancestors {
+		page := convertContentSchemeToPage(ancestor)
+		if i > 0 {
+			page.ParentID = result.Ancestors[i-1].ID
+		}
+		pages = append(pages, *page)
+	}
+	return pages, nil
+}
+
+// ListRootPages returns the top-level pages of a space
+func (c *AtlassianConfluenceClient) ListRootPages(ctx context.Context, spaceKey string) ([]types.Page, error) {
+	if spaceKey == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	var pages []types.Page
+	for startAt := 0; ; startAt += childrenPageSize {
+		result, response, err := c.client.Space.ContentByType(ctx, spaceKey, "page", "root",
+			[]string{"version", "space"}, startAt, childrenPageSize)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list pages of space %s", spaceKey)
+		}
+		for _, content := range result.Results {
+			page := convertContentSchemeToPage(content)
+			page.Position = len(pages)
+			pages = append(pages, *page)
+		}
+		if result.Size < childrenPageSize {
+			return pages, nil
+		}
+	}
+}
+
+// FetchTree builds the page trees below roots, listing the children of sibling
+// subtrees concurrently. maxDepth limits the levels returned, counting the roots
+// as level 1 (0 means unlimited). The first error stops the remaining requests.
+func FetchTree(ctx context.Context, client ConfluenceClient, roots []types.Page, maxDepth int) ([]*PageNode, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		slots    = make(chan struct{}, treeConcurrency)
+	)
+
+	var expand func(node *PageNode, depth int)
+	expand = func(node *PageNode, depth int) {
+		defer wg.Done()
+		if maxDepth > 0 && depth >= maxDepth {
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		children, err := client.ListChildren(ctx, node.ID)
+		<-slots
+		if err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+			mu.Unlock()
+			return
+		}
+
+		// Each node is written only by the goroutine expanding it
+		node.Children = make([]*PageNode, len(children))
+		for i, child := range children {
+			node.Children[i] = &PageNode{Page: child}
+			wg.Add(1)
+			go expand(node.Children[i], depth+1)
+		}
+	}
+
+	nodes := make([]*PageNode, len(roots))
+	for i, root := range roots {
+		nodes[i] = &PageNode{Page: root}
+		wg.Add(1)
+		go expand(nodes[i], 1)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// A cancelled caller context stops expansion without an error of its own
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// Walk visits every node depth-first, parents before their children
+func Walk(nodes []*PageNode, visit func(node *PageNode, depth int)) {
+	var walk func(nodes []*PageNode, depth int)
+	walk = func(nodes []*PageNode, depth int) {
+		for _, node := range nodes {
+			visit(node, depth)
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(nodes, 0)
+}
@@ -0,0 +1,119 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/types"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeClient serves ListChildren from a parent-to-children map
+type treeClient struct {
+	ConfluenceClient
+	children map[string][]string
+	fail     string
+
+	mu      sync.Mutex
+	calls   []string
+	active  atomic.Int32
+	maxSeen atomic.Int32
+}
+
+func (c *treeClient) ListChildren(ctx context.Context, id string) ([]types.Page, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, id)
+	c.mu.Unlock()
+
+	active := c.active.Add(1)
+	defer c.active.Add(-1)
+	for {
+		seen := c.maxSeen.Load()
+		if active <= seen || c.maxSeen.CompareAndSwap(seen, active) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	if id == c.fail {
+		return nil, errors.New("boom")
+	}
+	var pages []types.Page
+	for i, child := range c.children[id] {
+		pages = append(pages, types.Page{ID: child, Title: "Page " + child, ParentID: id, Position: i})
+	}
+	return pages, nil
+}
+
+// wideTree builds a root with n children that each have one child
+func wideTree(n int) map[string][]string {
+	children := map[string][]string{}
+	for i := 0; i < n; i++ {
+		child := fmt.Sprintf("c%d", i)
+		children["root"] = append(children["root"], child)
+		children[child] = []string{child + "-leaf"}
+	}
+	return children
+}
+
+func TestFetchTree(t *testing.T) {
+	client := &treeClient{children: map[string][]string{
+		"1": {"2", "3"},
+		"2": {"4"},
+	}}
+
+	tree, err := FetchTree(context.Background(), client, []types.Page{{ID: "1"}}, 0)
+	require.NoError(t, err)
+
+	var visited []string
+	Walk(tree, func(node *PageNode, depth int) {
+		visited = append(visited, fmt.Sprintf("%d:%s", depth, node.ID))
+	})
+	assert.Equal(t, []string{"0:1", "1:2", "2:4", "1:3"}, visited)
+	assert.Equal(t, "2", tree[0].Children[0].ID)
+	assert.Equal(t, 1, tree[0].Children[1].Position)
+}
+
+func TestFetchTree_Depth(t *testing.T) {
+	client := &treeClient{children: wideTree(3)}
+
+	tree, err := FetchTree(context.Background(), client, []types.Page{{ID: "root"}}, 2)
+	require.NoError(t, err)
+	assert.Len(t, tree[0].Children, 3)
+	for _, child := range tree[0].Children {
+		assert.Empty(t, child.Children)
+	}
+	// Pages on the last level are not asked for children
+	assert.Equal(t, []string{"root"}, client.calls)
+}
+
+func TestFetchTree_Concurrent(t *testing.T) {
+	client := &treeClient{children: wideTree(40)}
+
+	tree, err := FetchTree(context.Background(), client, []types.Page{{ID: "root"}}, 0)
+	require.NoError(t, err)
+	assert.Len(t, tree[0].Children, 40)
+	assert.Greater(t, client.maxSeen.Load(), int32(1), "subtrees should be fetched concurrently")
+	assert.LessOrEqual(t, client.maxSeen.Load(), int32(treeConcurrency))
+}
+
+func TestFetchTree_Error(t *testing.T) {
+	client := &treeClient{children: wideTree(5), fail: "c2"}
+
+	_, err := FetchTree(context.Background(), client, []types.Page{{ID: "root"}}, 0)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestFetchTree_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := FetchTree(ctx, &treeClient{children: wideTree(2)}, []types.Page{{ID: "root"}}, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,116 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// labelsPageSize is the number of labels requested per call
+const labelsPageSize = 200
+
+// cqlEscaper escapes a value for a double-quoted CQL string
+var cqlEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// CQLString returns value as a double-quoted CQL string, so user input cannot
+// end the string and change the query
+func CQLString(value string) string {
+	return `"` + cqlEscaper.Replace(value) + `"`
+}
+
+// ListLabels returns the labels of a page
+func (c *AtlassianConfluenceClient) ListLabels(ctx context.Context, pageID string) ([]types.Label, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	labels := []types.Label{}
+	for startAt := 0; ; startAt += labelsPageSize {
+		result, response, err := c.client.Content.Label.Gets(ctx, pageID, "", startAt, labelsPageSize)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list labels of page %s", pageID)
+		}
+		labels = append(labels, convertLabels(result.Results)...)
+		if result.Size < labelsPageSize {
+			return labels, nil
+		}
+	}
+}
+
+// AddLabels adds labels to a page, returning all of the page's labels. Labels
+// the page already has are left as they are.
+func (c *AtlassianConfluenceClient) AddLabels(ctx context.Context, pageID string, names []string) ([]types.Label, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if err := ValidateLabels(names); err != nil {
+		return nil, err
+	}
+
+	payload := make([]*models.ContentLabelPayloadScheme, len(names))
+	for i, name := range names {
+		payload[i] = &models.ContentLabelPayloadScheme{Prefix: "global", Name: name}
+	}
+	result, response, err := c.client.Content.Label.Add(ctx, pageID, payload, true)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to add labels to page %s", pageID)
+	}
+	return convertLabels(result.Results), nil
+}
+
+// RemoveLabel removes a label from a page
+func (c *AtlassianConfluenceClient) RemoveLabel(ctx context.Context, pageID, name string) error {
+	if pageID == "" {
+		return clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if name == "" {
+		return clierr.New(clierr.KindValidation, "label is required")
+	}
+
+	response, err := c.client.Content.Label.Remove(ctx, pageID, url.PathEscape(name))
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to remove label %s from page %s", name, pageID)
+	}
+	return nil
+}
+
+// ValidateLabels checks that labels are names Confluence accepts: non-empty and
+// without whitespace
+func ValidateLabels(names []string) error {
+	if len(names) == 0 {
+		return clierr.New(clierr.KindValidation, "at least one label is required")
+	}
+	for _, name := range names {
+		if name == "" {
+			return clierr.New(clierr.KindValidation, "labels cannot be empty")
+		}
+		if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			return clierr.New(clierr.KindValidation, "invalid label %q: labels cannot contain spaces", name)
+		}
+	}
+	return nil
+}
+
+// LabelCQL returns a CQL condition matching content that has every one of the
+// labels, or "" when there are none
+func LabelCQL(labels []string) string {
+	conditions := make([]string, len(labels))
+	for i, label := range labels {
+		conditions[i] = "label = " + CQLString(label)
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// convertLabels converts label schemes to our type
+func convertLabels(schemes []*models.ContentLabelScheme) []types.Label {
+	labels := make([]types.Label, 0, len(schemes))
+	for _, scheme := range schemes {
+		labels = append(labels, types.Label{ID: scheme.ID, Name: scheme.Name, Prefix: scheme.Prefix})
+	}
+	return labels
+}
@@ -0,0 +1,21 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelCQL(t *testing.T) {
+	assert.Equal(t, "", LabelCQL(nil))
+	assert.Equal(t, `label = "runbook"`, LabelCQL([]string{"runbook"}))
+	assert.Equal(t, `label = "runbook" AND label = "db\"x"`, LabelCQL([]string{"runbook", `db"x`}))
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels([]string{"runbook", "team-a"}))
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(ValidateLabels(nil)))
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(ValidateLabels([]string{""})))
+	assert.EqualError(t, ValidateLabels([]string{"two words"}), `invalid label "two words": labels cannot contain spaces`)
+}
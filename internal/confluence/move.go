@@ -0,0 +1,71 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// MovePage moves a page relative to a target page: "append" makes it the target's
+// last child, "before" and "after" make it the target's sibling
+func (c *AtlassianConfluenceClient) MovePage(ctx context.Context, id, position, targetID string) error {
+	if id == "" {
+		return clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if targetID == "" {
+		return clierr.New(clierr.KindValidation, "target page ID is required")
+	}
+	if !models.ValidPositions[position] {
+		return clierr.New(clierr.KindValidation, "invalid position %q: must be before, after or append", position)
+	}
+
+	_, response, err := c.client.Content.ChildrenDescendant.Move(ctx, id, position, targetID)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to move page %s", id)
+	}
+	return nil
+}
+
+// CopyPage copies a single page, without its children, to the end of a parent's children
+func (c *AtlassianConfluenceClient) CopyPage(ctx context.Context, id string, req *types.CopyPageRequest) (*types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if req == nil || req.ParentID == "" {
+		return nil, clierr.New(clierr.KindValidation, "destination parent ID is required")
+	}
+
+	options := &models.CopyOptionsScheme{
+		CopyAttachments: req.IncludeAttachments,
+		CopyLabels:      req.IncludeLabels,
+		Destination:     &models.CopyPageDestinationScheme{Type: "parent_page", Value: req.ParentID},
+		PageTitle:       req.Title,
+	}
+
+	result, response, err := c.client.Content.ChildrenDescendant.CopyPage(ctx, id, []string{"version", "space", "ancestors"}, options)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to copy page %s", id)
+	}
+	return convertContentSchemeToPage(result), nil
+}
+
+// GetSpaceHomepage returns the home page of a space, the parent of its top-level pages
+func (c *AtlassianConfluenceClient) GetSpaceHomepage(ctx context.Context, spaceKey string) (*types.Page, error) {
+	if spaceKey == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	space, response, err := c.client.Space.Get(ctx, spaceKey, []string{"homepage"})
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get space %s", spaceKey)
+	}
+	if space.HomePage == nil || space.HomePage.ID == "" {
+		return nil, clierr.New(clierr.KindNotFound, "space %s has no home page", spaceKey)
+	}
+
+	page := convertContentSchemeToPage(space.HomePage)
+	page.SpaceKey = spaceKey
+	return page, nil
+}
@@ -0,0 +1,218 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Operations a page restriction limits
+const (
+	RestrictionRead   = "read"
+	RestrictionUpdate = "update"
+)
+
+// restrictionOperations are the operations of page restrictions, in the order
+// they are listed
+var restrictionOperations = []string{RestrictionRead, RestrictionUpdate}
+
+// restrictionResults are the restrictions of a page as the v1 API returns them
+type restrictionResults struct {
+	Results []struct {
+		Operation    string `json:"operation"`
+		Restrictions struct {
+			User struct {
+				Results []struct {
+					AccountID   string `json:"accountId"`
+					DisplayName string `json:"displayName"`
+				} `json:"results"`
+			} `json:"user"`
+			Group struct {
+				Results []struct {
+					Name string `json:"name"`
+				} `json:"results"`
+			} `json:"group"`
+		} `json:"restrictions"`
+	} `json:"results"`
+}
+
+// restrictionSubject is a user or group in a restriction update
+type restrictionSubject struct {
+	Type      string `json:"type"`
+	AccountID string `json:"accountId,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ListSpacePermissions lists the permissions of a space, one grant per
+// operation and group or user
+func (c *AtlassianConfluenceClient) ListSpacePermissions(ctx context.Context, key string) ([]types.Grant, error) {
+	if key == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/space/%s?expand=permissions", url.PathEscape(key))
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	resource := new(spaceResource)
+	response, err := c.client.Call(request, resource)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get the permissions of space %s", key)
+	}
+	return spaceGrants(resource), nil
+}
+
+// GrantSpacePermission grants a user or group an operation in a space, such as
+// "create page"
+func (c *AtlassianConfluenceClient) GrantSpacePermission(ctx context.Context, key string, grant types.Grant) error {
+	if key == "" {
+		return clierr.New(clierr.KindValidation, "space key is required")
+	}
+	if grant.SubjectType != SubjectUser && grant.SubjectType != SubjectGroup {
+		return clierr.New(clierr.KindValidation, "permissions can only be granted to a user or a group")
+	}
+	operation, err := ParseSpaceOperation(grant.Operation)
+	if err != nil {
+		return err
+	}
+	operationKey, target, _ := strings.Cut(operation, " ")
+
+	payload := map[string]interface{}{
+		"subject":   map[string]string{"type": grant.SubjectType, "identifier": grant.SubjectID},
+		"operation": map[string]string{"key": operationKey, "target": target},
+	}
+	endpoint := fmt.Sprintf("wiki/rest/api/space/%s/permission", url.PathEscape(key))
+	request, err := c.client.NewRequest(ctx, http.MethodPost, endpoint, "", payload)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	response, err := c.client.Call(request, nil)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to grant %s to %s in space %s", operation, DescribeSubject(grant), key)
+	}
+	return nil
+}
+
+// RevokeSpacePermission removes a permission from a space by its ID
+func (c *AtlassianConfluenceClient) RevokeSpacePermission(ctx context.Context, key, id string) error {
+	if key == "" || id == "" {
+		return clierr.New(clierr.KindValidation, "space key and permission ID are required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/space/%s/permission/%s", url.PathEscape(key), url.PathEscape(id))
+	request, err := c.client.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	response, err := c.client.Call(request, nil)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to revoke permission %s in space %s", id, key)
+	}
+	return nil
+}
+
+// GetRestrictions lists who a page's read and update restrictions allow. A page
+// without restrictions has no grants: anyone with space permission may view and edit it.
+func (c *AtlassianConfluenceClient) GetRestrictions(ctx context.Context, pageID string) ([]types.Grant, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/restriction?expand=restrictions.user,restrictions.group", url.PathEscape(pageID))
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	result := new(restrictionResults)
+	response, err := c.client.Call(request, result)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get the restrictions of page %s", pageID)
+	}
+
+	var grants []types.Grant
+	for _, operation := range restrictionOperations {
+		for _, restriction := range result.Results {
+			if restriction.Operation != operation {
+				continue
+			}
+			for _, group := range restriction.Restrictions.Group.Results {
+				grants = append(grants, types.Grant{Operation: operation, SubjectType: SubjectGroup, SubjectID: group.Name, Subject: group.Name})
+			}
+			for _, user := range restriction.Restrictions.User.Results {
+				grants = append(grants, types.Grant{Operation: operation, SubjectType: SubjectUser, SubjectID: user.AccountID, Subject: user.DisplayName})
+			}
+		}
+	}
+	return grants, nil
+}
+
+// SetRestrictions replaces all of a page's restrictions with grants; no grants
+// removes the restrictions
+func (c *AtlassianConfluenceClient) SetRestrictions(ctx context.Context, pageID string, grants []types.Grant) error {
+	if pageID == "" {
+		return clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/restriction", url.PathEscape(pageID))
+	if len(grants) == 0 {
+		return c.callRestrictions(ctx, http.MethodDelete, endpoint, nil, pageID)
+	}
+	payload, err := restrictionPayload(grants)
+	if err != nil {
+		return err
+	}
+	return c.callRestrictions(ctx, http.MethodPut, endpoint, payload, pageID)
+}
+
+// callRestrictions sends a restriction update
+func (c *AtlassianConfluenceClient) callRestrictions(ctx context.Context, method, endpoint string, payload interface{}, pageID string) error {
+	request, err := c.client.NewRequest(ctx, method, endpoint, "", payload)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	response, err := c.client.Call(request, nil)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to set the restrictions of page %s", pageID)
+	}
+	return nil
+}
+
+// restrictionPayload builds the body of a restriction update: each operation
+// with the users and groups it is restricted to
+func restrictionPayload(grants []types.Grant) ([]interface{}, error) {
+	for _, grant := range grants {
+		if grant.Operation != RestrictionRead && grant.Operation != RestrictionUpdate {
+			return nil, clierr.New(clierr.KindValidation, "invalid restriction operation %q: must be read or update", grant.Operation)
+		}
+		if grant.SubjectType != SubjectUser && grant.SubjectType != SubjectGroup {
+			return nil, clierr.New(clierr.KindValidation, "pages can only be restricted to users and groups")
+		}
+	}
+
+	var payload []interface{}
+	for _, operation := range restrictionOperations {
+		users := []restrictionSubject{}
+		groups := []restrictionSubject{}
+		for _, grant := range grants {
+			switch {
+			case grant.Operation != operation:
+			case grant.SubjectType == SubjectUser:
+				users = append(users, restrictionSubject{Type: "known", AccountID: grant.SubjectID})
+			default:
+				groups = append(groups, restrictionSubject{Type: SubjectGroup, Name: grant.SubjectID})
+			}
+		}
+		if len(users) > 0 || len(groups) > 0 {
+			payload = append(payload, map[string]interface{}{
+				"operation":    operation,
+				"restrictions": map[string]interface{}{"user": users, "group": groups},
+			})
+		}
+	}
+	return payload, nil
+}
@@ -0,0 +1,86 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRestrictions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/content/123/restriction", r.URL.Path)
+		assert.Equal(t, "restrictions.user,restrictions.group", r.URL.Query().Get("expand"))
+		w.Write([]byte(`{"results": [
+			{"operation": "update", "restrictions": {
+				"user": {"results": [{"accountId": "5b10ac8d", "displayName": "Ana Lima"}]},
+				"group": {"results": []}}},
+			{"operation": "read", "restrictions": {
+				"user": {"results": []},
+				"group": {"results": [{"name": "incident-response"}]}}}
+		]}`))
+	})
+
+	grants, err := client.GetRestrictions(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, []types.Grant{
+		{Operation: RestrictionRead, SubjectType: SubjectGroup, SubjectID: "incident-response", Subject: "incident-response"},
+		{Operation: RestrictionUpdate, SubjectType: SubjectUser, SubjectID: "5b10ac8d", Subject: "Ana Lima"},
+	}, grants)
+}
+
+func TestSetRestrictions(t *testing.T) {
+	var method string
+	var payload []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/content/123/restriction", r.URL.Path)
+		method = r.Method
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	err := client.SetRestrictions(context.Background(), "123", []types.Grant{
+		{Operation: RestrictionUpdate, SubjectType: SubjectUser, SubjectID: "5b10ac8d"},
+		{Operation: RestrictionUpdate, SubjectType: SubjectGroup, SubjectID: "ops"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	require.Len(t, payload, 1)
+	assert.Equal(t, "update", payload[0]["operation"])
+	assert.Equal(t, map[string]interface{}{
+		"user":  []interface{}{map[string]interface{}{"type": "known", "accountId": "5b10ac8d"}},
+		"group": []interface{}{map[string]interface{}{"type": "group", "name": "ops"}},
+	}, payload[0]["restrictions"])
+
+	// No grants lifts the restrictions
+	require.NoError(t, client.SetRestrictions(context.Background(), "123", nil))
+	assert.Equal(t, http.MethodDelete, method)
+
+	err = client.SetRestrictions(context.Background(), "123", []types.Grant{{Operation: "delete", SubjectType: SubjectGroup, SubjectID: "ops"}})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestGrantSpacePermission(t *testing.T) {
+	var payload map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/wiki/rest/api/space/OPS/permission", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.Write([]byte(`{}`))
+	})
+
+	err := client.GrantSpacePermission(context.Background(), "OPS", types.Grant{Operation: "create page", SubjectType: SubjectGroup, SubjectID: "sre"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"type": "group", "identifier": "sre"}, payload["subject"])
+	assert.Equal(t, map[string]interface{}{"key": "create", "target": "page"}, payload["operation"])
+
+	err = client.GrantSpacePermission(context.Background(), "OPS", types.Grant{Operation: "read space", SubjectType: SubjectAnonymous})
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
@@ -0,0 +1,204 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// propertiesPageSize is the number of content properties requested per call
+const propertiesPageSize = 100
+
+var (
+	// propertyKey matches the key of a content property a filter may name
+	propertyKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+	// propertyAlias matches the alias a property index gives a value
+	propertyAlias = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+)
+
+// propertyOperators are the CQL operators of a property filter, longest first
+// so that ">=" is not read as ">"
+var propertyOperators = []string{">=", "<=", "!=", "=", ">", "<", "~"}
+
+// propertyResource is a content property as the v1 API returns it
+type propertyResource struct {
+	ID      string          `json:"id"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *struct {
+		Number int `json:"number"`
+	} `json:"version"`
+}
+
+// ListProperties returns the content properties of a page
+func (c *AtlassianConfluenceClient) ListProperties(ctx context.Context, pageID string) ([]types.ContentProperty, error) {
+	if pageID == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	properties := []types.ContentProperty{}
+	for startAt := 0; ; startAt += propertiesPageSize {
+		endpoint := fmt.Sprintf("wiki/rest/api/content/%s/property?expand=version&start=%d&limit=%d",
+			url.PathEscape(pageID), startAt, propertiesPageSize)
+		request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+		}
+		var result struct {
+			Results []*propertyResource `json:"results"`
+			Size    int                 `json:"size"`
+		}
+		response, err := c.client.Call(request, &result)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list properties of page %s", pageID)
+		}
+		for _, resource := range result.Results {
+			properties = append(properties, convertProperty(resource))
+		}
+		if result.Size < propertiesPageSize {
+			return properties, nil
+		}
+	}
+}
+
+// GetProperty retrieves a content property of a page by key
+func (c *AtlassianConfluenceClient) GetProperty(ctx context.Context, pageID, key string) (*types.ContentProperty, error) {
+	if pageID == "" || key == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID and property key are required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/property/%s?expand=version", url.PathEscape(pageID), url.PathEscape(key))
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	resource := new(propertyResource)
+	response, err := c.client.Call(request, resource)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get property %s of page %s", key, pageID)
+	}
+	property := convertProperty(resource)
+	return &property, nil
+}
+
+// SetProperty stores a JSON value on a page under key, creating the property
+// or replacing its value
+func (c *AtlassianConfluenceClient) SetProperty(ctx context.Context, pageID, key string, value json.RawMessage) (*types.ContentProperty, error) {
+	if pageID == "" || key == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID and property key are required")
+	}
+	if !json.Valid(value) {
+		return nil, clierr.New(clierr.KindValidation, "the value of property %s is not valid JSON", key)
+	}
+
+	payload := map[string]interface{}{"key": key, "value": value}
+	method := http.MethodPost
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/property", url.PathEscape(pageID))
+
+	current, err := c.GetProperty(ctx, pageID, key)
+	switch {
+	case err == nil:
+		// Updates name the version they create
+		method = http.MethodPut
+		endpoint += "/" + url.PathEscape(key)
+		payload["version"] = map[string]int{"number": current.Version + 1}
+	case clierr.KindOf(err) != clierr.KindNotFound:
+		return nil, err
+	}
+
+	request, err := c.client.NewRequest(ctx, method, endpoint, "", payload)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	resource := new(propertyResource)
+	response, err := c.client.Call(request, resource)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to set property %s of page %s", key, pageID)
+	}
+	property := convertProperty(resource)
+	return &property, nil
+}
+
+// DeleteProperty removes a content property from a page
+func (c *AtlassianConfluenceClient) DeleteProperty(ctx context.Context, pageID, key string) error {
+	if pageID == "" || key == "" {
+		return clierr.New(clierr.KindValidation, "page ID and property key are required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s/property/%s", url.PathEscape(pageID), url.PathEscape(key))
+	request, err := c.client.NewRequest(ctx, http.MethodDelete, endpoint, "", nil)
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	response, err := c.client.Call(request, nil)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to delete property %s of page %s", key, pageID)
+	}
+	return nil
+}
+
+// convertProperty converts a v1 content property to our type
+func convertProperty(resource *propertyResource) types.ContentProperty {
+	property := types.ContentProperty{ID: resource.ID, Key: resource.Key, Value: resource.Value}
+	if resource.Version != nil {
+		property.Version = resource.Version.Number
+	}
+	return property
+}
+
+// PropertyCQL returns a CQL condition matching content whose indexed properties
+// pass every filter, or "" when there are none. A filter is written
+// <key>.<alias><operator><value>, e.g. "service.tier>=2" or "owner.team=payments",
+// where alias is the name the property's index gives the value.
+func PropertyCQL(filters []string) (string, error) {
+	conditions := make([]string, len(filters))
+	for i, filter := range filters {
+		condition, err := propertyCondition(filter)
+		if err != nil {
+			return "", err
+		}
+		conditions[i] = condition
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// propertyCondition converts one property filter to a CQL condition
+func propertyCondition(filter string) (string, error) {
+	at := strings.IndexAny(filter, "=!<>~")
+	if at < 0 {
+		return "", invalidPropertyFilter(filter)
+	}
+	var operator string
+	for _, op := range propertyOperators {
+		if strings.HasPrefix(filter[at:], op) {
+			operator = op
+			break
+		}
+	}
+	dot := strings.LastIndex(filter[:at], ".")
+	if operator == "" || dot <= 0 || dot == at-1 {
+		return "", invalidPropertyFilter(filter)
+	}
+	key, alias, value := filter[:dot], filter[dot+1:at], filter[at+len(operator):]
+	if !propertyKey.MatchString(key) || !propertyAlias.MatchString(alias) {
+		return "", invalidPropertyFilter(filter)
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		value = CQLString(value)
+	}
+	return fmt.Sprintf("content.property[%s].%s %s %s", key, alias, operator, value), nil
+}
+
+// invalidPropertyFilter reports a property filter that cannot be parsed
+func invalidPropertyFilter(filter string) error {
+	return clierr.New(clierr.KindValidation, "invalid property filter %q: use <key>.<alias><operator><value>, e.g. owner.team=payments", filter)
+}
@@ -0,0 +1,70 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyCQL(t *testing.T) {
+	cql, err := PropertyCQL([]string{"owner.team=payments", "service.tier>=2", "review.due<2026-12-01", `owner.name~"Ana"`})
+	require.NoError(t, err)
+	assert.Equal(t, `content.property[owner].team = "payments" AND content.property[service].tier >= 2 AND `+
+		`content.property[review].due < "2026-12-01" AND content.property[owner].name ~ "\"Ana\""`, cql)
+
+	cql, err = PropertyCQL([]string{"meta.v1.status!=retired"})
+	require.NoError(t, err)
+	assert.Equal(t, `content.property[meta.v1].status != "retired"`, cql)
+
+	for _, filter := range []string{"owner", "owner=payments", ".team=payments", "owner.=payments",
+		"owner] OR x[y.team=payments", "owner.team OR 1=1", `own"er.team=payments`, "owner.2team=payments"} {
+		_, err := PropertyCQL([]string{filter})
+		assert.Equal(t, clierr.KindValidation, clierr.KindOf(err), filter)
+	}
+}
+
+func TestSetProperty(t *testing.T) {
+	properties := map[string]string{"owner": `{"id": "1", "key": "owner", "value": {"team": "search"}, "version": {"number": 3}}`}
+	var method string
+	var payload map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			body, ok := properties[r.URL.Path[len("/wiki/rest/api/content/123/property/"):]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		default:
+			method = r.Method + " " + r.URL.Path
+			payload = nil
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.Write([]byte(`{"id": "2", "key": "owner", "value": {"team": "payments"}, "version": {"number": 4}}`))
+		}
+	})
+
+	// An existing property is updated to its next version
+	property, err := client.SetProperty(context.Background(), "123", "owner", json.RawMessage(`{"team": "payments"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "PUT /wiki/rest/api/content/123/property/owner", method)
+	assert.Equal(t, map[string]interface{}{"number": float64(4)}, payload["version"])
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, payload["value"])
+	assert.Equal(t, 4, property.Version)
+	assert.JSONEq(t, `{"team": "payments"}`, string(property.Value))
+
+	// A new one is created
+	_, err = client.SetProperty(context.Background(), "123", "service", json.RawMessage(`"checkout"`))
+	require.NoError(t, err)
+	assert.Equal(t, "POST /wiki/rest/api/content/123/property", method)
+	assert.Equal(t, "checkout", payload["value"])
+	assert.NotContains(t, payload, "version")
+
+	_, err = client.SetProperty(context.Background(), "123", "owner", json.RawMessage(`payments`))
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
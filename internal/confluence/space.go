@@ -0,0 +1,280 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// Space statuses
+const (
+	SpaceCurrent  = "current"
+	SpaceArchived = "archived"
+)
+
+// spaceListExpand is what space lists expand
+var spaceListExpand = []string{"description.plain", "homepage"}
+
+// spaceExpand is what a single space expands: its list fields and permissions
+var spaceExpand = append(append([]string{}, spaceListExpand...), "permissions")
+
+// spaceResource is a space as the v1 API returns it: go-atlassian's space model
+// has no description, so spaces are read into spaceResource instead
+type spaceResource struct {
+	ID          int    `json:"id"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Description *struct {
+		Plain *struct {
+			Value string `json:"value"`
+		} `json:"plain"`
+	} `json:"description"`
+	Homepage *struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"homepage"`
+	Permissions []struct {
+		ID       int64 `json:"id"`
+		Subjects *struct {
+			User *struct {
+				Results []struct {
+					AccountID   string `json:"accountId"`
+					DisplayName string `json:"displayName"`
+					PublicName  string `json:"publicName"`
+				} `json:"results"`
+			} `json:"user"`
+			Group *struct {
+				Results []struct {
+					Name string `json:"name"`
+				} `json:"results"`
+			} `json:"group"`
+		} `json:"subjects"`
+		Operation *struct {
+			Operation  string `json:"operation"`
+			TargetType string `json:"targetType"`
+		} `json:"operation"`
+		AnonymousAccess bool `json:"anonymousAccess"`
+	} `json:"permissions"`
+}
+
+// spacePage is a page of spaces from the v1 API
+type spacePage struct {
+	Results []spaceResource `json:"results"`
+	Size    int             `json:"size"`
+}
+
+// GetSpace retrieves a space with its description, home page and a summary of
+// its permissions
+func (c *AtlassianConfluenceClient) GetSpace(ctx context.Context, key string) (*types.Space, error) {
+	if key == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	query := url.Values{"expand": {strings.Join(spaceExpand, ",")}}
+	endpoint := fmt.Sprintf("wiki/rest/api/space/%s?%s", url.PathEscape(key), query.Encode())
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	resource := new(spaceResource)
+	response, err := c.client.Call(request, resource)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get space %s", key)
+	}
+
+	space := convertSpace(resource)
+	return &space, nil
+}
+
+// CreateSpace creates a space, private to its creator when req.Private is set
+func (c *AtlassianConfluenceClient) CreateSpace(ctx context.Context, req *types.CreateSpaceRequest) (*types.Space, error) {
+	if req == nil || req.Key == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, clierr.New(clierr.KindValidation, "space name is required")
+	}
+
+	payload := &models.CreateSpaceScheme{
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: spaceDescription(req.Description),
+	}
+	_, response, err := c.client.Space.Create(ctx, payload, req.Private)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to create space %s", req.Key)
+	}
+
+	return c.GetSpace(ctx, req.Key)
+}
+
+// UpdateSpace changes the name, description or home page of a space
+func (c *AtlassianConfluenceClient) UpdateSpace(ctx context.Context, key string, req *types.UpdateSpaceRequest) (*types.Space, error) {
+	if key == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+	if req == nil || (req.Name == nil && req.Description == nil && req.HomepageID == nil) {
+		return nil, clierr.New(clierr.KindValidation, "nothing to update: give a name, description or home page")
+	}
+
+	payload := &models.UpdateSpaceScheme{}
+	if req.Name != nil {
+		payload.Name = *req.Name
+	}
+	if req.Description != nil {
+		payload.Description = spaceDescription(*req.Description)
+	}
+	if req.HomepageID != nil {
+		payload.Homepage = &models.UpdateSpaceHomepageScheme{ID: *req.HomepageID}
+	}
+	_, response, err := c.client.Space.Update(ctx, key, payload)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to update space %s", key)
+	}
+
+	return c.GetSpace(ctx, key)
+}
+
+// ArchiveSpace archives a space, or restores an archived space when archived
+// is false. go-atlassian's update model has no status, so the request is built here.
+func (c *AtlassianConfluenceClient) ArchiveSpace(ctx context.Context, key string, archived bool) (*types.Space, error) {
+	if key == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	status := SpaceCurrent
+	if archived {
+		status = SpaceArchived
+	}
+	endpoint := fmt.Sprintf("wiki/rest/api/space/%s", url.PathEscape(key))
+	request, err := c.client.NewRequest(ctx, http.MethodPut, endpoint, "", map[string]string{"status": status})
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	response, err := c.client.Call(request, nil)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to set the status of space %s to %s", key, status)
+	}
+
+	return c.GetSpace(ctx, key)
+}
+
+// DeleteSpace deletes a space and all of its content. Confluence removes the
+// content in the background after the call returns.
+func (c *AtlassianConfluenceClient) DeleteSpace(ctx context.Context, key string) error {
+	if key == "" {
+		return clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	_, response, err := c.client.Space.Delete(ctx, key)
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to delete space %s", key)
+	}
+	return nil
+}
+
+// spaceDescription builds the plain-text description of a space request
+func spaceDescription(description string) *models.CreateSpaceDescriptionScheme {
+	return &models.CreateSpaceDescriptionScheme{
+		Plain: &models.CreateSpaceDescriptionPlainScheme{Value: description, Representation: "plain"},
+	}
+}
+
+// convertSpace converts a space from the v1 API to our internal Space type
+func convertSpace(resource *spaceResource) types.Space {
+	space := types.Space{
+		ID:     strconv.Itoa(resource.ID),
+		Key:    resource.Key,
+		Name:   resource.Name,
+		Type:   resource.Type,
+		Status: resource.Status,
+	}
+	if resource.Description != nil && resource.Description.Plain != nil {
+		space.Description = resource.Description.Plain.Value
+	}
+	if resource.Homepage != nil {
+		space.HomepageID = resource.Homepage.ID
+		space.HomepageTitle = resource.Homepage.Title
+	}
+	space.Permissions = summarizePermissions(spaceGrants(resource))
+	return space
+}
+
+// spaceGrants flattens the permissions of a space into one grant per operation
+// and group or user
+func spaceGrants(resource *spaceResource) []types.Grant {
+	var grants []types.Grant
+	for _, permission := range resource.Permissions {
+		if permission.Operation == nil {
+			continue
+		}
+		grant := types.Grant{
+			ID:        strconv.FormatInt(permission.ID, 10),
+			Operation: strings.TrimSpace(permission.Operation.Operation + " " + permission.Operation.TargetType),
+		}
+		if permission.AnonymousAccess {
+			grant.SubjectType = SubjectAnonymous
+			grants = append(grants, grant)
+		}
+		if permission.Subjects == nil {
+			continue
+		}
+		if permission.Subjects.Group != nil {
+			for _, group := range permission.Subjects.Group.Results {
+				grant.SubjectType, grant.SubjectID, grant.Subject = SubjectGroup, group.Name, group.Name
+				grants = append(grants, grant)
+			}
+		}
+		if permission.Subjects.User != nil {
+			for _, user := range permission.Subjects.User.Results {
+				grant.SubjectType, grant.SubjectID, grant.Subject = SubjectUser, user.AccountID, user.DisplayName
+				if grant.Subject == "" {
+					grant.Subject = user.PublicName
+				}
+				grants = append(grants, grant)
+			}
+		}
+	}
+	return grants
+}
+
+// summarizePermissions groups the operations of a space's grants by the group
+// or user they are granted to, groups first
+func summarizePermissions(grants []types.Grant) []types.SpacePermission {
+	byKey := map[string]*types.SpacePermission{}
+	var keys []string
+	for _, grant := range grants {
+		key := grant.SubjectType + "\x00" + grant.Subject
+		permission, ok := byKey[key]
+		if !ok {
+			permission = &types.SpacePermission{SubjectType: grant.SubjectType, Subject: grant.Subject}
+			byKey[key] = permission
+			keys = append(keys, key)
+		}
+		permission.Operations = append(permission.Operations, grant.Operation)
+	}
+
+	order := map[string]int{SubjectGroup: 0, SubjectUser: 1, SubjectAnonymous: 2}
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := byKey[keys[i]], byKey[keys[j]]
+		if a.SubjectType != b.SubjectType {
+			return order[a.SubjectType] < order[b.SubjectType]
+		}
+		return a.Subject < b.Subject
+	})
+	var permissions []types.SpacePermission
+	for _, key := range keys {
+		permissions = append(permissions, *byKey[key])
+	}
+	return permissions
+}
@@ -0,0 +1,95 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/types"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spaceJSON = `{"id": 42, "key": "OPS", "name": "Operations", "type": "global", "status": "current",
+	"description": {"plain": {"value": "Runbooks", "representation": "plain"}},
+	"homepage": {"id": "100", "title": "Operations Home"},
+	"permissions": [
+		{"operation": {"operation": "read", "targetType": "space"},
+		 "subjects": {"group": {"results": [{"name": "confluence-users"}, {"name": "ops"}]}}},
+		{"operation": {"operation": "administer", "targetType": "space"},
+		 "subjects": {"group": {"results": [{"name": "ops"}]}}},
+		{"operation": {"operation": "create", "targetType": "page"},
+		 "subjects": {"user": {"results": [{"displayName": "Ana Lima"}]}}},
+		{"operation": {"operation": "read", "targetType": "space"}, "anonymousAccess": true}
+	]}`
+
+func TestGetSpace_SummarizesPermissions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/space/OPS", r.URL.Path)
+		assert.Equal(t, "description.plain,homepage,permissions", r.URL.Query().Get("expand"))
+		w.Write([]byte(spaceJSON))
+	})
+
+	space, err := client.GetSpace(context.Background(), "OPS")
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", space.ID)
+	assert.Equal(t, "Runbooks", space.Description)
+	assert.Equal(t, "100", space.HomepageID)
+	assert.Equal(t, "Operations Home", space.HomepageTitle)
+	assert.Equal(t, []types.SpacePermission{
+		{SubjectType: "group", Subject: "confluence-users", Operations: []string{"read space"}},
+		{SubjectType: "group", Subject: "ops", Operations: []string{"read space", "administer space"}},
+		{SubjectType: "user", Subject: "Ana Lima", Operations: []string{"create page"}},
+		{SubjectType: "anonymous", Operations: []string{"read space"}},
+	}, space.Permissions)
+}
+
+func TestListSpaces_IncludesDescriptions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/space", r.URL.Path)
+		assert.Equal(t, "description.plain,homepage", r.URL.Query().Get("expand"))
+		assert.Equal(t, "archived", r.URL.Query().Get("status"))
+		w.Write([]byte(`{"results": [{"id": 7, "key": "OLD", "name": "Old", "status": "archived",
+			"description": {"plain": {"value": "Retired project"}}}], "size": 1}`))
+	})
+
+	response, err := client.ListSpaces(context.Background(), &types.SpaceListOptions{Status: SpaceArchived})
+	require.NoError(t, err)
+	require.Len(t, response.Spaces, 1)
+	assert.Equal(t, "Retired project", response.Spaces[0].Description)
+	assert.Equal(t, "archived", response.Spaces[0].Status)
+	assert.Empty(t, response.NextCursor)
+}
+
+func TestArchiveSpace_SetsStatus(t *testing.T) {
+	var status string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var payload map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			status = payload["status"]
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(spaceJSON))
+	})
+
+	_, err := client.ArchiveSpace(context.Background(), "OPS", true)
+	require.NoError(t, err)
+	assert.Equal(t, SpaceArchived, status)
+
+	_, err = client.ArchiveSpace(context.Background(), "OPS", false)
+	require.NoError(t, err)
+	assert.Equal(t, SpaceCurrent, status)
+}
+
+func TestUpdateSpace_RequiresChange(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := client.UpdateSpace(context.Background(), "OPS", &types.UpdateSpaceRequest{})
+	assert.EqualError(t, err, "nothing to update: give a name, description or home page")
+}
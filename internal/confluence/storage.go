@@ -0,0 +1,42 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/diff"
+	"regexp"
+)
+
+// storageBreakTags matches the storage-format tags that end a block of content,
+// and the list and table openers whose children belong on their own lines
+const storageBreakTags = `</(?:p|h[1-6]|li|blockquote|pre|tr|td|th|table|tbody|thead|ul|ol|div|ac:structured-macro|ac:layout-section|ac:layout-cell|ac:task|ac:task-list)>|<(?:ul|ol|table|tbody|thead|tr|ac:task-list)(?:\s[^>]*)?>|<br\s*/?>|<hr\s*/?>`
+
+var (
+	// storageBreaks matches a block-ending tag with the newline that may follow it
+	storageBreaks = regexp.MustCompile(`(` + storageBreakTags + `)\n?`)
+
+	// segmentEnd matches where a segment of StorageSegments ends: after a
+	// block-ending tag and its newline, or after any other newline
+	segmentEnd = regexp.MustCompile(`(?:` + storageBreakTags + `)\n?|\n`)
+)
+
+// StorageLines splits storage-format content into one line per block, so that
+// line-based diffs and merges of pages saved as a single line stay readable
+func StorageLines(content string) []string {
+	return diff.SplitLines(storageBreaks.ReplaceAllString(content, "$1\n"))
+}
+
+// StorageSegments splits storage-format content at the same places as
+// StorageLines, but keeps every character, newlines included, so that joining
+// the segments gives the content back unchanged. Merges use it so that code
+// macros and whitespace they do not change are left exactly as they were.
+func StorageSegments(content string) []string {
+	var segments []string
+	start := 0
+	for _, end := range segmentEnd.FindAllStringIndex(content, -1) {
+		segments = append(segments, content[start:end[1]])
+		start = end[1]
+	}
+	if start < len(content) {
+		segments = append(segments, content[start:])
+	}
+	return segments
+}
@@ -0,0 +1,45 @@
+package confluence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageLines(t *testing.T) {
+	content := `<h1>Setup</h1><p>Install <strong>it</strong>.</p><ul><li>One</li><li>Two</li></ul><p>Line<br/>break</p>`
+
+	assert.Equal(t, []string{
+		"<h1>Setup</h1>",
+		"<p>Install <strong>it</strong>.</p>",
+		"<ul>",
+		"<li>One</li>",
+		"<li>Two</li>",
+		"</ul>",
+		"<p>Line<br/>",
+		"break</p>",
+	}, StorageLines(content))
+}
+
+func TestStorageLines_KeepsExistingNewlines(t *testing.T) {
+	assert.Equal(t, []string{"<p>a</p>", "<p>b</p>"}, StorageLines("<p>a</p>\n<p>b</p>\n"))
+	assert.Nil(t, StorageLines(""))
+}
+
+func TestStorageSegments(t *testing.T) {
+	content := "<p>Run:</p>\n<ac:structured-macro ac:name=\"code\"><ac:plain-text-body><![CDATA[if a {\n  b()\n}</p>]]></ac:plain-text-body></ac:structured-macro><p>x<br/>y</p>"
+
+	segments := StorageSegments(content)
+	assert.Equal(t, content, strings.Join(segments, ""), "segments keep every character")
+	assert.Equal(t, []string{
+		"<p>Run:</p>\n",
+		"<ac:structured-macro ac:name=\"code\"><ac:plain-text-body><![CDATA[if a {\n",
+		"  b()\n",
+		"}</p>",
+		"]]></ac:plain-text-body></ac:structured-macro>",
+		"<p>x<br/>",
+		"y</p>",
+	}, segments)
+	assert.Nil(t, StorageSegments(""))
+}
@@ -0,0 +1,152 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// templatesPageSize is the number of templates requested per call
+const templatesPageSize = 100
+
+var (
+	// templateVar matches a variable in a template body, e.g. <at:var at:name="service" />
+	templateVar = regexp.MustCompile(`<at:var\s[^>]*?at:name="([^"]*)"[^>]*?(?:/>|>\s*</at:var>)`)
+
+	// templateDeclarations matches the block declaring a template's variables,
+	// which Confluence uses to build its form and pages must not contain
+	templateDeclarations = regexp.MustCompile(`(?s)<at:declarations>.*?</at:declarations>`)
+)
+
+// templateResource is a content template as the v1 API returns it
+type templateResource struct {
+	TemplateID  string `json:"templateId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Space       *struct {
+		Key string `json:"key"`
+	} `json:"space"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Body *struct {
+		Storage *struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+}
+
+// ListTemplates returns the page templates of a space, or the global templates
+// when spaceKey is empty. Their bodies are left out.
+func (c *AtlassianConfluenceClient) ListTemplates(ctx context.Context, spaceKey string) ([]types.Template, error) {
+	templates := []types.Template{}
+	for startAt := 0; ; startAt += templatesPageSize {
+		query := url.Values{}
+		query.Set("start", strconv.Itoa(startAt))
+		query.Set("limit", strconv.Itoa(templatesPageSize))
+		if spaceKey != "" {
+			query.Set("spaceKey", spaceKey)
+		}
+		request, err := c.client.NewRequest(ctx, http.MethodGet, "wiki/rest/api/template/page?"+query.Encode(), "", nil)
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+		}
+		var result struct {
+			Results []*templateResource `json:"results"`
+			Size    int                 `json:"size"`
+		}
+		response, err := c.client.Call(request, &result)
+		if err != nil {
+			if spaceKey == "" {
+				return nil, clierr.FromResponse(response, err, "failed to list global templates")
+			}
+			return nil, clierr.FromResponse(response, err, "failed to list templates of space %s", spaceKey)
+		}
+		for _, resource := range result.Results {
+			templates = append(templates, convertTemplate(resource))
+		}
+		if result.Size < templatesPageSize {
+			return templates, nil
+		}
+	}
+}
+
+// GetTemplate retrieves a template by ID with its body
+func (c *AtlassianConfluenceClient) GetTemplate(ctx context.Context, id string) (*types.Template, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "template ID is required")
+	}
+
+	endpoint := fmt.Sprintf("wiki/rest/api/template/%s?expand=body", url.PathEscape(id))
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+	resource := new(templateResource)
+	response, err := c.client.Call(request, resource)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get template %s", id)
+	}
+	template := convertTemplate(resource)
+	return &template, nil
+}
+
+// convertTemplate converts a v1 template to our Template type
+func convertTemplate(resource *templateResource) types.Template {
+	template := types.Template{
+		ID:          resource.TemplateID,
+		Name:        resource.Name,
+		Description: resource.Description,
+	}
+	if resource.Space != nil {
+		template.SpaceKey = resource.Space.Key
+	}
+	for _, label := range resource.Labels {
+		template.Labels = append(template.Labels, label.Name)
+	}
+	if resource.Body != nil && resource.Body.Storage != nil {
+		template.Content = resource.Body.Storage.Value
+		template.Variables = TemplateVariables(template.Content)
+	}
+	return template
+}
+
+// TemplateVariables returns the names of the variables in a template body, in
+// the order they first appear
+func TemplateVariables(body string) []string {
+	var names []string
+	for _, match := range templateVar.FindAllStringSubmatch(body, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// FillTemplate replaces the variables of a template body with values, escaped
+// for storage format, and drops the variable declarations. Every variable must
+// be given a value.
+func FillTemplate(body string, values map[string]string) (string, error) {
+	var missing []string
+	for _, name := range TemplateVariables(body) {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", clierr.New(clierr.KindValidation, "template variables without a value: %s", strings.Join(missing, ", "))
+	}
+
+	body = templateDeclarations.ReplaceAllString(body, "")
+	return templateVar.ReplaceAllStringFunc(body, func(variable string) string {
+		return html.EscapeString(values[templateVar.FindStringSubmatch(variable)[1]])
+	}), nil
+}
@@ -0,0 +1,58 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const postmortemTemplate = `<at:declarations><at:string at:name="service" /><at:textarea at:name="summary" at:rows="5" /></at:declarations>` +
+	`<h1>Postmortem: <at:var at:name="service" /></h1><p><at:var at:name="summary" at:rawxhtml="true"></at:var></p><p>Owner: <at:var at:name="service" /></p>`
+
+func TestTemplateVariables(t *testing.T) {
+	assert.Equal(t, []string{"service", "summary"}, TemplateVariables(postmortemTemplate))
+	assert.Empty(t, TemplateVariables("<p>No variables</p>"))
+}
+
+func TestFillTemplate(t *testing.T) {
+	body, err := FillTemplate(postmortemTemplate, map[string]string{"service": "checkout", "summary": "Carts & payments failed"})
+	require.NoError(t, err)
+	assert.Equal(t, `<h1>Postmortem: checkout</h1><p>Carts &amp; payments failed</p><p>Owner: checkout</p>`, body)
+
+	_, err = FillTemplate(postmortemTemplate, map[string]string{"service": "checkout"})
+	assert.EqualError(t, err, "template variables without a value: summary")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestListTemplates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/template/page", r.URL.Path)
+		assert.Equal(t, "ENG", r.URL.Query().Get("spaceKey"))
+		w.Write([]byte(`{"results": [{"templateId": "98310", "name": "Postmortem", "description": "After an incident",
+			"space": {"key": "ENG"}, "labels": [{"name": "incident"}]}], "size": 1}`))
+	})
+
+	templates, err := client.ListTemplates(context.Background(), "ENG")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "98310", templates[0].ID)
+	assert.Equal(t, "ENG", templates[0].SpaceKey)
+	assert.Equal(t, []string{"incident"}, templates[0].Labels)
+}
+
+func TestGetTemplate(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/template/98310", r.URL.Path)
+		w.Write([]byte(`{"templateId": "98310", "name": "Postmortem",
+			"body": {"storage": {"value": "<p><at:var at:name=\"service\" /></p>", "representation": "storage"}}}`))
+	})
+
+	tmpl, err := client.GetTemplate(context.Background(), "98310")
+	require.NoError(t, err)
+	assert.Empty(t, tmpl.SpaceKey)
+	assert.Equal(t, []string{"service"}, tmpl.Variables)
+}
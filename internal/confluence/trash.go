@@ -0,0 +1,191 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// descendantsPageSize is the number of descendants requested per call
+const descendantsPageSize = 100
+
+// DeletePage moves a page to the space trash. Its children move up to its parent.
+func (c *AtlassianConfluenceClient) DeletePage(ctx context.Context, id string) error {
+	if id == "" {
+		return clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	response, err := c.client.Content.Delete(ctx, id, "")
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to delete page %s", id)
+	}
+	return nil
+}
+
+// ListDescendants returns every page below a page, parents before their children
+func (c *AtlassianConfluenceClient) ListDescendants(ctx context.Context, id string) ([]types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	var contents []*models.ContentScheme
+	for startAt := 0; ; startAt += descendantsPageSize {
+		result, response, err := c.client.Content.ChildrenDescendant.DescendantsByType(ctx, id, "page", "all",
+			[]string{"ancestors", "version", "space"}, startAt, descendantsPageSize)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list descendants of page %s", id)
+		}
+		contents = append(contents, result.Results...)
+		if result.Size < descendantsPageSize {
+			break
+		}
+	}
+
+	// The API does not promise an order, so sort by depth to keep parents first
+	sort.SliceStable(contents, func(i, j int) bool {
+		return len(contents[i].Ancestors) < len(contents[j].Ancestors)
+	})
+
+	pages := make([]types.Page, 0, len(contents))
+	for _, content := range contents {
+		pages = append(pages, *convertContentSchemeToPage(content))
+	}
+	return pages, nil
+}
+
+// ListTrash lists the trashed pages of a space
+func (c *AtlassianConfluenceClient) ListTrash(ctx context.Context, opts *types.PageListOptions) (*types.PageListResponse, error) {
+	if opts == nil || opts.SpaceKey == "" {
+		return nil, clierr.New(clierr.KindValidation, "space key is required")
+	}
+
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = 25
+	}
+
+	startAt := opts.StartAt
+	if startAt < 0 {
+		startAt = 0
+	}
+	if opts.Cursor != "" {
+		offset, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		startAt = offset
+	}
+
+	options := &models.GetContentOptionsScheme{
+		ContextType: "page",
+		SpaceKey:    opts.SpaceKey,
+		Title:       opts.Title,
+		Status:      []string{"trashed"},
+		Expand:      []string{"version", "space"},
+	}
+
+	result, response, err := c.client.Content.Gets(ctx, options, startAt, maxResults)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to list trash")
+	}
+
+	pages := make([]types.Page, 0, len(result.Results))
+	for _, content := range result.Results {
+		pages = append(pages, *convertContentSchemeToPage(content))
+	}
+
+	var nextCursor string
+	total := startAt + result.Size
+	if result.Size == maxResults {
+		nextCursor = strconv.Itoa(startAt + maxResults)
+		total++ // At least one more
+	}
+
+	return &types.PageListResponse{
+		Pages:      pages,
+		Total:      total,
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetTrashedPage retrieves a page that is in the trash
+func (c *AtlassianConfluenceClient) GetTrashedPage(ctx context.Context, id string) (*types.Page, error) {
+	content, err := c.getTrashedContent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return convertContentSchemeToPage(content), nil
+}
+
+// RestorePage moves a trashed page back into its space
+func (c *AtlassianConfluenceClient) RestorePage(ctx context.Context, id string) (*types.Page, error) {
+	trashed, err := c.getTrashedContent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restoring is an update to status "current" with the next version number
+	version := 1
+	if trashed.Version != nil {
+		version = trashed.Version.Number + 1
+	}
+	payload := &models.ContentScheme{
+		ID:      id,
+		Type:    trashed.Type,
+		Title:   trashed.Title,
+		Status:  "current",
+		Version: &models.ContentVersionScheme{Number: version},
+	}
+
+	result, response, err := c.client.Content.Update(ctx, id, payload)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to restore page %s", id)
+	}
+	return convertContentSchemeToPage(result), nil
+}
+
+// PurgePage permanently deletes a trashed page
+func (c *AtlassianConfluenceClient) PurgePage(ctx context.Context, id string) error {
+	if id == "" {
+		return clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	response, err := c.client.Content.Delete(ctx, id, "trashed")
+	if err != nil {
+		return clierr.FromResponse(response, err, "failed to purge page %s", id)
+	}
+	return nil
+}
+
+// getTrashedContent fetches trashed content, which Content.Get cannot request
+func (c *AtlassianConfluenceClient) getTrashedContent(ctx context.Context, id string) (*models.ContentScheme, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	query := url.Values{}
+	query.Set("status", "trashed")
+	query.Set("expand", "version,space")
+	endpoint := fmt.Sprintf("wiki/rest/api/content/%s?%s", url.PathEscape(id), query.Encode())
+
+	request, err := c.client.NewRequest(ctx, http.MethodGet, endpoint, "", nil)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "failed to build request")
+	}
+
+	content := new(models.ContentScheme)
+	response, err := c.client.Call(request, content)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get trashed page %s", id)
+	}
+	return content, nil
+}
@@ -0,0 +1,92 @@
+package confluence
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/types"
+	"context"
+	"time"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// versionsPageSize is the number of versions requested per call
+const versionsPageSize = 100
+
+// ListVersions returns the versions of a page, newest first
+func (c *AtlassianConfluenceClient) ListVersions(ctx context.Context, id string) ([]types.PageVersion, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+
+	var versions []types.PageVersion
+	for startAt := 0; ; startAt += versionsPageSize {
+		result, response, err := c.client.Content.Version.Gets(ctx, id, nil, startAt, versionsPageSize)
+		if err != nil {
+			return nil, clierr.FromResponse(response, err, "failed to list versions of page %s", id)
+		}
+		for _, version := range result.Results {
+			versions = append(versions, convertVersion(version))
+		}
+		if result.Size < versionsPageSize {
+			return versions, nil
+		}
+	}
+}
+
+// GetPageVersion retrieves a page as it was at the given version
+func (c *AtlassianConfluenceClient) GetPageVersion(ctx context.Context, id string, version int) (*types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if version < 1 {
+		return nil, clierr.New(clierr.KindValidation, "version must be at least 1")
+	}
+
+	result, response, err := c.client.Content.Get(ctx, id, []string{"body.storage", "version", "space"}, version)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to get version %d of page %s", version, id)
+	}
+	return convertContentSchemeToPage(result), nil
+}
+
+// RestoreVersion makes the content and title of an earlier version the page's
+// current version, recording message as the version comment
+func (c *AtlassianConfluenceClient) RestoreVersion(ctx context.Context, id string, version int, message string) (*types.Page, error) {
+	if id == "" {
+		return nil, clierr.New(clierr.KindValidation, "page ID is required")
+	}
+	if version < 1 {
+		return nil, clierr.New(clierr.KindValidation, "version must be at least 1")
+	}
+
+	payload := &models.ContentRestorePayloadScheme{
+		OperationKey: "restore",
+		Params: &models.ContentRestoreParamsPayloadScheme{
+			VersionNumber: version,
+			Message:       message,
+			RestoreTitle:  true,
+		},
+	}
+
+	_, response, err := c.client.Content.Version.Restore(ctx, id, payload, nil)
+	if err != nil {
+		return nil, clierr.FromResponse(response, err, "failed to restore version %d of page %s", version, id)
+	}
+	return c.GetPage(ctx, id)
+}
+
+// convertVersion converts a go-atlassian content version to our PageVersion type
+func convertVersion(scheme *models.ContentVersionScheme) types.PageVersion {
+	version := types.PageVersion{
+		Number:    scheme.Number,
+		Message:   scheme.Message,
+		MinorEdit: scheme.MinorEdit,
+	}
+	if scheme.By != nil {
+		version.Author = scheme.By.DisplayName
+	}
+	if t, err := time.Parse(time.RFC3339, scheme.When); err == nil {
+		version.When = t
+	}
+	return version
+}
@@ -0,0 +1,289 @@
+// Package diff compares texts line by line and renders the differences as unified diffs
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of a line edit
+type Op int
+
+const (
+	Equal  Op = iota // The line is in both texts
+	Delete           // The line is only in the old text
+	Insert           // The line is only in the new text
+)
+
+// Edit is one line of a line-by-line comparison
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+// SplitLines splits text into lines without their line endings. A trailing newline
+// does not start an extra empty line.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Lines returns the shortest sequence of edits that turns a into b, using the
+// linear-space variant of Myers' algorithm, so memory stays proportional to the
+// length of the texts however much they differ. Deletions come before
+// insertions within each changed block.
+func Lines(a, b []string) []Edit {
+	edits := make([]Edit, 0, len(a)+len(b))
+	edits = compare(edits, a, b)
+
+	// Order each changed block as deletions then insertions
+	for start := 0; start < len(edits); {
+		if edits[start].Op == Equal {
+			start++
+			continue
+		}
+		end := start
+		for end < len(edits) && edits[end].Op != Equal {
+			end++
+		}
+		block := append([]Edit(nil), edits[start:end]...)
+		i := start
+		for _, op := range []Op{Delete, Insert} {
+			for _, edit := range block {
+				if edit.Op == op {
+					edits[i] = edit
+					i++
+				}
+			}
+		}
+		start = end
+	}
+	return edits
+}
+
+// compare appends the edits that turn a into b. Lines shared at the start and
+// end need no search; the rest is split at the middle of a shortest edit path
+// and each side compared in turn.
+func compare(edits []Edit, a, b []string) []Edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Equal, line})
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	switch {
+	case len(middleA) == 0:
+		for _, line := range middleB {
+			edits = append(edits, Edit{Insert, line})
+		}
+	case len(middleB) == 0:
+		for _, line := range middleA {
+			edits = append(edits, Edit{Delete, line})
+		}
+	default:
+		if x, y, ok := middleSnake(middleA, middleB); ok {
+			edits = compare(edits, middleA[:x], middleB[:y])
+			edits = compare(edits, middleA[x:], middleB[y:])
+		} else {
+			for _, line := range middleA {
+				edits = append(edits, Edit{Delete, line})
+			}
+			for _, line := range middleB {
+				edits = append(edits, Edit{Insert, line})
+			}
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Equal, line})
+	}
+	return edits
+}
+
+// middleSnake searches for a shortest edit path from both ends of a and b at
+// once, keeping only the furthest point reached on each diagonal, and returns
+// the point where the two searches meet. It reports false when a and b have no
+// line in common.
+func middleSnake(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	forward := make([]int, 2*maxD+2)
+	reverse := make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], reverse[i] = -1, -1
+	}
+	forward[offset+1], reverse[offset+1] = 0, 0
+
+	delta := n - m
+	// With an odd delta the forward search is the one to find the overlap
+	front := delta%2 != 0
+	// Diagonals that ran off the edge of the grid need no further search
+	kStart, kEnd, rStart, rEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + kStart; k <= d-kEnd; k += 2 {
+			i := offset + k
+			var x1 int
+			if k == -d || (k != d && forward[i-1] < forward[i+1]) {
+				x1 = forward[i+1]
+			} else {
+				x1 = forward[i-1] + 1
+			}
+			y1 := x1 - k
+			for x1 < n && y1 < m && a[x1] == b[y1] {
+				x1++
+				y1++
+			}
+			forward[i] = x1
+			switch {
+			case x1 > n:
+				kEnd += 2
+			case y1 > m:
+				kStart += 2
+			case front:
+				j := offset + delta - k
+				if j >= 0 && j < len(reverse) && reverse[j] != -1 && x1 >= n-reverse[j] {
+					return x1, y1, true
+				}
+			}
+		}
+
+		for k := -d + rStart; k <= d-rEnd; k += 2 {
+			i := offset + k
+			var x2 int
+			if k == -d || (k != d && reverse[i-1] < reverse[i+1]) {
+				x2 = reverse[i+1]
+			} else {
+				x2 = reverse[i-1] + 1
+			}
+			y2 := x2 - k
+			for x2 < n && y2 < m && a[n-x2-1] == b[m-y2-1] {
+				x2++
+				y2++
+			}
+			reverse[i] = x2
+			switch {
+			case x2 > n:
+				rEnd += 2
+			case y2 > m:
+				rStart += 2
+			case !front:
+				j := offset + delta - k
+				if j >= 0 && j < len(forward) && forward[j] != -1 {
+					x1 := forward[j]
+					y1 := offset + x1 - j
+					if x1 >= n-x2 {
+						return x1, y1, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Unified renders the edits from Lines as a unified diff with the given number of
+// context lines around each change. It returns "" when the texts are equal.
+func Unified(edits []Edit, fromName, toName string, context int) string {
+	var sb strings.Builder
+	for _, h := range hunks(edits, context) {
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h.fromLine, h.fromCount), hunkRange(h.toLine, h.toCount))
+		for _, edit := range h.edits {
+			sb.WriteString(edit.String())
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// String formats the edit as a unified diff line
+func (e Edit) String() string {
+	switch e.Op {
+	case Delete:
+		return "-" + e.Line
+	case Insert:
+		return "+" + e.Line
+	default:
+		return " " + e.Line
+	}
+}
+
+// hunk is a run of edits with the line numbers (1-based) where it starts in each text
+type hunk struct {
+	fromLine, fromCount int
+	toLine, toCount     int
+	edits               []Edit
+}
+
+// hunks groups changes that are within 2*context lines of each other, keeping up
+// to context unchanged lines on either side
+func hunks(edits []Edit, context int) []hunk {
+	// Line numbers where each edit falls in the old and new text
+	fromLines := make([]int, len(edits))
+	toLines := make([]int, len(edits))
+	fromLine, toLine := 1, 1
+	for i, edit := range edits {
+		fromLines[i], toLines[i] = fromLine, toLine
+		if edit.Op != Insert {
+			fromLine++
+		}
+		if edit.Op != Delete {
+			toLine++
+		}
+	}
+
+	var result []hunk
+	end := -1
+	for i, edit := range edits {
+		if edit.Op == Equal {
+			continue
+		}
+		start := max(i-context, 0)
+		if len(result) == 0 || start > end {
+			result = append(result, hunk{fromLine: fromLines[start], toLine: toLines[start]})
+		} else {
+			start = end
+		}
+		current := &result[len(result)-1]
+		end = min(i+context+1, len(edits))
+		for _, e := range edits[start:end] {
+			current.add(e)
+		}
+	}
+	return result
+}
+
+// add appends an edit and counts it toward the hunk's line ranges
+func (h *hunk) add(e Edit) {
+	h.edits = append(h.edits, e)
+	if e.Op != Insert {
+		h.fromCount++
+	}
+	if e.Op != Delete {
+		h.toCount++
+	}
+}
+
+// hunkRange formats a unified diff line range; empty ranges point at the line before
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
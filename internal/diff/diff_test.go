@@ -0,0 +1,189 @@
+package diff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// apply rebuilds both texts from edits
+func apply(edits []Edit) (from, to []string) {
+	for _, e := range edits {
+		if e.Op != Insert {
+			from = append(from, e.Line)
+		}
+		if e.Op != Delete {
+			to = append(to, e.Line)
+		}
+	}
+	return from, to
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Nil(t, SplitLines(""))
+	assert.Equal(t, []string{"a", "b"}, SplitLines("a\nb\n"))
+	assert.Equal(t, []string{"a", "", "b"}, SplitLines("a\r\n\r\nb"))
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		changes int
+	}{
+		{"equal", "a b c", "a b c", 0},
+		{"empty to text", "", "a b", 2},
+		{"text to empty", "a b", "", 2},
+		{"replace middle", "a b c", "a x c", 2},
+		{"insert", "a c", "a b c", 1},
+		{"classic", "a b c a b b a", "c b a b a c", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Fields(tt.a), strings.Fields(tt.b)
+			edits := Lines(a, b)
+
+			from, to := apply(edits)
+			assert.Equal(t, len(a), len(from))
+			assert.Equal(t, strings.Join(a, " "), strings.Join(from, " "))
+			assert.Equal(t, strings.Join(b, " "), strings.Join(to, " "))
+
+			changes := 0
+			for _, e := range edits {
+				if e.Op != Equal {
+					changes++
+				}
+			}
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
+
+// lcsChanges returns the number of edits in a shortest edit script, from the
+// longest common subsequence
+func lcsChanges(a, b []string) int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return len(a) + len(b) - 2*lcs[0][0]
+}
+
+func TestLines_Shortest(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	text := func() []string {
+		lines := make([]string, random.Intn(12))
+		for i := range lines {
+			lines[i] = string(rune('a' + random.Intn(4)))
+		}
+		return lines
+	}
+
+	for range 500 {
+		a, b := text(), text()
+		edits := Lines(a, b)
+
+		from, to := apply(edits)
+		assert.Equal(t, strings.Join(a, ""), strings.Join(from, ""))
+		assert.Equal(t, strings.Join(b, ""), strings.Join(to, ""))
+
+		changes := 0
+		for _, e := range edits {
+			if e.Op != Equal {
+				changes++
+			}
+		}
+		assert.Equal(t, lcsChanges(a, b), changes, "%v -> %v", a, b)
+	}
+}
+
+func TestLines_LargeRewrite(t *testing.T) {
+	// A full rewrite of a large text is the worst case for memory
+	a := make([]string, 4000)
+	b := make([]string, 4000)
+	for i := range a {
+		a[i] = fmt.Sprintf("old %d", i)
+		b[i] = fmt.Sprintf("new %d", i)
+	}
+	b[2000] = a[1000]
+
+	edits := Lines(a, b)
+	from, to := apply(edits)
+	assert.Equal(t, a, from)
+	assert.Equal(t, b, to)
+}
+
+func TestLines_DeletesBeforeInserts(t *testing.T) {
+	edits := Lines([]string{"a", "b", "c"}, []string{"a", "x", "c"})
+	assert.Equal(t, []Edit{{Equal, "a"}, {Delete, "b"}, {Insert, "x"}, {Equal, "c"}}, edits)
+}
+
+func TestUnified(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		line := string(rune('a' + i - 1))
+		a = append(a, line)
+		switch i {
+		case 3:
+			b = append(b, "C")
+		case 15:
+			// Dropped; the unchanged lines after it are too many to join the next hunk
+		default:
+			b = append(b, line)
+		}
+	}
+	b = append(b, "end")
+
+	got := Unified(Lines(a, b), "version 1", "version 2", 2)
+	want := `--- version 1
++++ version 2
+@@ -1,5 +1,5 @@
+ a
+ b
+-c
++C
+ d
+ e
+@@ -13,5 +13,4 @@
+ m
+ n
+-o
+ p
+ q
+@@ -19,2 +18,3 @@
+ s
+ t
++end
+`
+	assert.Equal(t, want, got)
+}
+
+func TestUnified_Equal(t *testing.T) {
+	assert.Empty(t, Unified(Lines([]string{"a"}, []string{"a"}), "a", "b", 3))
+}
+
+func TestUnified_EmptyRange(t *testing.T) {
+	got := Unified(Lines(nil, []string{"new"}), "old", "new", 3)
+	assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1 @@\n+new\n", got)
+}
+
+func TestUnified_JoinsNearbyChanges(t *testing.T) {
+	a := strings.Fields("a b c d e f g")
+	b := strings.Fields("a B c d e F g")
+
+	got := Unified(Lines(a, b), "old", "new", 2)
+	assert.Equal(t, "--- old\n+++ new\n@@ -1,7 +1,7 @@\n a\n-b\n+B\n c\n d\n e\n-f\n+F\n g\n", got)
+}
@@ -0,0 +1,93 @@
+package diff
+
+import "slices"
+
+// Conflict markers written around the two sides of a conflicting change
+const (
+	markerOurs   = "<<<<<<< "
+	markerSep    = "======="
+	markerTheirs = ">>>>>>> "
+)
+
+// MergeResult is the outcome of a three-way merge
+type MergeResult struct {
+	Lines     []string
+	Conflicts int // Number of changes made differently on both sides
+}
+
+// Merge combines the changes made to base in ours and in theirs (diff3). Changes to
+// different parts of base are both kept; where both sides changed the same lines
+// differently, the result holds both versions between conflict markers labelled
+// with oursName and theirsName.
+func Merge(base, ours, theirs []string, oursName, theirsName string) MergeResult {
+	matchOurs := matches(base, ours)
+	matchTheirs := matches(base, theirs)
+
+	var result MergeResult
+	o, a, b := 0, 0, 0
+	for o < len(base) || a < len(ours) || b < len(theirs) {
+		// Copy lines that are unchanged on both sides
+		stable := 0
+		for o+stable < len(base) && matchOurs[o+stable] == a+stable && matchTheirs[o+stable] == b+stable {
+			stable++
+		}
+		if stable > 0 {
+			result.Lines = append(result.Lines, base[o:o+stable]...)
+			o, a, b = o+stable, a+stable, b+stable
+			continue
+		}
+
+		// The changed region ends at the next base line both sides kept
+		next := o
+		for next < len(base) && (matchOurs[next] < 0 || matchTheirs[next] < 0) {
+			next++
+		}
+		endA, endB := len(ours), len(theirs)
+		if next < len(base) {
+			endA, endB = matchOurs[next], matchTheirs[next]
+		}
+
+		result.resolve(base[o:next], ours[a:endA], theirs[b:endB], oursName, theirsName)
+		o, a, b = next, endA, endB
+	}
+	return result
+}
+
+// resolve adds the outcome of one changed region: the side that changed it, or
+// both sides between conflict markers when they changed it differently
+func (r *MergeResult) resolve(base, ours, theirs []string, oursName, theirsName string) {
+	switch {
+	case slices.Equal(ours, base), slices.Equal(ours, theirs):
+		r.Lines = append(r.Lines, theirs...)
+	case slices.Equal(theirs, base):
+		r.Lines = append(r.Lines, ours...)
+	default:
+		r.Conflicts++
+		r.Lines = append(r.Lines, markerOurs+oursName)
+		r.Lines = append(r.Lines, ours...)
+		r.Lines = append(r.Lines, markerSep)
+		r.Lines = append(r.Lines, theirs...)
+		r.Lines = append(r.Lines, markerTheirs+theirsName)
+	}
+}
+
+// matches maps each line of base to the index of the same line in other, or -1
+// when the line was deleted or changed
+func matches(base, other []string) []int {
+	result := make([]int, len(base))
+	o, i := 0, 0
+	for _, edit := range Lines(base, other) {
+		switch edit.Op {
+		case Equal:
+			result[o] = i
+			o++
+			i++
+		case Delete:
+			result[o] = -1
+			o++
+		case Insert:
+			i++
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts int
+	}{
+		{"no changes", "a b c", "a b c", "a b c", "a b c", 0},
+		{"only ours", "a b c", "a B c", "a b c", "a B c", 0},
+		{"only theirs", "a b c", "a b c", "a b C", "a b C", 0},
+		{"separate edits", "a b c d e", "A b c d e", "a b c d E", "A b c d E", 0},
+		{"same edit", "a b c", "a X c", "a X c", "a X c", 0},
+		{"insert and delete", "a b c d", "a b x c d", "a b c", "a b x c", 0},
+		{"both append", "a", "a b", "a", "a b", 0},
+		{"empty base", "", "a", "", "a", 0},
+		{
+			"conflict", "a b c", "a X c", "a Y c",
+			"a <<<<<<<_current X ======= Y >>>>>>>_yours c", 1,
+		},
+		{
+			"conflict and clean edit", "a b c d e", "a X c d e", "a Y c d E",
+			"a <<<<<<<_current X ======= Y >>>>>>>_yours c d E", 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Merge(strings.Fields(tt.base), strings.Fields(tt.ours), strings.Fields(tt.theirs), "current", "yours")
+
+			// Markers contain a space, so join with one and compare against underscores
+			got := strings.ReplaceAll(strings.Join(result.Lines, " "), "< current", "<_current")
+			got = strings.ReplaceAll(got, "> yours", ">_yours")
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.conflicts, result.Conflicts)
+		})
+	}
+}
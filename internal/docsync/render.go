@@ -0,0 +1,127 @@
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/markdown"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// childrenMacro is the content of directory pages without an index file
+const childrenMacro = `<ac:structured-macro ac:name="children" />`
+
+var (
+	storageLink   = regexp.MustCompile(`<a href="([^"]*)">(.*?)</a>`)
+	markdownImage = regexp.MustCompile(`!\[[^\]]*\]\(\s*(?:<([^>\n]*)>|([^)\s]+))`)
+	externalURL   = regexp.MustCompile(`^(?:[a-zA-Z][a-zA-Z0-9+.-]*:|//)`)
+)
+
+// attachment is a local image a page references
+type attachment struct {
+	Name string // Attachment file name
+	File string // Path relative to the synced directory
+	Hash string
+}
+
+// render converts a doc to storage format, turning links to other synced files
+// into page links, and returns the images it references
+func render(dir string, doc *Doc, docs map[string]*Doc) (string, []attachment, error) {
+	if doc.File == "" {
+		return childrenMacro, nil, nil
+	}
+
+	storage := markdown.ToStorage(doc.Markdown)
+	storage = storageLink.ReplaceAllStringFunc(storage, func(link string) string {
+		m := storageLink.FindStringSubmatch(link)
+		target, anchor, ok := resolveLink(doc, html.UnescapeString(m[1]), docs)
+		if !ok {
+			return link
+		}
+		anchorAttr := ""
+		if anchor != "" {
+			anchorAttr = fmt.Sprintf(` ac:anchor="%s"`, html.EscapeString(anchor))
+		}
+		return fmt.Sprintf(`<ac:link%s><ri:page ri:content-title="%s" /><ac:link-body>%s</ac:link-body></ac:link>`,
+			anchorAttr, html.EscapeString(target.Title), m[2])
+	})
+
+	attachments, err := images(dir, doc, storage)
+	if err != nil {
+		return "", nil, err
+	}
+	return storage, attachments, nil
+}
+
+// resolveLink finds the doc a relative link points to, with the link's fragment
+func resolveLink(from *Doc, href string, docs map[string]*Doc) (*Doc, string, bool) {
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "/") || externalURL.MatchString(href) {
+		return nil, "", false
+	}
+	target, anchor, _ := strings.Cut(href, "#")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	doc, ok := docs[path.Join(path.Dir(from.File), target)]
+	return doc, anchor, ok
+}
+
+// images returns the local image files a doc shows. Confluence names
+// attachments by file name alone, so two different files with the same name
+// cannot be shown on one page.
+func images(dir string, doc *Doc, storage string) ([]attachment, error) {
+	var attachments []attachment
+	files := map[string]string{}
+	for _, m := range markdownImage.FindAllStringSubmatch(doc.Markdown, -1) {
+		dest := m[1] + m[2]
+		if externalURL.MatchString(dest) {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(dest); err == nil {
+			dest = unescaped
+		}
+		name := path.Base(dest)
+		// Image syntax in code is not an image
+		if !strings.Contains(storage, fmt.Sprintf(`ri:filename="%s"`, html.EscapeString(name))) {
+			continue
+		}
+
+		// Images are read from the synced directory only
+		file := path.Clean(path.Join(path.Dir(doc.File), dest))
+		if path.IsAbs(dest) || filepath.IsAbs(dest) || file == ".." || strings.HasPrefix(file, "../") {
+			return nil, clierr.New(clierr.KindValidation, "%s: image %s is outside the synced directory", doc.File, dest)
+		}
+		if other, ok := files[name]; ok {
+			if other != file {
+				return nil, clierr.New(clierr.KindValidation, "%s shows two images named %s (%s and %s)", doc.File, name, other, file)
+			}
+			continue
+		}
+		files[name] = file
+
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindValidation, err, "%s: cannot read image %s", doc.File, dest)
+		}
+		attachments = append(attachments, attachment{Name: name, File: file, Hash: hash(string(data))})
+	}
+	return attachments, nil
+}
+
+// hash returns the hex SHA-256 of the parts, separated so that moving text
+// between parts changes the hash
+func hash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,232 @@
+// Package docsync publishes a directory of Markdown files to a Confluence page tree.
+//
+// Each Markdown file becomes a page, and each directory a page whose children are
+// the directory's files and subdirectories. A directory's index.md, _index.md or
+// README.md supplies the directory page's content; without one the page lists its
+// children. The top directory maps to an existing root page.
+//
+// Pages are matched to files by a page ID in the file's front matter or by a
+// state file written after each sync, which also records content hashes so that
+// unchanged pages are not updated again.
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// indexFiles are the file names that supply a directory page's content, in order of preference
+var indexFiles = []string{"index.md", "_index.md", "README.md"}
+
+var (
+	frontMatterDelimiter = regexp.MustCompile(`^(?:---|\.\.\.)\s*$`)
+	titleHeading         = regexp.MustCompile(`^ {0,3}#[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+)
+
+// Doc is a Markdown file or directory that maps to a page
+type Doc struct {
+	Path     string // Slash-separated path relative to the synced directory: the file, or the directory for directory pages ("." for the top)
+	File     string // Markdown file with the page's content; "" for a directory without an index file
+	Title    string
+	PageID   string // Page ID from front matter
+	Markdown string // Content without front matter or title heading
+	Children []*Doc
+
+	dir        bool
+	titleFixed bool // Title came from front matter
+}
+
+// IsDir reports whether the doc is a directory page
+func (d *Doc) IsDir() bool {
+	return d.dir
+}
+
+// frontMatter holds the front-matter fields sync reads
+type frontMatter struct {
+	Title  string `yaml:"title"`
+	PageID string `yaml:"confluence_page_id"`
+}
+
+// Scan reads the Markdown files under dir into a tree of docs. Hidden files and
+// directories, and directories without Markdown files, are skipped.
+func Scan(dir string) (*Doc, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "cannot read %s", dir)
+	}
+	if !info.IsDir() {
+		return nil, clierr.New(clierr.KindValidation, "%s is not a directory", dir)
+	}
+
+	root, err := scanDir(dir, ".")
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, clierr.New(clierr.KindValidation, "no Markdown files found in %s", dir)
+	}
+	return root, checkTitles(root)
+}
+
+// scanDir reads the directory at rel under base, returning nil when it has no Markdown files
+func scanDir(base, rel string) (*Doc, error) {
+	entries, err := os.ReadDir(filepath.Join(base, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "cannot read directory %s", rel)
+	}
+
+	doc := &Doc{Path: rel, Title: titleFromName(path.Base(rel)), dir: true}
+	index := ""
+	for _, name := range indexFiles {
+		for _, entry := range entries {
+			if index == "" && !entry.IsDir() && strings.EqualFold(entry.Name(), name) {
+				index = entry.Name()
+			}
+		}
+	}
+	if index != "" {
+		if err := readDoc(doc, base, path.Join(rel, index)); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || name == index {
+			continue
+		}
+		childPath := path.Join(rel, name)
+
+		if entry.IsDir() {
+			child, err := scanDir(base, childPath)
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				doc.Children = append(doc.Children, child)
+			}
+			continue
+		}
+		if !strings.EqualFold(path.Ext(name), ".md") {
+			continue
+		}
+
+		child := &Doc{Path: childPath, Title: titleFromName(strings.TrimSuffix(name, path.Ext(name)))}
+		if err := readDoc(child, base, childPath); err != nil {
+			return nil, err
+		}
+		doc.Children = append(doc.Children, child)
+	}
+
+	if index == "" && len(doc.Children) == 0 {
+		return nil, nil
+	}
+	return doc, nil
+}
+
+// readDoc reads a Markdown file into doc, taking the title from front matter or
+// a leading "# Title" heading
+func readDoc(doc *Doc, base, file string) error {
+	doc.File = file
+	data, err := os.ReadFile(filepath.Join(base, filepath.FromSlash(file)))
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot read %s", file)
+	}
+	content := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\ufeff"))), "\r\n", "\n")
+
+	matter, content, err := splitFrontMatter(content)
+	if err != nil {
+		return clierr.Wrap(clierr.KindValidation, err, "invalid front matter in %s", file)
+	}
+	doc.PageID = strings.TrimSpace(matter.PageID)
+
+	if matter.Title != "" {
+		doc.Title = matter.Title
+		doc.titleFixed = true
+	} else if title, rest, ok := splitTitleHeading(content); ok {
+		doc.Title = title
+		content = rest
+	}
+	doc.Markdown = content
+	return nil
+}
+
+// splitFrontMatter separates YAML front matter, between "---" lines at the start
+// of content, from the rest of the file
+func splitFrontMatter(content string) (frontMatter, string, error) {
+	var matter frontMatter
+	if !strings.HasPrefix(content, "---\n") {
+		return matter, content, nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if frontMatterDelimiter.MatchString(lines[i]) {
+			if err := yaml.Unmarshal([]byte(strings.Join(lines[1:i], "")), &matter); err != nil {
+				return matter, "", err
+			}
+			return matter, strings.Join(lines[i+1:], ""), nil
+		}
+	}
+	// No closing delimiter: the "---" is a thematic break, not front matter
+	return matter, content, nil
+}
+
+// splitTitleHeading returns the text of a level-one heading on the first
+// non-blank line and the content after it
+func splitTitleHeading(content string) (string, string, bool) {
+	trimmed := strings.TrimLeft(content, "\n")
+	line, rest, _ := strings.Cut(trimmed, "\n")
+	m := titleHeading.FindStringSubmatch(line)
+	if m == nil || m[1] == "" {
+		return "", content, false
+	}
+	return m[1], rest, true
+}
+
+// titleFromName turns a file or directory name such as "getting-started" into a
+// title such as "Getting started"
+func titleFromName(name string) string {
+	title := strings.TrimSpace(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	if title == "" || title == "." {
+		return name
+	}
+	first, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// checkTitles rejects trees where two docs would get the same title, which
+// Confluence does not allow within a space
+func checkTitles(root *Doc) error {
+	seen := map[string]string{}
+	var check func(doc *Doc) error
+	check = func(doc *Doc) error {
+		// The top directory keeps the root page's title
+		if doc.Path != "." {
+			key := strings.ToLower(doc.Title)
+			if other, ok := seen[key]; ok {
+				return clierr.New(clierr.KindValidation, "%s and %s both have the title %q; page titles must be unique in a space",
+					other, doc.Path, doc.Title)
+			}
+			seen[key] = doc.Path
+		}
+		for _, child := range doc.Children {
+			if err := check(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(root)
+}
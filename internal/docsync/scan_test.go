@@ -0,0 +1,112 @@
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates files under a new temporary directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, writeFile(dir, name, content))
+	}
+	return dir
+}
+
+// writeFile writes a file at a slash-separated path under dir
+func writeFile(dir, name, content string) error {
+	file := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(content), 0o644)
+}
+
+func TestScan(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"README.md":               "# Docs\n\nWelcome\n",
+		"getting-started.md":      "---\ntitle: Start Here\nconfluence_page_id: 42\n---\nHello\n",
+		"guides/setup.md":         "\n# Setup Guide #\n\nSteps\n",
+		"guides/img/arch.png":     "png",
+		"api/_index.md":           "API overview\n",
+		"api/errors.md":           "Errors\n",
+		"empty/notes.txt":         "not markdown",
+		".github/workflows/ci.md": "hidden",
+		".confluence-sync.json":   "{}",
+	})
+
+	root, err := Scan(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, ".", root.Path)
+	assert.Equal(t, "README.md", root.File)
+	assert.Equal(t, "\nWelcome\n", root.Markdown)
+	require.Len(t, root.Children, 3)
+
+	api := root.Children[0]
+	assert.Equal(t, "api", api.Path)
+	assert.True(t, api.IsDir())
+	assert.Equal(t, "api/_index.md", api.File)
+	assert.Equal(t, "Api", api.Title)
+	require.Len(t, api.Children, 1)
+	assert.Equal(t, "Errors", api.Children[0].Title)
+
+	start := root.Children[1]
+	assert.Equal(t, "getting-started.md", start.Path)
+	assert.False(t, start.IsDir())
+	assert.Equal(t, "Start Here", start.Title)
+	assert.Equal(t, "42", start.PageID)
+	assert.Equal(t, "Hello\n", start.Markdown)
+
+	guides := root.Children[2]
+	assert.Equal(t, "", guides.File, "no index file")
+	assert.Equal(t, "Guides", guides.Title)
+	require.Len(t, guides.Children, 1)
+	assert.Equal(t, "Setup Guide", guides.Children[0].Title)
+	assert.Equal(t, "\nSteps\n", guides.Children[0].Markdown)
+}
+
+func TestScan_DuplicateTitles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.md": "# Overview\n",
+		"b.md": "---\ntitle: overview\n---\n",
+	})
+
+	_, err := Scan(dir)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+	assert.ErrorContains(t, err, "a.md and b.md")
+}
+
+func TestScan_NoMarkdown(t *testing.T) {
+	_, err := Scan(writeFiles(t, map[string]string{"notes.txt": "x"}))
+	assert.ErrorContains(t, err, "no Markdown files")
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	matter, rest, err := splitFrontMatter("---\ntitle: A\nconfluence_page_id: \"7\"\nlayout: doc\n...\nbody\n")
+	require.NoError(t, err)
+	assert.Equal(t, frontMatter{Title: "A", PageID: "7"}, matter)
+	assert.Equal(t, "body\n", rest)
+
+	// An unclosed "---" is a thematic break
+	matter, rest, err = splitFrontMatter("---\nbody\n")
+	require.NoError(t, err)
+	assert.Empty(t, matter.Title)
+	assert.Equal(t, "---\nbody\n", rest)
+
+	_, _, err = splitFrontMatter("---\ntitle: [\n---\n")
+	assert.Error(t, err)
+}
+
+func TestTitleFromName(t *testing.T) {
+	assert.Equal(t, "Getting started", titleFromName("getting-started"))
+	assert.Equal(t, "Api reference", titleFromName("api_reference"))
+	assert.Equal(t, "FAQ", titleFromName("FAQ"))
+	assert.Equal(t, "Été", titleFromName("été"))
+}
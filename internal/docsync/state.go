@@ -0,0 +1,71 @@
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// StateFile is the default name of the state file, kept in the synced directory
+const StateFile = ".confluence-sync.json"
+
+// State records the pages a directory was last synced to
+type State struct {
+	Space  string                `json:"space"`
+	RootID string                `json:"rootId"`
+	Pages  map[string]*PageState `json:"pages"` // By doc path
+}
+
+// PageState records a synced page
+type PageState struct {
+	PageID      string            `json:"pageId"`
+	ParentID    string            `json:"parentId,omitempty"`
+	Hash        string            `json:"hash"`                  // Hash of the title and storage content last written
+	Attachments map[string]string `json:"attachments,omitempty"` // Hash of each uploaded file, by attachment name
+}
+
+// NewState returns an empty state for a space and root page
+func NewState(space, rootID string) *State {
+	return &State{Space: space, RootID: rootID, Pages: map[string]*PageState{}}
+}
+
+// LoadState reads a state file, returning an empty state when it does not exist.
+// A state recorded for another space or root page is an error, since its page
+// IDs would not belong to this tree.
+func LoadState(file, space, rootID string) (*State, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewState(space, rootID), nil
+	}
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "cannot read state file")
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "invalid state file %s", file)
+	}
+	if state.Space != space || state.RootID != rootID {
+		return nil, clierr.New(clierr.KindValidation,
+			"state file %s is for space %s under page %s; use --state to keep a separate state for space %s under page %s",
+			file, state.Space, state.RootID, space, rootID)
+	}
+	if state.Pages == nil {
+		state.Pages = map[string]*PageState{}
+	}
+	return &state, nil
+}
+
+// Save writes the state file
+func (s *State) Save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write state file")
+	}
+	return nil
+}
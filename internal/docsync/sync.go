@@ -0,0 +1,331 @@
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Actions a sync takes on a page
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+	ActionDelete    = "delete"
+)
+
+// Options select what to sync and where
+type Options struct {
+	Dir    string // Synced directory
+	Space  string // Space key for new pages
+	RootID string // Page the top directory maps to
+	Prune  bool   // Trash pages under the root that no file maps to
+}
+
+// Change is what a sync does, or would do, to one page
+type Change struct {
+	Action      string   `json:"action"`
+	Path        string   `json:"path,omitempty"` // Doc path; empty for pruned pages
+	PageID      string   `json:"pageId,omitempty"`
+	Title       string   `json:"title"`
+	Content     bool     `json:"content"`               // Title and content are written
+	Moved       bool     `json:"moved"`                 // Page moves under its directory's page
+	Attachments []string `json:"attachments,omitempty"` // Images uploaded
+	Error       string   `json:"error,omitempty"`
+}
+
+// Plan is the changes a sync makes, in the order they are applied: parents
+// before children, then pruned pages deepest first
+type Plan struct {
+	Changes []*Change
+
+	opts     Options
+	previous *State
+	steps    []*step
+}
+
+// step is the planned change to one doc
+type step struct {
+	change      *Change
+	doc         *Doc
+	parent      *step
+	storage     string
+	hash        string
+	attachments []attachment
+	parentID    string // Current parent of an existing page
+	keepTitle   bool   // Leave the page's title as it is
+}
+
+// NewPlan compares the docs with the pages they were last synced to, reading
+// pages only when the state does not cover them
+func NewPlan(ctx context.Context, client confluence.ConfluenceClient, root *Doc, state *State, opts Options) (*Plan, error) {
+	rootPage, err := client.GetPage(ctx, opts.RootID)
+	if err != nil {
+		return nil, err
+	}
+	if rootPage.SpaceKey != "" && rootPage.SpaceKey != opts.Space {
+		return nil, clierr.New(clierr.KindValidation, "root page %s is in space %s, not %s", opts.RootID, rootPage.SpaceKey, opts.Space)
+	}
+
+	// The root page keeps its title unless front matter sets one
+	keepTitle := !root.titleFixed
+	if keepTitle {
+		root.Title = rootPage.Title
+	}
+
+	docs := map[string]*Doc{}
+	var index func(doc *Doc)
+	index = func(doc *Doc) {
+		docs[doc.Path] = doc
+		if doc.File != "" {
+			docs[doc.File] = doc
+		}
+		for _, child := range doc.Children {
+			index(child)
+		}
+	}
+	index(root)
+
+	plan := &Plan{opts: opts, previous: state}
+	ids := map[string]string{}
+	var walk func(doc *Doc, parent *step) error
+	walk = func(doc *Doc, parent *step) error {
+		s, err := plan.planDoc(ctx, client, doc, parent, docs)
+		if err != nil {
+			return err
+		}
+		if other, ok := ids[s.change.PageID]; ok && s.change.PageID != "" {
+			return clierr.New(clierr.KindValidation, "%s and %s are both synced to page %s", other, doc.Path, s.change.PageID)
+		}
+		ids[s.change.PageID] = doc.Path
+		s.keepTitle = doc == root && keepTitle
+
+		for _, child := range doc.Children {
+			if err := walk(child, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, nil); err != nil {
+		return nil, err
+	}
+
+	if opts.Prune {
+		descendants, err := client.ListDescendants(ctx, opts.RootID)
+		if err != nil {
+			return nil, err
+		}
+		// Descendants come parents first; trash the deepest first
+		for i := len(descendants) - 1; i >= 0; i-- {
+			page := descendants[i]
+			if _, ok := ids[page.ID]; !ok {
+				plan.Changes = append(plan.Changes, &Change{Action: ActionDelete, PageID: page.ID, Title: page.Title})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// planDoc works out the change to a doc's page
+func (p *Plan) planDoc(ctx context.Context, client confluence.ConfluenceClient, doc *Doc, parent *step, docs map[string]*Doc) (*step, error) {
+	storage, attachments, err := render(p.opts.Dir, doc, docs)
+	if err != nil {
+		return nil, err
+	}
+	s := &step{
+		change:  &Change{Path: doc.Path, Title: doc.Title},
+		doc:     doc,
+		parent:  parent,
+		storage: storage,
+		hash:    hash(doc.Title, storage),
+	}
+
+	id := doc.PageID
+	if parent == nil {
+		id = p.opts.RootID
+	}
+	previous := p.previous.Pages[doc.Path]
+	if id == "" && previous != nil {
+		id = previous.PageID
+	}
+	s.change.PageID = id
+
+	var uploaded map[string]string
+	switch {
+	case id == "":
+		s.change.Action = ActionCreate
+		s.change.Content = true
+	case previous != nil && previous.PageID == id:
+		s.parentID = previous.ParentID
+		s.change.Content = previous.Hash != s.hash
+		uploaded = previous.Attachments
+	default:
+		page, err := client.GetPage(ctx, id)
+		if err != nil {
+			return nil, clierr.Wrap(clierr.KindOf(err), err, "%s: cannot get page %s", doc.Path, id)
+		}
+		s.parentID = page.ParentID
+		s.change.Content = page.Title != doc.Title || page.Content != storage
+	}
+
+	// A top directory without an index file leaves the root page's content alone
+	if parent == nil && doc.File == "" {
+		s.change.Content = false
+		s.storage, s.hash = "", ""
+	}
+
+	for _, a := range attachments {
+		if uploaded[a.Name] != a.Hash {
+			s.attachments = append(s.attachments, a)
+			s.change.Attachments = append(s.change.Attachments, a.Name)
+		}
+	}
+
+	if s.change.Action == "" {
+		s.change.Moved = parent != nil && (parent.change.PageID == "" || parent.change.PageID != s.parentID)
+		s.change.Action = ActionUnchanged
+		if s.change.Content || s.change.Moved || len(s.attachments) > 0 {
+			s.change.Action = ActionUpdate
+		}
+	}
+
+	p.steps = append(p.steps, s)
+	p.Changes = append(p.Changes, s.change)
+	return s, nil
+}
+
+// Apply makes the planned changes, recording errors in each change and carrying
+// on with the pages that do not depend on a failed one. It returns the state to
+// save, which records the pages synced so far even when some failed.
+func (p *Plan) Apply(ctx context.Context, client confluence.ConfluenceClient) (*State, error) {
+	state := NewState(p.opts.Space, p.opts.RootID)
+	failed := 0
+	var stopped error
+
+	for _, s := range p.steps {
+		entry := &PageState{}
+		if previous := p.previous.Pages[s.doc.Path]; previous != nil {
+			copied := *previous
+			entry = &copied
+		}
+
+		switch {
+		case stopped != nil:
+			s.change.Error = "not synced: " + stopped.Error()
+		case s.parent != nil && s.parent.change.PageID == "":
+			s.change.Error = "parent page was not synced"
+		default:
+			if err := p.applyStep(ctx, client, s, entry); err != nil {
+				s.change.Error = err.Error()
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					stopped = ctxErr
+				}
+			}
+		}
+
+		if s.change.Error != "" {
+			failed++
+		}
+		if entry.PageID != "" {
+			state.Pages[s.doc.Path] = entry
+		}
+	}
+
+	for _, change := range p.Changes {
+		if change.Action != ActionDelete {
+			continue
+		}
+		if stopped == nil {
+			stopped = ctx.Err()
+		}
+		if stopped != nil {
+			change.Error = "not synced: " + stopped.Error()
+		} else if err := client.DeletePage(ctx, change.PageID); err != nil {
+			change.Error = err.Error()
+		}
+		if change.Error != "" {
+			failed++
+		}
+	}
+
+	if stopped != nil {
+		return state, clierr.Wrap(clierr.KindOf(stopped), stopped, "sync stopped; %d of %d pages not synced", failed, len(p.Changes))
+	}
+	if failed > 0 {
+		return state, clierr.New(clierr.KindGeneral, "%d of %d pages failed to sync", failed, len(p.Changes))
+	}
+	return state, nil
+}
+
+// applyStep creates or updates, moves and uploads the attachments of one page,
+// recording in entry what was done
+func (p *Plan) applyStep(ctx context.Context, client confluence.ConfluenceClient, s *step, entry *PageState) error {
+	change := s.change
+	parentID := ""
+	if s.parent != nil {
+		parentID = s.parent.change.PageID
+	}
+
+	switch {
+	case change.Action == ActionCreate:
+		page, err := client.CreatePage(ctx, &types.CreatePageRequest{
+			SpaceKey: p.opts.Space,
+			Title:    s.doc.Title,
+			Content:  s.storage,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return err
+		}
+		change.PageID = page.ID
+		*entry = PageState{PageID: page.ID, ParentID: parentID, Hash: s.hash}
+	case change.Content:
+		req := &types.UpdatePageRequest{Content: &s.storage, Message: "Synced from " + s.doc.File}
+		if !s.keepTitle {
+			req.Title = &s.doc.Title
+		}
+		if _, err := client.UpdatePage(ctx, change.PageID, req); err != nil {
+			return err
+		}
+		entry.Hash = s.hash
+	default:
+		entry.Hash = s.hash
+	}
+	entry.PageID = change.PageID
+
+	if change.Moved {
+		if err := client.MovePage(ctx, change.PageID, "append", parentID); err != nil {
+			return err
+		}
+	}
+	if s.parent != nil {
+		entry.ParentID = parentID
+	}
+
+	for _, a := range s.attachments {
+		if err := p.upload(ctx, client, change.PageID, a); err != nil {
+			return err
+		}
+		if entry.Attachments == nil {
+			entry.Attachments = map[string]string{}
+		}
+		entry.Attachments[a.Name] = a.Hash
+	}
+	return nil
+}
+
+// upload attaches a local image to a page
+func (p *Plan) upload(ctx context.Context, client confluence.ConfluenceClient, pageID string, a attachment) error {
+	file, err := os.Open(filepath.Join(p.opts.Dir, filepath.FromSlash(a.File)))
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot read image %s", a.File)
+	}
+	defer file.Close()
+
+	_, err = client.UploadAttachment(ctx, pageID, a.Name, file)
+	return err
+}
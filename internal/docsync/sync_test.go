@@ -0,0 +1,291 @@
+package docsync
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps pages in memory and records the changes made to them
+type fakeClient struct {
+	confluence.ConfluenceClient
+	pages   map[string]*types.Page
+	nextID  int
+	calls   []string
+	uploads map[string]string // File content by "pageID/name"
+	fail    map[string]bool   // Page IDs whose updates fail
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		pages:   map[string]*types.Page{"1": {ID: "1", Title: "Home", SpaceKey: "DOC", Content: "<p>old</p>"}},
+		nextID:  100,
+		uploads: map[string]string{},
+		fail:    map[string]bool{},
+	}
+}
+
+func (f *fakeClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page, ok := f.pages[id]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "page %s not found", id)
+	}
+	copied := *page
+	return &copied, nil
+}
+
+func (f *fakeClient) CreatePage(ctx context.Context, req *types.CreatePageRequest) (*types.Page, error) {
+	id := fmt.Sprint(f.nextID)
+	f.nextID++
+	f.pages[id] = &types.Page{ID: id, Title: req.Title, SpaceKey: req.SpaceKey, ParentID: req.ParentID, Content: req.Content}
+	f.calls = append(f.calls, "create "+req.Title+" under "+req.ParentID)
+	return f.pages[id], nil
+}
+
+func (f *fakeClient) UpdatePage(ctx context.Context, id string, req *types.UpdatePageRequest) (*types.Page, error) {
+	if f.fail[id] {
+		return nil, clierr.New(clierr.KindGeneral, "update failed")
+	}
+	page := f.pages[id]
+	if req.Title != nil {
+		page.Title = *req.Title
+	}
+	page.Content = *req.Content
+	f.calls = append(f.calls, "update "+id)
+	return page, nil
+}
+
+func (f *fakeClient) MovePage(ctx context.Context, id, position, targetID string) error {
+	f.pages[id].ParentID = targetID
+	f.calls = append(f.calls, "move "+id+" to "+targetID)
+	return nil
+}
+
+func (f *fakeClient) UploadAttachment(ctx context.Context, pageID, fileName string, file io.Reader) (*types.Attachment, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	f.uploads[pageID+"/"+fileName] = string(data)
+	f.calls = append(f.calls, "upload "+fileName+" to "+pageID)
+	return &types.Attachment{Title: fileName, PageID: pageID}, nil
+}
+
+func (f *fakeClient) ListDescendants(ctx context.Context, id string) ([]types.Page, error) {
+	var pages []types.Page
+	var collect func(parent string)
+	collect = func(parent string) {
+		for pageID := 100; pageID < f.nextID; pageID++ {
+			if page, ok := f.pages[fmt.Sprint(pageID)]; ok && page.ParentID == parent {
+				pages = append(pages, *page)
+				collect(page.ID)
+			}
+		}
+	}
+	collect(id)
+	return pages, nil
+}
+
+func (f *fakeClient) DeletePage(ctx context.Context, id string) error {
+	delete(f.pages, id)
+	f.calls = append(f.calls, "delete "+id)
+	return nil
+}
+
+// syncDir plans and applies a sync of dir to page 1, returning the new state
+func syncDir(t *testing.T, client *fakeClient, dir string, state *State, prune bool) (*Plan, *State) {
+	root, err := Scan(dir)
+	require.NoError(t, err)
+	plan, err := NewPlan(context.Background(), client, root, state, Options{Dir: dir, Space: "DOC", RootID: "1", Prune: prune})
+	require.NoError(t, err)
+	newState, err := plan.Apply(context.Background(), client)
+	require.NoError(t, err)
+	return plan, newState
+}
+
+func actions(plan *Plan) map[string]string {
+	result := map[string]string{}
+	for _, change := range plan.Changes {
+		key := change.Path
+		if key == "" {
+			key = change.PageID
+		}
+		result[key] = change.Action
+	}
+	return result
+}
+
+func TestSync_CreateThenSkipUnchanged(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"index.md":        "See [setup](guides/setup.md#install) and ![arch](img/arch.png)\n",
+		"guides/setup.md": "# Setup\n\nBack to [home](../index.md)\n",
+		"img/arch.png":    "png-v1",
+		"notes/todo.md":   "```\n![not an image](missing.png)\n```\n",
+	})
+	client := newFakeClient()
+
+	plan, state := syncDir(t, client, dir, NewState("DOC", "1"), false)
+	assert.Equal(t, map[string]string{
+		".": ActionUpdate, "guides": ActionCreate, "guides/setup.md": ActionCreate, "notes": ActionCreate, "notes/todo.md": ActionCreate,
+	}, actions(plan))
+	assert.Equal(t, []string{
+		"update 1", "upload arch.png to 1",
+		"create Guides under 1", "create Setup under 100",
+		"create Notes under 1", "create Todo under 102",
+	}, client.calls)
+
+	home := client.pages["1"]
+	assert.Equal(t, "Home", home.Title, "root keeps its title")
+	assert.Contains(t, home.Content, `<ac:link ac:anchor="install"><ri:page ri:content-title="Setup" /><ac:link-body>setup</ac:link-body></ac:link>`)
+	assert.Contains(t, home.Content, `<ri:attachment ri:filename="arch.png" />`)
+	assert.Contains(t, client.pages["101"].Content, `<ri:page ri:content-title="Home" />`)
+	assert.Equal(t, childrenMacro, client.pages["100"].Content)
+	assert.Equal(t, "png-v1", client.uploads["1/arch.png"])
+
+	assert.Equal(t, "101", state.Pages["guides/setup.md"].PageID)
+	assert.Equal(t, "100", state.Pages["guides/setup.md"].ParentID)
+
+	// A second sync changes nothing
+	client.calls = nil
+	plan, state = syncDir(t, client, dir, state, false)
+	assert.Empty(t, client.calls)
+	for _, change := range plan.Changes {
+		assert.Equal(t, ActionUnchanged, change.Action, change.Path)
+	}
+
+	// Changed images are uploaded again without rewriting the page
+	require.NoError(t, writeFile(dir, "img/arch.png", "png-v2"))
+	plan, _ = syncDir(t, client, dir, state, false)
+	assert.Equal(t, []string{"upload arch.png to 1"}, client.calls)
+	assert.Equal(t, []string{"arch.png"}, plan.Changes[0].Attachments)
+	assert.False(t, plan.Changes[0].Content)
+}
+
+func TestSync_MoveAndPrune(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a/page.md": "Page\n",
+		"old.md":    "Old\n",
+	})
+	client := newFakeClient()
+	_, state := syncDir(t, client, dir, NewState("DOC", "1"), false)
+	pageID := state.Pages["a/page.md"].PageID
+	oldID := state.Pages["old.md"].PageID
+
+	// Move the file to another directory and delete another
+	moved := writeFiles(t, map[string]string{
+		"a/keep.md": "Keep\n",
+		"b/page.md": "Page\n",
+	})
+	state.Pages["b/page.md"] = state.Pages["a/page.md"]
+	client.calls = nil
+
+	plan, newState := syncDir(t, client, moved, state, true)
+	change := plan.Changes[len(plan.Changes)-1]
+	assert.Equal(t, ActionDelete, change.Action)
+	assert.Equal(t, oldID, change.PageID)
+
+	pageChange := plan.Changes[4]
+	assert.Equal(t, "b/page.md", pageChange.Path)
+	assert.True(t, pageChange.Moved)
+	assert.False(t, pageChange.Content)
+	assert.Contains(t, client.calls, "move "+pageID+" to "+newState.Pages["b"].PageID)
+	assert.NotContains(t, client.pages, oldID)
+	assert.NotContains(t, newState.Pages, "old.md")
+}
+
+func TestSync_FrontMatterPageID(t *testing.T) {
+	client := newFakeClient()
+	client.pages["7"] = &types.Page{ID: "7", Title: "Existing", ParentID: "1", Content: "<p>Same</p>"}
+	dir := writeFiles(t, map[string]string{"doc.md": "---\ntitle: Existing\nconfluence_page_id: 7\n---\nSame\n"})
+
+	plan, state := syncDir(t, client, dir, NewState("DOC", "1"), false)
+	assert.Equal(t, ActionUnchanged, plan.Changes[1].Action, "content matches the page")
+	assert.Equal(t, "7", state.Pages["doc.md"].PageID)
+	assert.Empty(t, client.calls)
+}
+
+func TestSync_DryRunPlanOnly(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"doc.md": "Doc\n"})
+	client := newFakeClient()
+	root, err := Scan(dir)
+	require.NoError(t, err)
+
+	plan, err := NewPlan(context.Background(), client, root, NewState("DOC", "1"), Options{Dir: dir, Space: "DOC", RootID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{".": ActionUnchanged, "doc.md": ActionCreate}, actions(plan))
+	assert.Empty(t, client.calls)
+}
+
+func TestSync_FailureSkipsChildren(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a/index.md": "A\n", "a/b.md": "B\n", "c.md": "C\n"})
+	client := newFakeClient()
+	_, state := syncDir(t, client, dir, NewState("DOC", "1"), false)
+
+	require.NoError(t, writeFile(dir, "a/index.md", "A2\n"))
+	require.NoError(t, writeFile(dir, "c.md", "C2\n"))
+	client.fail[state.Pages["a"].PageID] = true
+
+	root, err := Scan(dir)
+	require.NoError(t, err)
+	plan, err := NewPlan(context.Background(), client, root, state, Options{Dir: dir, Space: "DOC", RootID: "1"})
+	require.NoError(t, err)
+	newState, err := plan.Apply(context.Background(), client)
+	assert.ErrorContains(t, err, "1 of 4 pages failed to sync")
+	assert.Equal(t, "update failed", plan.Changes[1].Error)
+	assert.Empty(t, plan.Changes[3].Error, "other pages are still synced")
+	assert.Equal(t, state.Pages["a"].Hash, newState.Pages["a"].Hash, "failed page is retried next time")
+	assert.NotEqual(t, state.Pages["c.md"].Hash, newState.Pages["c.md"].Hash)
+}
+
+func TestNewPlan_Validation(t *testing.T) {
+	client := newFakeClient()
+
+	dir := writeFiles(t, map[string]string{"doc.md": "![x](missing.png)\n"})
+	root, err := Scan(dir)
+	require.NoError(t, err)
+	_, err = NewPlan(context.Background(), client, root, NewState("DOC", "1"), Options{Dir: dir, Space: "DOC", RootID: "1"})
+	assert.ErrorContains(t, err, "cannot read image missing.png")
+
+	_, err = NewPlan(context.Background(), client, root, NewState("OTHER", "1"), Options{Dir: dir, Space: "OTHER", RootID: "1"})
+	assert.ErrorContains(t, err, "root page 1 is in space DOC, not OTHER")
+}
+
+func TestNewPlan_ImageOutsideDir(t *testing.T) {
+	client := newFakeClient()
+
+	for _, dest := range []string{"../secret.png", "a/../../secret.png", "/etc/secret.png", "%2E%2E/secret.png"} {
+		dir := writeFiles(t, map[string]string{"doc.md": "![x](" + dest + ")\n"})
+		root, err := Scan(dir)
+		require.NoError(t, err)
+		_, err = NewPlan(context.Background(), client, root, NewState("DOC", "1"), Options{Dir: dir, Space: "DOC", RootID: "1"})
+		require.Error(t, err, dest)
+		assert.Equal(t, clierr.KindValidation, clierr.KindOf(err), dest)
+		assert.ErrorContains(t, err, "is outside the synced directory", dest)
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	file := filepath.Join(t.TempDir(), StateFile)
+
+	state, err := LoadState(file, "DOC", "1")
+	require.NoError(t, err)
+	assert.Empty(t, state.Pages)
+
+	state.Pages["a.md"] = &PageState{PageID: "5", Hash: "h"}
+	require.NoError(t, state.Save(file))
+
+	loaded, err := LoadState(file, "DOC", "1")
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+
+	_, err = LoadState(file, "DOC", "2")
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
@@ -0,0 +1,472 @@
+// Package export writes Confluence pages and their attachments to local files.
+//
+// Pages are written as Markdown, converted from storage format, or as the HTML
+// Confluence renders for export. A page with children becomes a directory whose
+// index file holds the page, so the layout matches what page sync reads back.
+// Links between exported pages and to downloaded attachments are rewritten as
+// relative paths. A manifest records each page's version so that exporting
+// again only writes the pages and attachments that changed.
+package export
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/output"
+	"atlassian-cli/internal/types"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Export formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Actions an export reports for a page
+const (
+	ActionExported  = "exported"
+	ActionUnchanged = "unchanged"
+	ActionRemoved   = "removed"
+	ActionFailed    = "failed"
+)
+
+// attachmentsDir holds downloaded attachments, one directory per page
+const attachmentsDir = "attachments"
+
+// maxSlugLength caps the length of file names made from titles
+const maxSlugLength = 80
+
+// Options control an export
+type Options struct {
+	Dir    string
+	Format string
+}
+
+// PageResult reports what an export did with one page
+type PageResult struct {
+	Action      string `json:"action"`
+	PageID      string `json:"pageId"`
+	Title       string `json:"title"`
+	Version     int    `json:"version"`
+	Path        string `json:"path"`
+	Attachments int    `json:"attachments"` // Attachments downloaded
+	Error       string `json:"error,omitempty"`
+}
+
+// exporter holds the state of one export
+type exporter struct {
+	client   confluence.ConfluenceClient
+	opts     Options
+	previous map[string]*ManifestPage
+	files    map[string]*pageFile
+	renderer *renderer
+}
+
+// CheckFormat returns a validation error unless format is an export format
+func CheckFormat(format string) error {
+	if format != FormatMarkdown && format != FormatHTML {
+		return clierr.New(clierr.KindValidation, "invalid format %q: must be markdown or html", format)
+	}
+	return nil
+}
+
+// Export writes the page trees to opts.Dir, skipping pages whose version and
+// file are unchanged since the export recorded in manifest, and removing the
+// files of pages no longer in the trees. It updates manifest with what was
+// written; failed pages keep their previous entry so the next export retries them.
+func Export(ctx context.Context, client confluence.ConfluenceClient, roots []*confluence.PageNode, manifest *Manifest, opts Options) ([]*PageResult, error) {
+	if err := CheckFormat(opts.Format); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "cannot create %s", opts.Dir)
+	}
+
+	e := &exporter{client: client, opts: opts, previous: manifest.Pages, files: layout(roots, opts.Format)}
+	e.renderer = newRenderer(e.files)
+	manifest.Pages = map[string]*ManifestPage{}
+
+	var results []*PageResult
+	var stopped error
+	failed := 0
+	confluence.Walk(roots, func(node *confluence.PageNode, depth int) {
+		result := &PageResult{PageID: node.ID, Title: node.Title, Version: node.Version, Path: e.files[node.ID].Path}
+		results = append(results, result)
+
+		if stopped == nil {
+			stopped = ctx.Err()
+		}
+		entry := e.previous[node.ID]
+		var err error
+		if stopped != nil {
+			err = stopped
+		} else {
+			entry, err = e.exportPage(ctx, node, result)
+		}
+		if err != nil {
+			result.Action = ActionFailed
+			result.Error = err.Error()
+			failed++
+			entry = e.previous[node.ID]
+		}
+		if entry != nil {
+			manifest.Pages[node.ID] = entry
+		}
+	})
+
+	for id, previous := range e.previous {
+		if _, ok := e.files[id]; ok {
+			continue
+		}
+		result := &PageResult{Action: ActionRemoved, PageID: id, Title: previous.Title, Version: previous.Version, Path: previous.Path}
+		results = append(results, result)
+		if err := e.remove(previous.Path); err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+		for _, attachment := range previous.Attachments {
+			e.remove(attachment.Path)
+		}
+	}
+
+	if stopped != nil {
+		return results, clierr.Wrap(clierr.KindCanceled, stopped, "export interrupted; %d of %d pages not exported", failed, len(results))
+	}
+	if failed > 0 {
+		return results, clierr.New(clierr.KindGeneral, "%d of %d pages failed to export", failed, len(results))
+	}
+	return results, nil
+}
+
+// Run exports the page trees to opts.Dir, refreshing the export of source found
+// there, and saves the manifest even when some pages fail
+func Run(ctx context.Context, client confluence.ConfluenceClient, roots []*confluence.PageNode, source string, opts Options) ([]*PageResult, error) {
+	manifest, err := LoadManifest(opts.Dir, source, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	results, exportErr := Export(ctx, client, roots, manifest, opts)
+	if results == nil {
+		return nil, exportErr
+	}
+	if err := manifest.Save(opts.Dir); err != nil {
+		return results, err
+	}
+	return results, exportErr
+}
+
+// Summary describes the results of an export in one line
+func Summary(results []*PageResult, dir string) string {
+	counts := map[string]int{}
+	attachments, failed := 0, 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+			continue
+		}
+		counts[result.Action]++
+		attachments += result.Attachments
+	}
+
+	summary := fmt.Sprintf("Exported %d %s and %d %s to %s", counts[ActionExported], plural(counts[ActionExported], "page"),
+		attachments, plural(attachments, "attachment"), dir)
+	var details []string
+	if n := counts[ActionUnchanged]; n > 0 {
+		details = append(details, fmt.Sprintf("%d unchanged", n))
+	}
+	if n := counts[ActionRemoved]; n > 0 {
+		details = append(details, fmt.Sprintf("%d removed", n))
+	}
+	if failed > 0 {
+		details = append(details, fmt.Sprintf("%d failed", failed))
+	}
+	if len(details) > 0 {
+		summary += " (" + strings.Join(details, ", ") + ")"
+	}
+	return summary
+}
+
+// plural adds an "s" to a noun following a count other than one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+// exportPage downloads a page's changed attachments and, when it or a page it
+// links to changed, writes its file
+func (e *exporter) exportPage(ctx context.Context, node *confluence.PageNode, result *PageResult) (*ManifestPage, error) {
+	file := e.files[node.ID]
+	previous := e.previous[node.ID]
+	entry := &ManifestPage{Title: node.Title, Version: node.Version, ParentID: node.ParentID, Path: file.Path}
+
+	attachments, downloaded, err := e.exportAttachments(ctx, node.ID, previous)
+	if err != nil {
+		return nil, err
+	}
+	entry.Attachments = attachments
+	result.Attachments = downloaded
+
+	if previous != nil && !e.changed(previous, file) {
+		result.Action = ActionUnchanged
+		entry.Links = previous.Links
+		return entry, nil
+	}
+
+	var page *types.Page
+	if e.opts.Format == FormatHTML {
+		page, err = e.client.GetPageHTML(ctx, node.ID)
+	} else {
+		page, err = e.client.GetPage(ctx, node.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.renderer.attachments = map[string]string{}
+	for name, attachment := range attachments {
+		e.renderer.attachments[name] = attachment.Path
+	}
+	var content string
+	if e.opts.Format == FormatHTML {
+		content, entry.Links = e.renderer.htmlFile(page, file)
+	} else if content, entry.Links, err = e.renderer.markdownFile(page, file); err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "cannot convert page %s to Markdown", node.ID)
+	}
+
+	if err := e.write(file.Path, []byte(content)); err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.Path != file.Path {
+		e.remove(previous.Path)
+	}
+	entry.Version = page.Version
+	result.Version = page.Version
+	result.Action = ActionExported
+	return entry, nil
+}
+
+// changed reports whether a page's file must be written again: the page has a
+// new version, its file moved or is missing, or a page it links to moved
+func (e *exporter) changed(previous *ManifestPage, file *pageFile) bool {
+	if previous.Version == 0 || previous.Version != file.Version || previous.Path != file.Path || !e.exists(file.Path) {
+		return true
+	}
+	for _, id := range previous.Links {
+		target, ok := e.files[id]
+		if old := e.previous[id]; !ok || old == nil || old.Path != target.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// exportAttachments downloads the attachments of a page that are new or have a
+// new version, and removes those deleted since the last export
+func (e *exporter) exportAttachments(ctx context.Context, pageID string, previous *ManifestPage) (map[string]*ManifestAttachment, int, error) {
+	attachments, err := e.client.ListAttachments(ctx, pageID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := map[string]*ManifestAttachment{}
+	downloaded := 0
+	for i := range attachments {
+		attachment := &attachments[i]
+		file := path.Join(attachmentsDir, pageID, safeName(attachment.Title))
+		entry := &ManifestAttachment{ID: attachment.ID, Version: attachment.Version, Path: file}
+
+		var old *ManifestAttachment
+		if previous != nil {
+			old = previous.Attachments[attachment.Title]
+		}
+		if old == nil || old.Version != attachment.Version || old.Path != file || !e.exists(file) {
+			if err := e.download(ctx, attachment, file); err != nil {
+				return nil, 0, err
+			}
+			downloaded++
+		}
+		entries[attachment.Title] = entry
+	}
+
+	if previous != nil {
+		for name, old := range previous.Attachments {
+			if current, ok := entries[name]; !ok || current.Path != old.Path {
+				e.remove(old.Path)
+			}
+		}
+	}
+	return entries, downloaded, nil
+}
+
+// write writes a file at a slash-separated path under the export directory
+func (e *exporter) write(file string, data []byte) error {
+	full := filepath.Join(e.opts.Dir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot create directory for %s", file)
+	}
+	if err := os.WriteFile(full, data, 0o644); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+	return nil
+}
+
+// download streams an attachment to a slash-separated path under the export
+// directory. It is written to a temporary file beside its target and renamed
+// into place, so a failed download never leaves a truncated attachment.
+func (e *exporter) download(ctx context.Context, attachment *types.Attachment, file string) error {
+	full := filepath.Join(e.opts.Dir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot create directory for %s", file)
+	}
+	temp, err := os.CreateTemp(filepath.Dir(full), "."+filepath.Base(full)+".*.tmp")
+	if err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+	defer os.Remove(temp.Name()) // Fails harmlessly once renamed
+
+	if err := e.client.DownloadAttachment(ctx, attachment, temp); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+	if err := os.Chmod(temp.Name(), 0o644); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+	if err := os.Rename(temp.Name(), full); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write %s", file)
+	}
+	return nil
+}
+
+// exists reports whether a file exists under the export directory
+func (e *exporter) exists(file string) bool {
+	_, err := os.Stat(filepath.Join(e.opts.Dir, filepath.FromSlash(file)))
+	return err == nil
+}
+
+// remove deletes a file under the export directory, and then any directories
+// left empty. Files now assigned to another page are kept.
+func (e *exporter) remove(file string) error {
+	for _, current := range e.files {
+		if current.Path == file {
+			return nil
+		}
+	}
+	full := filepath.Join(e.opts.Dir, filepath.FromSlash(file))
+	if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot remove %s", file)
+	}
+	for dir := filepath.Dir(full); dir != filepath.Clean(e.opts.Dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// layout assigns each page a file: pages with children become a directory with
+// an index file, other pages a file named after their title. Names that clash
+// within a directory get the page ID appended.
+func layout(roots []*confluence.PageNode, format string) map[string]*pageFile {
+	ext := ".md"
+	if format == FormatHTML {
+		ext = ".html"
+	}
+
+	files := map[string]*pageFile{}
+	var place func(nodes []*confluence.PageNode, dir string)
+	place = func(nodes []*confluence.PageNode, dir string) {
+		used := map[string]bool{"index": true, attachmentsDir: true, "manifest": true}
+		for _, node := range nodes {
+			name := slug(node.Title)
+			if used[name] {
+				name += "-" + node.ID
+			}
+			used[name] = true
+
+			file := &pageFile{ID: node.ID, Title: node.Title, Space: node.SpaceKey, Version: node.Version}
+			if len(node.Children) > 0 {
+				file.Path = path.Join(dir, name, "index"+ext)
+				place(node.Children, path.Join(dir, name))
+			} else {
+				file.Path = path.Join(dir, name+ext)
+			}
+			files[node.ID] = file
+		}
+	}
+	place(roots, "")
+	return files
+}
+
+// slug turns a title into a file name: lower case letters and digits, with
+// other characters replaced by single hyphens
+func slug(title string) string {
+	var sb strings.Builder
+	hyphen := false
+	length := 0
+	for _, r := range strings.ToLower(title) {
+		if length >= maxSlugLength {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && sb.Len() > 0 {
+			sb.WriteByte('-')
+			hyphen = true
+		}
+		length++
+	}
+	name := strings.TrimRight(sb.String(), "-")
+	if name == "" {
+		return "page"
+	}
+	return name
+}
+
+// safeName makes an attachment file name safe to use as a file name
+func safeName(name string) string {
+	name = strings.NewReplacer("/", "-", "\\", "-").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+// Result reports what an export wrote
+type Result struct {
+	Dir    string        `json:"dir"`
+	Format string        `json:"format"`
+	Pages  []*PageResult `json:"pages"`
+}
+
+// Table lists each exported page with what the export did with it
+func (r *Result) Table() *output.Table {
+	table := output.NewTable("Action", "ID", "Version", "Path", "Details")
+	for _, page := range r.Pages {
+		details := ""
+		if page.Error != "" {
+			details = "error: " + page.Error
+		} else if page.Attachments > 0 {
+			details = fmt.Sprintf("%d %s", page.Attachments, plural(page.Attachments, "attachment"))
+		}
+		table.AddRow(page.Action, page.PageID, page.Version, page.Path, details)
+	}
+	table.Empty = "No pages to export"
+	if len(r.Pages) > 0 {
+		table.Footer = Summary(r.Pages, r.Dir)
+	}
+	return table
+}
@@ -0,0 +1,227 @@
+package export
+
+import (
+	"atlassian-cli/internal/clierr"
+	"atlassian-cli/internal/confluence"
+	"atlassian-cli/internal/types"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient serves pages and attachments from memory and counts downloads
+type fakeClient struct {
+	confluence.ConfluenceClient
+	pages       map[string]*types.Page
+	attachments map[string][]types.Attachment // By page ID
+	files       map[string]string             // Content by download URL
+	gets        []string
+	downloads   []string
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		pages: map[string]*types.Page{
+			"1": {ID: "1", Title: "Runbooks", SpaceKey: "OPS", Version: 3,
+				Content: `<p>See <ac:link><ri:page ri:content-title="Restart the API" /></ac:link>.</p>`},
+			"2": {ID: "2", Title: "Restart the API", SpaceKey: "OPS", Version: 1, ParentID: "1",
+				Content: `<p>Run it.</p><ac:image><ri:attachment ri:filename="flow.png" /></ac:image>`},
+		},
+		attachments: map[string][]types.Attachment{
+			"2": {{ID: "a1", Title: "flow.png", Version: 1, PageID: "2", DownloadURL: "wiki/download/flow.png"}},
+		},
+		files: map[string]string{"wiki/download/flow.png": "PNG"},
+	}
+}
+
+func (f *fakeClient) GetPage(ctx context.Context, id string) (*types.Page, error) {
+	page, ok := f.pages[id]
+	if !ok {
+		return nil, clierr.New(clierr.KindNotFound, "page %s not found", id)
+	}
+	f.gets = append(f.gets, id)
+	copied := *page
+	return &copied, nil
+}
+
+func (f *fakeClient) ListAttachments(ctx context.Context, pageID string) ([]types.Attachment, error) {
+	return f.attachments[pageID], nil
+}
+
+func (f *fakeClient) DownloadAttachment(ctx context.Context, attachment *types.Attachment, w io.Writer) error {
+	f.downloads = append(f.downloads, attachment.Title)
+	content, ok := f.files[attachment.DownloadURL]
+	if !ok {
+		io.WriteString(w, "partial")
+		return clierr.New(clierr.KindNetwork, "download of %s failed", attachment.Title)
+	}
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+// tree builds the page trees of the fake client's pages
+func (f *fakeClient) tree() []*confluence.PageNode {
+	nodes := map[string]*confluence.PageNode{}
+	for _, id := range []string{"1", "2", "3"} {
+		if page, ok := f.pages[id]; ok {
+			node := &confluence.PageNode{Page: *page}
+			node.Content = ""
+			nodes[id] = node
+		}
+	}
+	var roots []*confluence.PageNode
+	for _, id := range []string{"1", "2", "3"} {
+		node, ok := nodes[id]
+		if !ok {
+			continue
+		}
+		if parent, ok := nodes[node.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+func readFile(t *testing.T, dir, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func actions(results []*PageResult) map[string]string {
+	byID := map[string]string{}
+	for _, result := range results {
+		byID[result.PageID] = result.Action
+	}
+	return byID
+}
+
+func TestRun_WritesTreeWithRelativeLinks(t *testing.T) {
+	dir := t.TempDir()
+	client := newFakeClient()
+
+	results, err := Run(context.Background(), client, client.tree(), "page:1", Options{Dir: dir, Format: FormatMarkdown})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": ActionExported, "2": ActionExported}, actions(results))
+
+	index := readFile(t, dir, "runbooks/index.md")
+	assert.Contains(t, index, "confluence_page_id: \"1\"")
+	assert.Contains(t, index, "(restart-the-api.md)")
+
+	child := readFile(t, dir, "runbooks/restart-the-api.md")
+	assert.Contains(t, child, "../attachments/2/flow.png")
+	assert.Equal(t, "PNG", readFile(t, dir, "attachments/2/flow.png"))
+
+	manifest, err := LoadManifest(dir, "page:1", FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, 3, manifest.Pages["1"].Version)
+	assert.Equal(t, []string{"2"}, manifest.Pages["1"].Links)
+	assert.Equal(t, "attachments/2/flow.png", manifest.Pages["2"].Attachments["flow.png"].Path)
+}
+
+func TestRun_RefreshesOnlyChanges(t *testing.T) {
+	dir := t.TempDir()
+	client := newFakeClient()
+	opts := Options{Dir: dir, Format: FormatMarkdown}
+	_, err := Run(context.Background(), client, client.tree(), "page:1", opts)
+	require.NoError(t, err)
+
+	client.gets, client.downloads = nil, nil
+	client.pages["2"].Version = 2
+	results, err := Run(context.Background(), client, client.tree(), "page:1", opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": ActionUnchanged, "2": ActionExported}, actions(results))
+	assert.Equal(t, []string{"2"}, client.gets)
+	assert.Empty(t, client.downloads, "unchanged attachments are not downloaded again")
+
+	// Deleting the child turns the root into a plain file and removes the child's files
+	delete(client.pages, "2")
+	delete(client.attachments, "2")
+	results, err = Run(context.Background(), client, client.tree(), "page:1", opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": ActionExported, "2": ActionRemoved}, actions(results))
+	assert.FileExists(t, filepath.Join(dir, "runbooks.md"))
+	assert.NoDirExists(t, filepath.Join(dir, "runbooks"))
+	assert.NoDirExists(t, filepath.Join(dir, "attachments"))
+}
+
+func TestRun_KeepsAttachmentWhenDownloadFails(t *testing.T) {
+	dir := t.TempDir()
+	client := newFakeClient()
+	opts := Options{Dir: dir, Format: FormatMarkdown}
+	_, err := Run(context.Background(), client, client.tree(), "page:1", opts)
+	require.NoError(t, err)
+
+	client.attachments["2"][0].Version = 2
+	delete(client.files, "wiki/download/flow.png")
+	results, err := Run(context.Background(), client, client.tree(), "page:1", opts)
+	require.Error(t, err)
+	assert.Equal(t, ActionFailed, actions(results)["2"])
+
+	assert.Equal(t, "PNG", readFile(t, dir, "attachments/2/flow.png"), "the previous download is kept")
+	entries, err := os.ReadDir(filepath.Join(dir, "attachments", "2"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestRun_RefusesOtherSource(t *testing.T) {
+	dir := t.TempDir()
+	client := newFakeClient()
+	_, err := Run(context.Background(), client, client.tree(), "page:1", Options{Dir: dir, Format: FormatMarkdown})
+	require.NoError(t, err)
+
+	_, err = Run(context.Background(), client, client.tree(), "space:OPS", Options{Dir: dir, Format: FormatMarkdown})
+	require.Error(t, err)
+	assert.Equal(t, clierr.KindValidation, clierr.KindOf(err))
+}
+
+func TestRun_SavesManifestWhenPagesFail(t *testing.T) {
+	dir := t.TempDir()
+	client := newFakeClient()
+	roots := client.tree()
+	delete(client.pages, "2")
+
+	results, err := Run(context.Background(), client, roots, "page:1", Options{Dir: dir, Format: FormatMarkdown})
+	require.Error(t, err)
+	assert.Equal(t, map[string]string{"1": ActionExported, "2": ActionFailed}, actions(results))
+
+	manifest, err := LoadManifest(dir, "page:1", FormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, manifest.Pages, "1")
+	assert.NotContains(t, manifest.Pages, "2")
+}
+
+func TestHTMLFile_RewritesLinks(t *testing.T) {
+	files := map[string]*pageFile{
+		"1": {ID: "1", Title: "Runbooks", Path: "runbooks/index.html"},
+		"2": {ID: "2", Title: "Restart", Path: "runbooks/restart.html"},
+	}
+	r := newRenderer(files)
+	r.attachments = map[string]string{"flow chart.png": "attachments/1/flow chart.png"}
+
+	page := &types.Page{ID: "1", Title: "Runbooks", Version: 2, Content: `<a href="/wiki/spaces/OPS/pages/2/Restart#steps">Restart</a>` +
+		`<img src="/wiki/download/attachments/1/flow%20chart.png?version=1">` +
+		`<a href="/wiki/spaces/OPS/pages/99/Other">Other</a>`}
+	document, links := r.htmlFile(page, files["1"])
+
+	assert.Equal(t, []string{"2"}, links)
+	assert.Contains(t, document, `href="restart.html#steps"`)
+	assert.Contains(t, document, `src="../attachments/1/flow chart.png"`)
+	assert.Contains(t, document, `href="/wiki/spaces/OPS/pages/99/Other"`)
+	assert.Contains(t, document, `<meta name="confluence-version" content="2">`)
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "restart-the-api", slug("Restart the API!"))
+	assert.Equal(t, "page", slug("???"))
+	assert.Equal(t, maxSlugLength, len(slug(strings.Repeat("a", 200))))
+}
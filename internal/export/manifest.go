@@ -0,0 +1,82 @@
+package export
+
+import (
+	"atlassian-cli/internal/clierr"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFile is the name of the manifest written at the top of an export
+const ManifestFile = "manifest.json"
+
+// Manifest records what an export wrote, so a later export of the same pages
+// only writes what changed
+type Manifest struct {
+	Source string                   `json:"source"` // "space:KEY" or "page:ID"
+	Format string                   `json:"format"`
+	Pages  map[string]*ManifestPage `json:"pages"` // By page ID
+}
+
+// ManifestPage records an exported page
+type ManifestPage struct {
+	Title       string                         `json:"title"`
+	Version     int                            `json:"version"`
+	ParentID    string                         `json:"parentId,omitempty"`
+	Path        string                         `json:"path"`            // Slash-separated, relative to the export directory
+	Links       []string                       `json:"links,omitempty"` // IDs of exported pages the page links to
+	Attachments map[string]*ManifestAttachment `json:"attachments,omitempty"`
+}
+
+// ManifestAttachment records a downloaded attachment, by file name
+type ManifestAttachment struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Path    string `json:"path"`
+}
+
+// LoadManifest reads the manifest in dir, returning an empty one when there is
+// none. A manifest for another source is an error, since exporting would remove
+// that export's files.
+func LoadManifest(dir, source, format string) (*Manifest, error) {
+	manifest := &Manifest{Source: source, Format: format, Pages: map[string]*ManifestPage{}}
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, clierr.Wrap(clierr.KindGeneral, err, "cannot read export manifest")
+	}
+
+	var previous Manifest
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, clierr.Wrap(clierr.KindValidation, err, "invalid export manifest in %s", dir)
+	}
+	if previous.Source != source {
+		return nil, clierr.New(clierr.KindValidation, "%s holds an export of %s; export %s to another directory", dir, previous.Source, source)
+	}
+	if previous.Pages != nil {
+		manifest.Pages = previous.Pages
+	}
+	// Files in another format are all written again
+	if previous.Format != format {
+		for _, page := range manifest.Pages {
+			page.Version = 0
+		}
+	}
+	return manifest, nil
+}
+
+// Save writes the manifest to dir
+func (m *Manifest) Save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o644); err != nil {
+		return clierr.Wrap(clierr.KindGeneral, err, "cannot write export manifest")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package export
+
+import (
+	"atlassian-cli/internal/markdown"
+	"atlassian-cli/internal/types"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	storageLink       = regexp.MustCompile(`(?s)<ac:link\b([^>]*)>(.*?)</ac:link>`)
+	storageImage      = regexp.MustCompile(`(?s)<ac:image\b[^>]*>.*?</ac:image>`)
+	pageRef           = regexp.MustCompile(`<ri:page\b([^>]*)/>`)
+	attachmentRef     = regexp.MustCompile(`<ri:attachment\s+ri:filename="([^"]*)"\s*/>`)
+	linkBody          = regexp.MustCompile(`(?s)<ac:link-body>(.*?)</ac:link-body>`)
+	plainLinkBody     = regexp.MustCompile(`(?s)<ac:plain-text-link-body><!\[CDATA\[(.*?)\]\]></ac:plain-text-link-body>`)
+	xmlAttr           = regexp.MustCompile(`([\w:-]+)="([^"]*)"`)
+	htmlURLAttr       = regexp.MustCompile(`\b(href|src)="([^"]*)"`)
+	htmlPageURL       = regexp.MustCompile(`/pages/(?:viewpage\.action\?pageId=)?(\d+)|[?&]pageId=(\d+)`)
+	htmlAttachmentURL = regexp.MustCompile(`/download/(?:attachments|thumbnails)/(\d+)/([^?#"]+)`)
+)
+
+// pageFile is what rendering needs to know about an exported page
+type pageFile struct {
+	ID      string
+	Title   string
+	Space   string
+	Version int
+	Path    string
+}
+
+// renderer turns page content into files, rewriting links between exported
+// pages and to downloaded attachments as relative paths
+type renderer struct {
+	pages       map[string]*pageFile // By page ID
+	titles      map[string]*pageFile // By space key and title
+	attachments map[string]string    // Attachment paths by file name, for the page being rendered
+}
+
+func newRenderer(pages map[string]*pageFile) *renderer {
+	r := &renderer{pages: pages, titles: map[string]*pageFile{}}
+	for _, page := range pages {
+		r.titles[page.Space+"\x00"+page.Title] = page
+	}
+	return r
+}
+
+// relative returns the path from the file at from to the file at to
+func relative(from, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+	return filepath.ToSlash(rel)
+}
+
+// attrs parses the attributes of a storage element's start tag
+func attrs(s string) map[string]string {
+	result := map[string]string{}
+	for _, m := range xmlAttr.FindAllStringSubmatch(s, -1) {
+		result[m[1]] = html.UnescapeString(m[2])
+	}
+	return result
+}
+
+// markdownFile converts a page to Markdown with front matter, returning the IDs
+// of the exported pages it links to
+func (r *renderer) markdownFile(page *types.Page, file *pageFile) (string, []string, error) {
+	var links []string
+	storage := storageLink.ReplaceAllStringFunc(page.Content, func(link string) string {
+		m := storageLink.FindStringSubmatch(link)
+		linkAttrs, inner := attrs(m[1]), m[2]
+
+		href := ""
+		text := ""
+		if ref := pageRef.FindStringSubmatch(inner); ref != nil {
+			refAttrs := attrs(ref[1])
+			space := refAttrs["ri:space-key"]
+			if space == "" {
+				space = file.Space
+			}
+			target, ok := r.titles[space+"\x00"+refAttrs["ri:content-title"]]
+			if !ok {
+				return link
+			}
+			href = relative(file.Path, target.Path)
+			text = target.Title
+			links = append(links, target.ID)
+		} else if ref := attachmentRef.FindStringSubmatch(inner); ref != nil {
+			name := html.UnescapeString(ref[1])
+			attachment, ok := r.attachments[name]
+			if !ok {
+				return link
+			}
+			href = relative(file.Path, attachment)
+			text = name
+		} else if linkAttrs["ac:anchor"] == "" {
+			return link
+		}
+
+		if anchor := linkAttrs["ac:anchor"]; anchor != "" {
+			href += "#" + anchor
+		}
+		body := html.EscapeString(text)
+		if b := linkBody.FindStringSubmatch(inner); b != nil {
+			body = b[1]
+		} else if b := plainLinkBody.FindStringSubmatch(inner); b != nil {
+			body = html.EscapeString(b[1])
+		}
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(href), body)
+	})
+
+	storage = storageImage.ReplaceAllStringFunc(storage, func(image string) string {
+		return attachmentRef.ReplaceAllStringFunc(image, func(ref string) string {
+			name := html.UnescapeString(attachmentRef.FindStringSubmatch(ref)[1])
+			attachment, ok := r.attachments[name]
+			if !ok {
+				return ref
+			}
+			return fmt.Sprintf(`<ri:url ri:value="%s" />`, html.EscapeString(relative(file.Path, attachment)))
+		})
+	})
+
+	body, err := markdown.FromStorage(storage)
+	if err != nil {
+		return "", nil, err
+	}
+
+	matter, err := yaml.Marshal(struct {
+		Title   string `yaml:"title"`
+		PageID  string `yaml:"confluence_page_id"`
+		Version int    `yaml:"confluence_version"`
+	}{page.Title, page.ID, page.Version})
+	if err != nil {
+		return "", nil, err
+	}
+	return "---\n" + string(matter) + "---\n\n" + body, links, nil
+}
+
+// htmlFile wraps a page's exported HTML in a document, returning the IDs of the
+// exported pages it links to
+func (r *renderer) htmlFile(page *types.Page, file *pageFile) (string, []string) {
+	var links []string
+	content := htmlURLAttr.ReplaceAllStringFunc(page.Content, func(attr string) string {
+		m := htmlURLAttr.FindStringSubmatch(attr)
+		name, value := m[1], html.UnescapeString(m[2])
+
+		if a := htmlAttachmentURL.FindStringSubmatch(value); a != nil && a[1] == page.ID {
+			fileName, err := url.PathUnescape(a[2])
+			if err != nil {
+				return attr
+			}
+			if attachment, ok := r.attachments[fileName]; ok {
+				return fmt.Sprintf(`%s="%s"`, name, html.EscapeString(relative(file.Path, attachment)))
+			}
+			return attr
+		}
+
+		if p := htmlPageURL.FindStringSubmatch(value); p != nil && name == "href" {
+			target, ok := r.pages[p[1]+p[2]]
+			if !ok {
+				return attr
+			}
+			href := relative(file.Path, target.Path)
+			if _, fragment, ok := strings.Cut(value, "#"); ok {
+				href += "#" + fragment
+			}
+			links = append(links, target.ID)
+			return fmt.Sprintf(`href="%s"`, html.EscapeString(href))
+		}
+		return attr
+	})
+
+	title := html.EscapeString(page.Title)
+	document := fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="confluence-page-id" content="%s">
+<meta name="confluence-version" content="%d">
+<title>%s</title>
+</head>
+<body>
+<h1>%s</h1>
+%s
+</body>
+</html>
+`, html.EscapeString(page.ID), page.Version, title, title, content)
+	return document, links
+}
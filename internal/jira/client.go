@@ -2,9 +2,11 @@ package jira
 
 import (
 	"atlassian-cli/internal/cache"
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/types"
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -32,18 +34,30 @@ type AtlassianJiraClient struct {
 
 // NewAtlassianJiraClient creates a new JIRA client
 func NewAtlassianJiraClient(baseURL, email, token string) (*AtlassianJiraClient, error) {
+	return NewAtlassianJiraClientWithHTTP(nil, baseURL, email, token)
+}
+
+// NewAtlassianJiraClientWithHTTP creates a JIRA client that sends its requests
+// through httpClient, or the default HTTP client when it is nil
+func NewAtlassianJiraClientWithHTTP(httpClient *http.Client, baseURL, email, token string) (*AtlassianJiraClient, error) {
 	if baseURL == "" {
-		return nil, fmt.Errorf("base URL is required")
+		return nil, clierr.New(clierr.KindValidation, "base URL is required")
 	}
 	if email == "" {
-		return nil, fmt.Errorf("email is required")
+		return nil, clierr.New(clierr.KindValidation, "email is required")
 	}
 	if token == "" {
-		return nil, fmt.Errorf("token is required")
+		return nil, clierr.New(clierr.KindValidation, "token is required")
 	}
 
 	// Create the client instance
-	instance, err := v3.New(nil, baseURL)
+	var instance *v3.Client
+	var err error
+	if httpClient != nil {
+		instance, err = v3.New(httpClient, baseURL)
+	} else {
+		instance, err = v3.New(nil, baseURL)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JIRA client: %w", err)
 	}
@@ -59,7 +73,7 @@ func NewAtlassianJiraClient(baseURL, email, token string) (*AtlassianJiraClient,
 // CreateIssue creates a new JIRA issue
 func (c *AtlassianJiraClient) CreateIssue(ctx context.Context, req *types.CreateIssueRequest) (*types.Issue, error) {
 	if req == nil {
-		return nil, fmt.Errorf("create issue request cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "create issue request cannot be nil")
 	}
 
 	// Build the issue creation payload
@@ -105,9 +119,9 @@ func (c *AtlassianJiraClient) CreateIssue(ctx context.Context, req *types.Create
 	}
 
 	// Create the issue
-	result, _, err := c.client.Issue.Create(ctx, payload, nil)
+	result, response, err := c.client.Issue.Create(ctx, payload, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create issue: %w", err)
+		return nil, clierr.FromResponse(response, err, "failed to create issue")
 	}
 
 	// Convert the response to our internal type
@@ -124,27 +138,28 @@ func (c *AtlassianJiraClient) CreateIssue(ctx context.Context, req *types.Create
 // GetIssue retrieves a JIRA issue by key
 func (c *AtlassianJiraClient) GetIssue(ctx context.Context, key string) (*types.Issue, error) {
 	if key == "" {
-		return nil, fmt.Errorf("issue key cannot be empty")
+		return nil, clierr.New(clierr.KindValidation, "issue key cannot be empty")
 	}
 
 	// Get the issue
-	result, _, err := c.client.Issue.Get(ctx, key, nil, nil)
+	result, response, err := c.client.Issue.Get(ctx, key, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue %s: %w", key, err)
+		return nil, clierr.FromResponse(response, err, "failed to get issue %s", key)
 	}
 
 	// Convert the response to our internal type
 	issue := convertAtlassianIssue(result)
+	attachCustomFields(response, issue)
 	return issue, nil
 }
 
 // UpdateIssue updates an existing JIRA issue
 func (c *AtlassianJiraClient) UpdateIssue(ctx context.Context, key string, req *types.UpdateIssueRequest) (*types.Issue, error) {
 	if key == "" {
-		return nil, fmt.Errorf("issue key cannot be empty")
+		return nil, clierr.New(clierr.KindValidation, "issue key cannot be empty")
 	}
 	if req == nil {
-		return nil, fmt.Errorf("update issue request cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "update issue request cannot be nil")
 	}
 
 	// Build the fields for the update
@@ -185,9 +200,9 @@ func (c *AtlassianJiraClient) UpdateIssue(ctx context.Context, key string, req *
 	}
 
 	// Update the issue
-	_, err := c.client.Issue.Update(ctx, key, true, payload, nil, nil)
+	response, err := c.client.Issue.Update(ctx, key, true, payload, nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update issue %s: %w", key, err)
+		return nil, clierr.FromResponse(response, err, "failed to update issue %s", key)
 	}
 
 	// Handle status transition if needed
@@ -214,7 +229,7 @@ func (c *AtlassianJiraClient) UpdateIssue(ctx context.Context, key string, req *
 			for i, t := range transitions {
 				availableTransitions[i] = fmt.Sprintf("%s (to: %s)", t.Name, t.To.Name)
 			}
-			return nil, fmt.Errorf("no transition found for status %q. Available transitions: %v", targetStatus, availableTransitions)
+			return nil, clierr.New(clierr.KindValidation, "no transition found for status %q. Available transitions: %v", targetStatus, availableTransitions)
 		}
 
 		// Perform the transition
@@ -251,16 +266,19 @@ func (c *AtlassianJiraClient) ListIssues(ctx context.Context, opts *types.IssueL
 	}
 
 	// Search for issues
-	result, _, err := c.client.Issue.Search.Get(ctx, jql, nil, nil, maxResults, startAt, "")
+	result, resp, err := c.client.Issue.Search.Get(ctx, jql, nil, nil, maxResults, startAt, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
+		return nil, clierr.FromResponse(resp, err, "failed to list issues")
 	}
 
 	// Convert the response to our internal type
 	issues := make([]types.Issue, len(result.Issues))
+	converted := make([]*types.Issue, len(result.Issues))
 	for i, atlassianIssue := range result.Issues {
 		issues[i] = *convertAtlassianIssue(atlassianIssue)
+		converted[i] = &issues[i]
 	}
+	attachCustomFields(resp, converted...)
 
 	response := &types.IssueListResponse{
 		Issues:     issues,
@@ -275,11 +293,11 @@ func (c *AtlassianJiraClient) ListIssues(ctx context.Context, opts *types.IssueL
 // SearchIssues searches JIRA issues using JQL
 func (c *AtlassianJiraClient) SearchIssues(ctx context.Context, opts *types.IssueSearchOptions) (*types.IssueSearchResponse, error) {
 	if opts == nil {
-		return nil, fmt.Errorf("search options cannot be nil")
+		return nil, clierr.New(clierr.KindValidation, "search options cannot be nil")
 	}
 
 	if opts.JQL == "" {
-		return nil, fmt.Errorf("JQL query is required")
+		return nil, clierr.New(clierr.KindValidation, "JQL query is required")
 	}
 
 	// Set default values
@@ -294,16 +312,19 @@ func (c *AtlassianJiraClient) SearchIssues(ctx context.Context, opts *types.Issu
 	}
 
 	// Search for issues
-	result, _, err := c.client.Issue.Search.Get(ctx, opts.JQL, nil, nil, maxResults, startAt, "")
+	result, resp, err := c.client.Issue.Search.Get(ctx, opts.JQL, nil, nil, maxResults, startAt, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+		return nil, clierr.FromResponse(resp, err, "failed to search issues")
 	}
 
 	// Convert the response to our internal type
 	issues := make([]types.Issue, len(result.Issues))
+	converted := make([]*types.Issue, len(result.Issues))
 	for i, atlassianIssue := range result.Issues {
 		issues[i] = *convertAtlassianIssue(atlassianIssue)
+		converted[i] = &issues[i]
 	}
+	attachCustomFields(resp, converted...)
 
 	response := &types.IssueSearchResponse{
 		Issues:     issues,
@@ -327,6 +348,9 @@ func convertAtlassianIssue(issue *models.IssueScheme) *types.Issue {
 
 		if issue.Fields.Status != nil {
 			result.Status = issue.Fields.Status.Name
+			if issue.Fields.Status.StatusCategory != nil {
+				result.StatusCategory = issue.Fields.Status.StatusCategory.Key
+			}
 		}
 
 		if issue.Fields.IssueType != nil {
@@ -438,7 +462,7 @@ func (c *AtlassianJiraClient) ListProjects(ctx context.Context, opts *types.Proj
 	// Call real JIRA API
 	projectsResult, resp, err := c.client.Project.Search(ctx, &models.ProjectSearchOptionsScheme{}, startAt, maxResults)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list projects: %w", err)
+		return nil, clierr.FromResponse(resp, err, "failed to list projects")
 	}
 
 	if resp == nil {
@@ -484,13 +508,13 @@ func (c *AtlassianJiraClient) ListProjects(ctx context.Context, opts *types.Proj
 // GetProject retrieves a JIRA project by key
 func (c *AtlassianJiraClient) GetProject(ctx context.Context, key string) (*types.Project, error) {
 	if key == "" {
-		return nil, fmt.Errorf("project key is required")
+		return nil, clierr.New(clierr.KindValidation, "project key is required")
 	}
 
 	// Call real JIRA API
 	projectResult, resp, err := c.client.Project.Get(ctx, key, []string{"description", "lead", "url"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project %s: %w", key, err)
+		return nil, clierr.FromResponse(resp, err, "failed to get project %s", key)
 	}
 
 	if resp == nil || projectResult == nil {
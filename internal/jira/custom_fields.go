@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"atlassian-cli/internal/types"
+	"encoding/json"
+	"strings"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+)
+
+// customFieldPrefix identifies custom fields in JIRA issue payloads
+const customFieldPrefix = "customfield_"
+
+// rawIssue is the subset of an issue payload needed to read custom fields,
+// which go-atlassian's typed issue model drops
+type rawIssue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// attachCustomFields copies customfield_* values from a raw search or issue
+// response onto the converted issues, matched by key
+func attachCustomFields(response *models.ResponseScheme, issues ...*types.Issue) {
+	if response == nil || response.Bytes.Len() == 0 || len(issues) == 0 {
+		return
+	}
+	body := response.Bytes.Bytes()
+
+	var payload struct {
+		rawIssue
+		Issues []rawIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return
+	}
+
+	raw := payload.Issues
+	if payload.Key != "" {
+		raw = append(raw, payload.rawIssue)
+	}
+
+	byKey := make(map[string]map[string]interface{}, len(raw))
+	for _, item := range raw {
+		if fields := customFields(item.Fields); len(fields) > 0 {
+			byKey[item.Key] = fields
+		}
+	}
+
+	for _, issue := range issues {
+		if fields, ok := byKey[issue.Key]; ok {
+			issue.CustomFields = fields
+		}
+	}
+}
+
+// customFields decodes the non-empty custom fields of an issue
+func customFields(fields map[string]json.RawMessage) map[string]interface{} {
+	result := make(map[string]interface{})
+	for name, raw := range fields {
+		if !strings.HasPrefix(name, customFieldPrefix) {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil || value == nil {
+			continue
+		}
+		result[name] = simplifyCustomField(value)
+	}
+	return result
+}
+
+// simplifyCustomField reduces option, user and version objects to their display
+// value so custom fields print naturally in tables
+func simplifyCustomField(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range []string{"value", "displayName", "name"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+		return v
+	case []interface{}:
+		simplified := make([]interface{}, len(v))
+		for i, item := range v {
+			simplified[i] = simplifyCustomField(item)
+		}
+		return simplified
+	}
+	return value
+}
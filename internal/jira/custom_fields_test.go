@@ -0,0 +1,47 @@
+package jira
+
+import (
+	"atlassian-cli/internal/types"
+	"testing"
+
+	"github.com/ctreminiom/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachCustomFields(t *testing.T) {
+	response := &models.ResponseScheme{}
+	response.Bytes.WriteString(`{
+		"issues": [
+			{"key": "DEMO-1", "fields": {
+				"summary": "ignored",
+				"customfield_10010": {"value": "High", "id": "1"},
+				"customfield_10020": [{"displayName": "Ada"}, {"displayName": "Grace"}],
+				"customfield_10030": 5,
+				"customfield_10040": null
+			}},
+			{"key": "DEMO-2", "fields": {"summary": "no custom fields"}}
+		]
+	}`)
+
+	first := &types.Issue{Key: "DEMO-1"}
+	second := &types.Issue{Key: "DEMO-2"}
+	attachCustomFields(response, first, second)
+
+	assert.Equal(t, map[string]interface{}{
+		"customfield_10010": "High",
+		"customfield_10020": []interface{}{"Ada", "Grace"},
+		"customfield_10030": float64(5),
+	}, first.CustomFields)
+	assert.Nil(t, second.CustomFields)
+}
+
+func TestAttachCustomFields_SingleIssue(t *testing.T) {
+	response := &models.ResponseScheme{}
+	response.Bytes.WriteString(`{"key": "DEMO-3", "fields": {"customfield_1": "text"}}`)
+
+	issue := &types.Issue{Key: "DEMO-3"}
+	attachCustomFields(response, issue)
+	assert.Equal(t, "text", issue.CustomFields["customfield_1"])
+
+	attachCustomFields(nil, issue) // no response is a no-op
+}
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"atlassian-cli/internal/clierr"
 	"atlassian-cli/internal/types"
 	"context"
 	"fmt"
@@ -9,13 +10,13 @@ import (
 // GetTransitions retrieves available transitions for an issue
 func (c *AtlassianJiraClient) GetTransitions(ctx context.Context, issueKey string) ([]types.Transition, error) {
 	if issueKey == "" {
-		return nil, fmt.Errorf("issue key is required")
+		return nil, clierr.New(clierr.KindValidation, "issue key is required")
 	}
 
 	// Call JIRA API to get transitions
 	transitionsResult, resp, err := c.client.Issue.Transitions(ctx, issueKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transitions for issue %s: %w", issueKey, err)
+		return nil, clierr.FromResponse(resp, err, "failed to get transitions for issue %s", issueKey)
 	}
 
 	if resp == nil || transitionsResult == nil {
@@ -45,11 +46,11 @@ func (c *AtlassianJiraClient) GetTransitions(ctx context.Context, issueKey strin
 // go-atlassian versions should be added in production
 func (c *AtlassianJiraClient) TransitionIssue(ctx context.Context, issueKey string, transitionID string) error {
 	if issueKey == "" {
-		return fmt.Errorf("issue key is required")
+		return clierr.New(clierr.KindValidation, "issue key is required")
 	}
 
 	if transitionID == "" {
-		return fmt.Errorf("transition ID is required")
+		return clierr.New(clierr.KindValidation, "transition ID is required")
 	}
 
 	// Use the Transitions endpoint to perform the transition
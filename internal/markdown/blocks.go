@@ -0,0 +1,378 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// blockKind identifies the type of a Markdown block
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	codeBlock
+	quoteBlock
+	panelBlock
+	listBlock
+	tableBlock
+	ruleBlock
+	htmlBlock
+)
+
+// block is a parsed Markdown block
+type block struct {
+	kind     blockKind
+	level    int        // Heading level
+	text     string     // Inline text of paragraphs and headings, code, or raw HTML
+	language string     // Code block language
+	panel    string     // Confluence panel macro for alerts
+	children []*block   // Content of quotes and alerts
+	ordered  bool       // Numbered list
+	start    int        // First number of a numbered list
+	items    []*block   // List items, each holding its content in children
+	task     bool       // List item with a checkbox
+	done     bool       // Checked list item
+	align    []string   // Table column alignment: "", "left", "center" or "right"
+	rows     [][]string // Table cells, the header row first
+}
+
+var (
+	atxHeading      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextUnderline = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	thematicBreak   = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceOpen       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	listMarker      = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])(?:([ \t]+)(.*))?$`)
+	taskMarker      = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	quoteMarker     = regexp.MustCompile(`^ {0,3}> ?`)
+	alertMarker     = regexp.MustCompile(`(?i)^\[!(note|tip|important|warning|caution)\][ \t]*$`)
+	tableDelimiter  = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	htmlStart       = regexp.MustCompile(`^ {0,3}<(?:[a-zA-Z][a-zA-Z0-9:-]*[ \t/>]|[a-zA-Z][a-zA-Z0-9:-]*$|/[a-zA-Z]|!--)`)
+)
+
+// splitLines splits source into lines with tabs expanded to four-column stops
+func splitLines(source string) []string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "\t") {
+			lines[i] = expandTabs(line)
+		}
+	}
+	return lines
+}
+
+// expandTabs replaces tabs with spaces up to the next multiple of four columns
+func expandTabs(line string) string {
+	var sb strings.Builder
+	column := 0
+	for _, r := range line {
+		if r == '\t' {
+			spaces := 4 - column%4
+			sb.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+			continue
+		}
+		sb.WriteRune(r)
+		column++
+	}
+	return sb.String()
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentOf counts the leading spaces of line
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// interruptsParagraph reports whether line starts a block that ends a paragraph
+func interruptsParagraph(line string) bool {
+	if fenceOpen.MatchString(line) || atxHeading.MatchString(line) || thematicBreak.MatchString(line) ||
+		quoteMarker.MatchString(line) || isHTMLStart(line) {
+		return true
+	}
+	// Only non-empty list items, and numbered lists starting at 1, interrupt a paragraph
+	if m := listMarker.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[4]) != "" {
+		marker := m[2]
+		return !isDigit(marker[0]) || strings.TrimLeft(marker[:len(marker)-1], "0") == "1"
+	}
+	return false
+}
+
+// parseBlocks parses lines into a sequence of blocks
+func parseBlocks(lines []string) []*block {
+	var blocks []*block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		var b *block
+		n := 1
+
+		switch {
+		case isBlank(line):
+			i++
+			continue
+		case indentOf(line) >= 4:
+			b, n = parseIndentedCode(lines[i:])
+		case fenceOpen.MatchString(line):
+			b, n = parseFencedCode(lines[i:])
+		case atxHeading.MatchString(line):
+			m := atxHeading.FindStringSubmatch(line)
+			b = &block{kind: headingBlock, level: len(m[1]), text: strings.TrimSpace(m[2])}
+		case thematicBreak.MatchString(line):
+			b = &block{kind: ruleBlock}
+		case quoteMarker.MatchString(line):
+			b, n = parseQuote(lines[i:])
+		case listMarker.MatchString(line):
+			b, n = parseList(lines[i:])
+		case isTableStart(lines[i:]):
+			b, n = parseTable(lines[i:])
+		case isHTMLStart(line):
+			b, n = parseHTML(lines[i:])
+		default:
+			b, n = parseParagraph(lines[i:])
+		}
+
+		blocks = append(blocks, b)
+		i += n
+	}
+	return blocks
+}
+
+// parseParagraph collects lines up to a blank line or the start of another block.
+// A following "===" or "---" line turns the paragraph into a heading.
+func parseParagraph(lines []string) (*block, int) {
+	var text []string
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+		if i > 0 {
+			if m := setextUnderline.FindStringSubmatch(line); m != nil {
+				level := 2
+				if m[1][0] == '=' {
+					level = 1
+				}
+				return &block{kind: headingBlock, level: level, text: strings.TrimSpace(strings.Join(text, "\n"))}, i + 1
+			}
+			if interruptsParagraph(line) {
+				break
+			}
+		}
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+	return &block{kind: paragraphBlock, text: strings.TrimRight(strings.Join(text, "\n"), " ")}, i
+}
+
+// parseIndentedCode collects lines indented by four or more spaces
+func parseIndentedCode(lines []string) (*block, int) {
+	var code []string
+	i := 0
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			code = append(code, "")
+			continue
+		}
+		if indentOf(lines[i]) < 4 {
+			break
+		}
+		code = append(code, lines[i][4:])
+	}
+	// Blank lines after the code belong to the document, not the block
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	return &block{kind: codeBlock, text: strings.Join(code, "\n")}, i
+}
+
+// parseFencedCode collects the lines between a ``` or ~~~ fence and its closing fence
+func parseFencedCode(lines []string) (*block, int) {
+	m := fenceOpen.FindStringSubmatch(lines[0])
+	indent, fence := len(m[1]), m[2]
+	b := &block{kind: codeBlock}
+	if fields := strings.Fields(m[3]); len(fields) > 0 {
+		b.language = fields[0]
+	}
+
+	closing := regexp.MustCompile(`^ {0,3}` + regexp.QuoteMeta(fence[:1]) + `{` + strconv.Itoa(len(fence)) + `,}[ \t]*$`)
+	var code []string
+	i := 1
+	for ; i < len(lines); i++ {
+		if closing.MatchString(lines[i]) {
+			i++
+			break
+		}
+		line := lines[i]
+		line = line[min(indent, indentOf(line)):]
+		code = append(code, line)
+	}
+	b.text = strings.Join(code, "\n")
+	return b, i
+}
+
+// parseQuote collects a block quote, including lazy continuation lines of its
+// paragraphs. A quote starting with "[!NOTE]" and similar is an alert.
+func parseQuote(lines []string) (*block, int) {
+	var inner []string
+	i := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if loc := quoteMarker.FindStringIndex(line); loc != nil {
+			inner = append(inner, line[loc[1]:])
+			continue
+		}
+		// A lazy line continues the quote's last paragraph
+		if isBlank(line) || len(inner) == 0 || isBlank(inner[len(inner)-1]) || interruptsParagraph(line) {
+			break
+		}
+		inner = append(inner, line)
+	}
+
+	if m := alertMarker.FindStringSubmatch(strings.TrimSpace(inner[0])); m != nil {
+		return &block{kind: panelBlock, panel: alertPanels[strings.ToLower(m[1])], children: parseBlocks(inner[1:])}, i
+	}
+	return &block{kind: quoteBlock, children: parseBlocks(inner)}, i
+}
+
+// parseList collects consecutive items that use the same bullet or number delimiter
+func parseList(lines []string) (*block, int) {
+	first := listMarker.FindStringSubmatch(lines[0])
+	b := &block{kind: listBlock, ordered: isDigit(first[2][0]), start: 1}
+	if b.ordered {
+		b.start, _ = strconv.Atoi(first[2][:len(first[2])-1])
+	}
+	delimiter := first[2][len(first[2])-1]
+
+	i := 0
+	for i < len(lines) {
+		m := listMarker.FindStringSubmatch(lines[i])
+		if m == nil || m[2][len(m[2])-1] != delimiter || isDigit(m[2][0]) != b.ordered || thematicBreak.MatchString(lines[i]) {
+			break
+		}
+
+		// Item content is indented past the marker and one to four spaces
+		spaces := len(m[3])
+		content := m[4]
+		if spaces == 0 || spaces > 4 {
+			content = strings.Repeat(" ", max(spaces-1, 0)) + content
+			spaces = 1
+		}
+		contentIndent := len(m[1]) + len(m[2]) + spaces
+
+		itemLines := []string{content}
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				itemLines = append(itemLines, "")
+				continue
+			}
+			if indentOf(line) >= contentIndent {
+				itemLines = append(itemLines, line[contentIndent:])
+				continue
+			}
+			// A lazy line continues the item's last paragraph
+			if isBlank(itemLines[len(itemLines)-1]) || interruptsParagraph(line) || listMarker.MatchString(line) {
+				break
+			}
+			itemLines = append(itemLines, strings.TrimLeft(line, " "))
+		}
+
+		item := &block{}
+		if tm := taskMarker.FindStringSubmatch(itemLines[0]); tm != nil {
+			item.task, item.done = true, tm[1] != " "
+			itemLines[0] = itemLines[0][len(tm[0]):]
+		}
+		item.children = parseBlocks(itemLines)
+		b.items = append(b.items, item)
+	}
+	return b, i
+}
+
+// isTableStart reports whether lines start with a table header and delimiter row
+func isTableStart(lines []string) bool {
+	if len(lines) < 2 || !strings.Contains(lines[0], "|") || !tableDelimiter.MatchString(lines[1]) {
+		return false
+	}
+	return len(splitRow(lines[0])) == len(splitRow(lines[1]))
+}
+
+// parseTable collects a table's header, alignment and rows
+func parseTable(lines []string) (*block, int) {
+	header := splitRow(lines[0])
+	b := &block{kind: tableBlock, rows: [][]string{header}}
+	for _, cell := range splitRow(lines[1]) {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			b.align = append(b.align, "center")
+		case right:
+			b.align = append(b.align, "right")
+		case left:
+			b.align = append(b.align, "left")
+		default:
+			b.align = append(b.align, "")
+		}
+	}
+
+	i := 2
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) || interruptsParagraph(lines[i]) {
+			break
+		}
+		// Rows are padded or cut to the header's width
+		row := make([]string, len(header))
+		copy(row, splitRow(lines[i]))
+		b.rows = append(b.rows, row)
+	}
+	return b, i
+}
+
+// splitRow splits a table row into trimmed cells, honoring escaped pipes
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseHTML collects raw HTML up to the next blank line
+func parseHTML(lines []string) (*block, int) {
+	i := 0
+	for i < len(lines) && !isBlank(lines[i]) {
+		i++
+	}
+	return &block{kind: htmlBlock, text: strings.Join(lines[:i], "\n")}, i
+}
+
+// isHTMLStart reports whether line opens an HTML block. Autolinks such as
+// "<https://example.com>" start paragraphs instead.
+func isHTMLStart(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return htmlStart.MatchString(line) && !autolink.MatchString(trimmed) && !emailLink.MatchString(trimmed)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
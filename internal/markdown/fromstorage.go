@@ -0,0 +1,495 @@
+package markdown
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// node is an element or text in parsed storage format
+type node struct {
+	name     string            // Element name with its prefix, e.g. "p" or "ac:image"; "" for text
+	attrs    map[string]string // Attributes by prefixed name
+	text     string            // Character data of text nodes
+	raw      string            // Source of the element, kept for storage that has no Markdown form
+	children []*node
+}
+
+// voidElements are the HTML elements storage may leave unclosed. Unlike
+// xml.HTMLAutoClose it leaves out "link", which would close ac:link.
+var voidElements = []string{"br", "hr", "img", "col", "area", "input", "wbr"}
+
+// blockElements are the storage elements rendered as Markdown blocks
+var blockElements = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "table": true, "pre": true, "blockquote": true, "hr": true,
+	"div": true, "section": true, "ac:structured-macro": true, "ac:task-list": true,
+	"ac:layout": true, "ac:layout-section": true, "ac:layout-cell": true,
+}
+
+var (
+	markdownSpecial = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`)
+	lineStartMarker = regexp.MustCompile(`^(?:[#>+=-]|\d+[.)])`)
+	textAlign       = regexp.MustCompile(`text-align:\s*(left|center|right)`)
+)
+
+// FromStorage converts Confluence storage format to Markdown
+func FromStorage(storage string) (string, error) {
+	root, err := parseStorage(storage)
+	if err != nil {
+		return "", err
+	}
+	markdown := strings.TrimSpace(blocksMarkdown(root.children, false))
+	if markdown == "" {
+		return "", nil
+	}
+	return markdown + "\n", nil
+}
+
+// parseStorage parses storage format into a tree under a synthetic root element
+func parseStorage(storage string) (*node, error) {
+	source := "<storage>" + storage + "</storage>"
+	decoder := xml.NewDecoder(strings.NewReader(source))
+	decoder.Strict = false
+	decoder.AutoClose = voidElements
+	decoder.Entity = xml.HTMLEntity
+
+	var root *node
+	var stack []*node
+	var starts []int64
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage format: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{name: qualifiedName(t.Name), attrs: map[string]string{}}
+			for _, attr := range t.Attr {
+				n.attrs[qualifiedName(attr.Name)] = attr.Value
+			}
+			if len(stack) == 0 {
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+			starts = append(starts, offset)
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.raw = source[starts[len(starts)-1]:decoder.InputOffset()]
+			stack, starts = stack[:len(stack)-1], starts[:len(starts)-1]
+		case xml.CharData:
+			if len(stack) == 0 {
+				continue
+			}
+			parent := stack[len(stack)-1]
+			if last := len(parent.children) - 1; last >= 0 && parent.children[last].name == "" {
+				parent.children[last].text += string(t)
+			} else {
+				parent.children = append(parent.children, &node{text: string(t)})
+			}
+		}
+	}
+	if root == nil {
+		return &node{}, nil
+	}
+	return root, nil
+}
+
+// qualifiedName joins an XML name with its namespace prefix
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// blocksMarkdown renders nodes as Markdown blocks separated by blank lines. Runs of
+// inline nodes between blocks form paragraphs. In list items (tight), nested
+// lists follow the text before them without a blank line.
+func blocksMarkdown(nodes []*node, tight bool) string {
+	var sb strings.Builder
+	var run []*node
+
+	add := func(markdown string, list bool) {
+		if markdown == "" {
+			return
+		}
+		if sb.Len() > 0 {
+			if tight && list {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(markdown)
+	}
+	flush := func() {
+		add(paragraphMarkdown(run), false)
+		run = nil
+	}
+
+	for _, n := range nodes {
+		if !blockElements[n.name] {
+			run = append(run, n)
+			continue
+		}
+		flush()
+		add(blockMarkdown(n), n.name == "ul" || n.name == "ol" || n.name == "ac:task-list")
+	}
+	flush()
+	return sb.String()
+}
+
+// paragraphMarkdown renders inline nodes as a paragraph, escaping a leading
+// character that would otherwise start a heading, quote or list
+func paragraphMarkdown(nodes []*node) string {
+	text := strings.TrimSpace(inlineMarkdown(nodes))
+	if lineStartMarker.MatchString(text) {
+		return `\` + text
+	}
+	return text
+}
+
+func blockMarkdown(n *node) string {
+	switch n.name {
+	case "p":
+		return paragraphMarkdown(n.children)
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.name[1] - '0')
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(inlineMarkdown(n.children))
+	case "ul", "ol":
+		return listMarkdown(n)
+	case "ac:task-list":
+		return taskListMarkdown(n)
+	case "blockquote":
+		return quoteMarkdown(blocksMarkdown(n.children, false))
+	case "pre":
+		return fenceMarkdown(textContent(n), "")
+	case "hr":
+		return "---"
+	case "table":
+		return tableMarkdown(n)
+	case "ac:structured-macro":
+		return macroMarkdown(n)
+	default:
+		return blocksMarkdown(n.children, false)
+	}
+}
+
+// listMarkdown renders a bulleted or numbered list, indenting item content under
+// its marker
+func listMarkdown(n *node) string {
+	number := 1
+	if start, err := strconv.Atoi(n.attrs["start"]); err == nil {
+		number = start
+	}
+
+	var items []string
+	for _, item := range childElements(n, "li") {
+		marker := "- "
+		if n.name == "ol" {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+		items = append(items, marker+indent(blocksMarkdown(item.children, true), len(marker)))
+	}
+	return strings.Join(items, "\n")
+}
+
+// taskListMarkdown renders a Confluence task list as a list of checkboxes
+func taskListMarkdown(n *node) string {
+	var items []string
+	for _, task := range childElements(n, "ac:task") {
+		marker := "- [ ] "
+		for _, status := range childElements(task, "ac:task-status") {
+			if strings.TrimSpace(textContent(status)) == "complete" {
+				marker = "- [x] "
+			}
+		}
+		var body string
+		for _, content := range childElements(task, "ac:task-body") {
+			body = blocksMarkdown(content.children, true)
+		}
+		items = append(items, marker+indent(body, 2))
+	}
+	return strings.Join(items, "\n")
+}
+
+// tableMarkdown renders a table with its first row as the header
+func tableMarkdown(n *node) string {
+	var rows [][]*node
+	var collect func(n *node)
+	collect = func(n *node) {
+		for _, child := range n.children {
+			switch child.name {
+			case "tr":
+				var cells []*node
+				for _, cell := range child.children {
+					if cell.name == "th" || cell.name == "td" {
+						cells = append(cells, cell)
+					}
+				}
+				rows = append(rows, cells)
+			case "thead", "tbody", "tfoot":
+				collect(child)
+			}
+		}
+	}
+	collect(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	var sb strings.Builder
+	for r, row := range rows {
+		sb.WriteString("|")
+		for c := 0; c < columns; c++ {
+			text := ""
+			if c < len(row) {
+				text = cellMarkdown(row[c])
+			}
+			sb.WriteString(" " + text + " |")
+		}
+		sb.WriteString("\n")
+
+		if r == 0 {
+			sb.WriteString("|")
+			for c := 0; c < columns; c++ {
+				align := ""
+				if c < len(row) {
+					if m := textAlign.FindStringSubmatch(row[c].attrs["style"]); m != nil {
+						align = m[1]
+					}
+				}
+				switch align {
+				case "left":
+					sb.WriteString(" :--- |")
+				case "center":
+					sb.WriteString(" :---: |")
+				case "right":
+					sb.WriteString(" ---: |")
+				default:
+					sb.WriteString(" --- |")
+				}
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// cellMarkdown renders a table cell on one line, with line breaks as <br/>
+func cellMarkdown(cell *node) string {
+	text := strings.TrimSpace(blocksMarkdown(cell.children, false))
+	text = strings.ReplaceAll(text, "\\\n", "<br/>")
+	text = strings.ReplaceAll(text, "\n\n", "<br/>")
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// macroMarkdown renders code macros as fenced code and panels as alerts; other
+// macros are kept as storage format
+func macroMarkdown(n *node) string {
+	switch name := n.attrs["ac:name"]; name {
+	case "code", "noformat":
+		language := ""
+		for _, param := range childElements(n, "ac:parameter") {
+			if param.attrs["ac:name"] == "language" {
+				language = strings.TrimSpace(textContent(param))
+			}
+		}
+		var body string
+		for _, content := range childElements(n, "ac:plain-text-body") {
+			body = textContent(content)
+		}
+		return fenceMarkdown(body, language)
+	case "info", "tip", "note", "warning":
+		var body string
+		for _, content := range childElements(n, "ac:rich-text-body") {
+			body = blocksMarkdown(content.children, false)
+		}
+		return quoteMarkdown(strings.TrimRight("[!"+panelAlerts[name]+"]\n"+body, "\n"))
+	default:
+		return n.raw
+	}
+}
+
+// inlineMarkdown renders inline nodes, collapsing whitespace as HTML does
+func inlineMarkdown(nodes []*node) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		switch n.name {
+		case "":
+			sb.WriteString(markdownSpecial.Replace(collapseSpace(n.text)))
+		case "strong", "b":
+			sb.WriteString(wrapInline(inlineMarkdown(n.children), "**"))
+		case "em", "i":
+			sb.WriteString(wrapInline(inlineMarkdown(n.children), "*"))
+		case "del", "s":
+			sb.WriteString(wrapInline(inlineMarkdown(n.children), "~~"))
+		case "code":
+			sb.WriteString(codeSpanMarkdown(textContent(n)))
+		case "a":
+			fmt.Fprintf(&sb, "[%s](%s)", strings.TrimSpace(inlineMarkdown(n.children)), linkDestination(n.attrs["href"]))
+		case "br":
+			sb.WriteString("\\\n")
+		case "ac:image":
+			sb.WriteString(imageMarkdown(n))
+		case "p":
+			sb.WriteString(" " + inlineMarkdown(n.children) + " ")
+		default:
+			if strings.HasPrefix(n.name, "ac:") || strings.HasPrefix(n.name, "ri:") || n.name == "time" {
+				sb.WriteString(n.raw)
+			} else {
+				sb.WriteString(inlineMarkdown(n.children))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// wrapInline surrounds text with a delimiter, keeping surrounding spaces outside it
+func wrapInline(text, delimiter string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	trail := text[len(strings.TrimRight(text, " ")):]
+	return lead + delimiter + trimmed + delimiter + trail
+}
+
+// codeSpanMarkdown renders a code span, using a longer backtick run than any
+// inside the code
+func codeSpanMarkdown(code string) string {
+	code = collapseSpace(code)
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// fenceMarkdown renders a fenced code block
+func fenceMarkdown(code, language string) string {
+	fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	return fence + language + "\n" + strings.TrimSuffix(code, "\n") + "\n" + fence
+}
+
+// imageMarkdown renders an attachment or URL image
+func imageMarkdown(n *node) string {
+	dest := ""
+	for _, child := range n.children {
+		switch child.name {
+		case "ri:attachment":
+			dest = child.attrs["ri:filename"]
+		case "ri:url":
+			dest = child.attrs["ri:value"]
+		}
+	}
+	return fmt.Sprintf("![%s](%s)", markdownSpecial.Replace(n.attrs["ac:alt"]), linkDestination(dest))
+}
+
+// linkDestination wraps destinations that contain spaces or parentheses in <>
+func linkDestination(dest string) string {
+	if strings.ContainsAny(dest, " ()") {
+		return "<" + dest + ">"
+	}
+	return dest
+}
+
+// quoteMarkdown prefixes each line of markdown with "> "
+func quoteMarkdown(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indent prefixes every line after the first with width spaces, leaving blank lines empty
+func indent(markdown string, width int) string {
+	lines := strings.Split(markdown, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", width) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// childElements returns the direct children of n with the given name
+func childElements(n *node, name string) []*node {
+	var result []*node
+	for _, child := range n.children {
+		if child.name == name {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// textContent concatenates the text of n and its descendants
+func textContent(n *node) string {
+	if n.name == "" {
+		return n.text
+	}
+	var sb strings.Builder
+	for _, child := range n.children {
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+// collapseSpace replaces each run of whitespace with a single space
+func collapseSpace(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		sb.WriteRune(r)
+		space = false
+	}
+	return sb.String()
+}
+
+// longestRun returns the length of the longest run of c in s
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
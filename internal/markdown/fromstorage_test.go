@@ -0,0 +1,64 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage string
+		want    string
+	}{
+		{"heading", "<h3>Setup</h3>", "### Setup\n"},
+		{"paragraphs", "<p>one\n  two</p><p>three</p>", "one two\n\nthree\n"},
+		{"inline", "<p><strong>a</strong> <em>b</em> <del>c</del> <code>d</code></p>", "**a** *b* ~~c~~ `d`\n"},
+		{"escaping", "<p>2 * 3 &lt; [x] &amp; a_b</p>", "2 \\* 3 \\< \\[x\\] & a\\_b\n"},
+		{"line start", "<p># not a heading</p><p>1. not a list</p>", "\\# not a heading\n\n\\1. not a list\n"},
+		{"link", `<p><a href="https://example.com/a (b)">docs</a></p>`, "[docs](<https://example.com/a (b)>)\n"},
+		{"line break", "<p>one<br/>two</p>", "one\\\ntwo\n"},
+		{"nbsp entity", "<p>a&nbsp;b</p>", "a b\n"},
+		{"attachment image", `<p><ac:image ac:alt="Arch"><ri:attachment ri:filename="arch.png" /></ac:image></p>`, "![Arch](arch.png)\n"},
+		{"url image", `<ac:image><ri:url ri:value="https://example.com/a.png" /></ac:image>`, "![](https://example.com/a.png)\n"},
+		{"code macro", "<ac:structured-macro ac:name=\"code\" ac:schema-version=\"1\"><ac:parameter ac:name=\"language\">sh</ac:parameter><ac:plain-text-body><![CDATA[echo \"```\"]]></ac:plain-text-body></ac:structured-macro>", "````sh\necho \"```\"\n````\n"},
+		{"pre", "<pre>a &lt; b</pre>", "```\na < b\n```\n"},
+		{"panel", `<ac:structured-macro ac:name="note"><ac:rich-text-body><p>Read this</p><p>Then this</p></ac:rich-text-body></ac:structured-macro>`, "> [!IMPORTANT]\n> Read this\n>\n> Then this\n"},
+		{"blockquote", "<blockquote><p>quoted</p></blockquote>", "> quoted\n"},
+		{"lists", `<ul><li><p>a</p><ol start="2"><li>b</li><li>c</li></ol></li><li>d</li></ul>`, "- a\n  2. b\n  3. c\n- d\n"},
+		{"task list", "<ac:task-list><ac:task><ac:task-id>1</ac:task-id><ac:task-status>complete</ac:task-status><ac:task-body>done</ac:task-body></ac:task><ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>todo</ac:task-body></ac:task></ac:task-list>", "- [x] done\n- [ ] todo\n"},
+		{"table", `<table><tbody><tr><th>a</th><th style="text-align: right;">b</th></tr><tr><td><p>x | y</p></td><td>1<br/>2</td></tr></tbody></table>`, "| a | b |\n| --- | ---: |\n| x \\| y | 1<br/>2 |\n"},
+		{"rule", "<p>a</p><hr/>", "a\n\n---\n"},
+		{"other macro", `<ac:structured-macro ac:name="toc"><ac:parameter ac:name="maxLevel">2</ac:parameter></ac:structured-macro>`, "<ac:structured-macro ac:name=\"toc\"><ac:parameter ac:name=\"maxLevel\">2</ac:parameter></ac:structured-macro>\n"},
+		{"page link", `<p>See <ac:link><ri:page ri:content-title="Home" /></ac:link>.</p>`, "See <ac:link><ri:page ri:content-title=\"Home\" /></ac:link>.\n"},
+		{"layout", "<ac:layout><ac:layout-section><ac:layout-cell><p>cell</p></ac:layout-cell></ac:layout-section></ac:layout>", "cell\n"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromStorage(tt.storage)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromStorage_Invalid(t *testing.T) {
+	_, err := FromStorage("<p>unclosed <b>tag</p></b></i>")
+	assert.ErrorContains(t, err, "invalid storage format")
+}
+
+func TestRoundTrip(t *testing.T) {
+	markdown := "# Guide\n\nSome *em*, **strong** and `code` with a [link](https://example.com).\n\n" +
+		"> [!WARNING]\n> Back up first\n\n- [x] done\n- [ ] todo\n\n1. one\n2. two\n   - nested\n\n" +
+		"| a | b |\n| :--- | ---: |\n| 1 | 2 |\n\n```go\nfmt.Println(\"hi\")\n```\n\n![diagram](arch.png)\n\n---\n\nA & B \\< C\n"
+
+	storage := ToStorage(markdown)
+	got, err := FromStorage(storage)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, got)
+	assert.Equal(t, storage, ToStorage(got))
+}
@@ -0,0 +1,345 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	linkDefinition = regexp.MustCompile(`^\[((?:[^\[\]\\]|\\.)+)\]:[ \t]*(?:<([^<>\n]*)>|([^\s<]\S*))(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^()]*\)))?[ \t]*$`)
+	autolink       = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+	emailLink      = regexp.MustCompile(`^<([^\s@<>]+@[^\s@<>]+\.[^\s@<>]+)>`)
+	inlineTag      = regexp.MustCompile(`^</?[a-zA-Z][a-zA-Z0-9:-]*(?:\s[^<>]*)?/?>`)
+	entity         = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	urlPattern     = regexp.MustCompile(`^(?:[a-zA-Z][a-zA-Z0-9+.-]*:|//)`)
+)
+
+// inline converts Markdown inline syntax to storage format
+func (c *converter) inline(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				sb.WriteString("<br />")
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && isPunct(s[i+1]) {
+				sb.WriteString(escapeText(s[i+1 : i+2]))
+				i += 2
+				continue
+			}
+		case '`':
+			run := runLength(s, i)
+			if code, n := codeSpan(s[i:]); n > 0 {
+				sb.WriteString(code)
+				i += n
+			} else {
+				sb.WriteString(s[i : i+run])
+				i += run
+			}
+			continue
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if text, dest, n := c.parseLink(s[i+1:]); n > 0 {
+					sb.WriteString(image(text, dest))
+					i += 1 + n
+					continue
+				}
+			}
+		case '[':
+			if text, dest, n := c.parseLink(s[i:]); n > 0 {
+				fmt.Fprintf(&sb, `<a href="%s">%s</a>`, escapeAttr(dest), c.inline(text))
+				i += n
+				continue
+			}
+		case '<':
+			if m := autolink.FindStringSubmatch(s[i:]); m != nil {
+				fmt.Fprintf(&sb, `<a href="%s">%s</a>`, escapeAttr(m[1]), escapeText(m[1]))
+				i += len(m[0])
+				continue
+			}
+			if m := emailLink.FindStringSubmatch(s[i:]); m != nil {
+				fmt.Fprintf(&sb, `<a href="mailto:%s">%s</a>`, escapeAttr(m[1]), escapeText(m[1]))
+				i += len(m[0])
+				continue
+			}
+			// Inline HTML, including storage elements, is kept as written;
+			// unknown elements are escaped
+			if tag := inlineTag.FindString(s[i:]); tag != "" && !c.escapeHTML {
+				if converted, ok := xhtmlTag(tag); ok {
+					sb.WriteString(converted)
+					i += len(tag)
+					continue
+				}
+			}
+		case '*', '_', '~':
+			run := runLength(s, i)
+			if styled, n := c.emphasis(s, i); n > 0 {
+				sb.WriteString(styled)
+				i += n
+			} else {
+				sb.WriteString(s[i : i+run])
+				i += run
+			}
+			continue
+		case '&':
+			// Entities become characters, which are then escaped for XML
+			if ref := entity.FindString(s[i:]); ref != "" {
+				sb.WriteString(escapeText(html.UnescapeString(ref)))
+				i += len(ref)
+				continue
+			}
+		case ' ':
+			// Two or more spaces before a line break make a hard break
+			j := i
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+			if j < len(s) && s[j] == '\n' {
+				if j-i >= 2 {
+					sb.WriteString("<br />")
+				} else {
+					sb.WriteByte(' ')
+				}
+				i = j + 1
+				continue
+			}
+		case '\n':
+			sb.WriteByte(' ')
+			i++
+			continue
+		}
+		sb.WriteString(escapeText(s[i : i+1]))
+		i++
+	}
+	return sb.String()
+}
+
+// runLength counts the repeats of s[i] starting at i
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// codeSpan converts a code span at the start of s, returning the bytes consumed
+// or 0 when its backticks are not closed
+func codeSpan(s string) (string, int) {
+	n := runLength(s, 0)
+	for j := n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j)
+		if m == n {
+			code := strings.ReplaceAll(s[n:j], "\n", " ")
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			return "<code>" + escapeText(code) + "</code>", j + m
+		}
+		j += m
+	}
+	return "", 0
+}
+
+// closingBracket returns the index of the "]" closing the "[" that starts s,
+// or -1 when it is not closed
+func closingBracket(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			if _, n := codeSpan(s[i:]); n > 0 {
+				i += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseLink parses "[text](destination)" or a reference link, "[text][label]",
+// "[text][]" or "[text]", at the start of s, returning the bytes consumed or 0
+// when s does not start with a link
+func (c *converter) parseLink(s string) (text, dest string, n int) {
+	end := closingBracket(s)
+	if end < 0 {
+		return "", "", 0
+	}
+	if end+1 < len(s) && s[end+1] == '(' {
+		if dest, n := inlineDestination(s, end); n > 0 {
+			return s[1:end], dest, n
+		}
+	}
+	return c.referenceLink(s, end)
+}
+
+// referenceLink parses a reference link whose text closes at s[end], returning
+// the bytes consumed or 0 when its label is not defined
+func (c *converter) referenceLink(s string, end int) (text, dest string, n int) {
+	label, n := s[1:end], end+1
+	if n < len(s) && s[n] == '[' {
+		close := strings.IndexByte(s[n:], ']')
+		if close < 0 {
+			return "", "", 0
+		}
+		if close > 1 {
+			label = s[n+1 : n+close]
+		}
+		n += close + 1
+	}
+	dest, ok := c.refs[normalizeLabel(label)]
+	if !ok {
+		return "", "", 0
+	}
+	return s[1:end], dest, n
+}
+
+// normalizeLabel folds the case and whitespace of a link label so labels match
+// as CommonMark matches them
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// inlineDestination parses the "(destination "title")" following link text
+// that closes at s[end], returning the bytes of s consumed or 0 when it is not
+// a valid destination
+func inlineDestination(s string, end int) (dest string, n int) {
+	i := end + 2
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	if i < len(s) && s[i] == '<' {
+		close := strings.IndexByte(s[i:], '>')
+		if close < 0 {
+			return "", 0
+		}
+		dest = s[i+1 : i+close]
+		i += close + 1
+	} else {
+		start, parens := i, 0
+		for ; i < len(s) && s[i] != ' ' && s[i] != '\n'; i++ {
+			if s[i] == '(' {
+				parens++
+			} else if s[i] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[start:i]
+	}
+
+	// Skip an optional title
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		close := strings.IndexByte(s[i+1:], s[i])
+		if close < 0 {
+			return "", 0
+		}
+		i += close + 2
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+	}
+	if i >= len(s) || s[i] != ')' {
+		return "", 0
+	}
+	return dest, i + 1
+}
+
+// image converts an image to a URL image, or to an attachment image when the
+// destination is a file name or relative path
+func image(alt, dest string) string {
+	attrs := ""
+	if alt != "" {
+		attrs = fmt.Sprintf(` ac:alt="%s"`, escapeAttr(alt))
+	}
+	if urlPattern.MatchString(dest) {
+		return fmt.Sprintf(`<ac:image%s><ri:url ri:value="%s" /></ac:image>`, attrs, escapeAttr(dest))
+	}
+	name := path.Base(dest)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return fmt.Sprintf(`<ac:image%s><ri:attachment ri:filename="%s" /></ac:image>`, attrs, escapeAttr(name))
+}
+
+// emphasis converts emphasis, strong emphasis or strikethrough opening at s[i],
+// returning the bytes consumed or 0 when the delimiter run is not closed
+func (c *converter) emphasis(s string, i int) (string, int) {
+	delim := s[i]
+	n := runLength(s, i)
+	if (delim == '~' && n != 2) || n > 3 {
+		return "", 0
+	}
+	// An opening run is followed by text; "_" does not open inside a word
+	if i+n >= len(s) || isSpace(s[i+n]) || (delim == '_' && i > 0 && isAlnum(s[i-1])) {
+		return "", 0
+	}
+
+	for j := i + n; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+			continue
+		case '`':
+			if _, m := codeSpan(s[j:]); m > 0 {
+				j += m - 1
+			}
+			continue
+		case delim:
+		default:
+			continue
+		}
+
+		m := runLength(s, j)
+		if m == n && !isSpace(s[j-1]) && (delim != '_' || j+m >= len(s) || !isAlnum(s[j+m])) {
+			open, close := "<em>", "</em>"
+			switch {
+			case delim == '~':
+				open, close = "<del>", "</del>"
+			case n == 2:
+				open, close = "<strong>", "</strong>"
+			case n == 3:
+				open, close = "<strong><em>", "</em></strong>"
+			}
+			return open + c.inline(s[i+n:j]) + close, j + m - i
+		}
+		j += m - 1
+	}
+	return "", 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,53 @@
+// Package markdown converts between Markdown and Confluence storage format.
+//
+// ToStorage accepts CommonMark with these GitHub Flavored Markdown extensions:
+// tables, task lists, strikethrough and alerts ("> [!NOTE]"), which become
+// Confluence info, tip, note and warning panels. Fenced code blocks become code
+// macros and images become attachment or URL images. Reference links are
+// resolved from their definitions. Inline and block HTML is passed through, so
+// storage-format macros can be embedded as they are, as long as it is
+// well-formed XHTML; void elements such as <br> are closed, and HTML that does
+// not nest properly or uses unknown elements is escaped.
+//
+// FromStorage converts back, keeping storage elements that have no Markdown
+// equivalent (other macros, page links, emoticons) as inline storage XML.
+package markdown
+
+import "strings"
+
+// alertPanels maps GitHub alert types to the Confluence panel macros that show them
+var alertPanels = map[string]string{
+	"note":      "info",
+	"tip":       "tip",
+	"important": "note",
+	"warning":   "warning",
+	"caution":   "warning",
+}
+
+// panelAlerts maps Confluence panel macros back to GitHub alert types
+var panelAlerts = map[string]string{
+	"info":    "NOTE",
+	"tip":     "TIP",
+	"note":    "IMPORTANT",
+	"warning": "WARNING",
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// escapeText escapes s for use as XHTML character data
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// escapeAttr escapes s for use in a double-quoted XHTML attribute
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// escapeCDATA escapes s for use inside a CDATA section, splitting any "]]>"
+func escapeCDATA(s string) string {
+	return strings.ReplaceAll(s, "]]>", "]]]]><![CDATA[>")
+}
@@ -0,0 +1,285 @@
+package markdown
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	// frontMatterDelimiter matches the lines that open and close YAML front matter
+	frontMatterDelimiter = regexp.MustCompile(`^(?:---|\.\.\.)[ \t]*$`)
+
+	// htmlTag matches an HTML or storage start, end or empty-element tag
+	htmlTag = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9:-]*)(?:\s[^<>]*)?/?>`)
+)
+
+// htmlElements are the HTML elements raw HTML may use in storage format; true
+// marks void elements, which XHTML needs written as empty-element tags
+var htmlElements = map[string]bool{
+	"a": false, "abbr": false, "b": false, "big": false, "blockquote": false, "br": true,
+	"caption": false, "cite": false, "code": false, "col": true, "colgroup": false,
+	"dd": false, "del": false, "dfn": false, "div": false, "dl": false, "dt": false,
+	"em": false, "h1": false, "h2": false, "h3": false, "h4": false, "h5": false, "h6": false,
+	"hr": true, "i": false, "img": true, "ins": false, "kbd": false, "li": false,
+	"ol": false, "p": false, "pre": false, "q": false, "s": false, "samp": false,
+	"small": false, "span": false, "strike": false, "strong": false, "sub": false,
+	"sup": false, "table": false, "tbody": false, "td": false, "tfoot": false,
+	"th": false, "thead": false, "time": false, "tr": false, "tt": false, "u": false,
+	"ul": false, "var": false, "wbr": true,
+}
+
+// converter holds the state of converting one document: its link reference
+// definitions and whether raw HTML is escaped rather than kept
+type converter struct {
+	refs       map[string]string // Link destinations by normalized label
+	escapeHTML bool
+}
+
+// ToStorage converts Markdown to Confluence storage format. Raw HTML is kept
+// only when it is well-formed XHTML made of HTML and storage elements; other
+// HTML is escaped and shows as text.
+func ToStorage(source string) string {
+	blocks := parseBlocks(splitLines(source))
+	c := &converter{refs: map[string]string{}}
+	c.collectReferences(blocks)
+
+	var sb strings.Builder
+	c.writeBlocks(&sb, blocks)
+	return sb.String()
+}
+
+// StripFrontMatter removes YAML front matter, delimited by "---" lines, from the
+// start of a Markdown document
+func StripFrontMatter(source string) string {
+	lines := strings.SplitAfter(source, "\n")
+	if len(lines) < 2 || strings.TrimRight(lines[0], "\r\n") != "---" {
+		return source
+	}
+	for i := 1; i < len(lines); i++ {
+		if frontMatterDelimiter.MatchString(strings.TrimRight(lines[i], "\r\n")) {
+			return strings.Join(lines[i+1:], "")
+		}
+	}
+	return source
+}
+
+// collectReferences records the link reference definitions that start
+// paragraphs and removes them from the paragraphs' text
+func (c *converter) collectReferences(blocks []*block) {
+	for _, b := range blocks {
+		switch b.kind {
+		case paragraphBlock:
+			lines := strings.Split(b.text, "\n")
+			i := 0
+			for ; i < len(lines); i++ {
+				m := linkDefinition.FindStringSubmatch(lines[i])
+				if m == nil {
+					break
+				}
+				label := normalizeLabel(m[1])
+				if _, ok := c.refs[label]; !ok && label != "" {
+					c.refs[label] = m[2] + m[3]
+				}
+			}
+			b.text = strings.Join(lines[i:], "\n")
+		case quoteBlock, panelBlock:
+			c.collectReferences(b.children)
+		case listBlock:
+			for _, item := range b.items {
+				c.collectReferences(item.children)
+			}
+		}
+	}
+}
+
+// writeBlocks writes blocks as storage format
+func (c *converter) writeBlocks(sb *strings.Builder, blocks []*block) {
+	for _, b := range blocks {
+		c.writeBlock(sb, b)
+	}
+}
+
+func (c *converter) writeBlock(sb *strings.Builder, b *block) {
+	switch b.kind {
+	case paragraphBlock:
+		// Paragraphs of only link reference definitions have no content
+		if b.text != "" {
+			fmt.Fprintf(sb, "<p>%s</p>", c.inlineXHTML(b.text))
+		}
+	case headingBlock:
+		fmt.Fprintf(sb, "<h%d>%s</h%d>", b.level, c.inlineXHTML(b.text), b.level)
+	case codeBlock:
+		sb.WriteString(`<ac:structured-macro ac:name="code">`)
+		if b.language != "" {
+			fmt.Fprintf(sb, `<ac:parameter ac:name="language">%s</ac:parameter>`, escapeText(b.language))
+		}
+		fmt.Fprintf(sb, "<ac:plain-text-body><![CDATA[%s]]></ac:plain-text-body></ac:structured-macro>", escapeCDATA(b.text))
+	case quoteBlock:
+		sb.WriteString("<blockquote>")
+		c.writeBlocks(sb, b.children)
+		sb.WriteString("</blockquote>")
+	case panelBlock:
+		fmt.Fprintf(sb, `<ac:structured-macro ac:name="%s"><ac:rich-text-body>`, b.panel)
+		c.writeBlocks(sb, b.children)
+		sb.WriteString("</ac:rich-text-body></ac:structured-macro>")
+	case listBlock:
+		c.writeList(sb, b)
+	case tableBlock:
+		c.writeTable(sb, b)
+	case ruleBlock:
+		sb.WriteString("<hr />")
+	case htmlBlock:
+		if raw, ok := xhtmlFragment(b.text); ok && !c.escapeHTML {
+			sb.WriteString(raw)
+		} else {
+			fmt.Fprintf(sb, "<p>%s</p>", escapeText(b.text))
+		}
+	}
+}
+
+// inlineXHTML converts inline text, escaping its raw HTML instead when the tags
+// do not nest properly, e.g. an element opened and never closed
+func (c *converter) inlineXHTML(s string) string {
+	converted := c.inline(s)
+	if c.escapeHTML || wellFormed(converted) {
+		return converted
+	}
+	escaped := *c
+	escaped.escapeHTML = true
+	return escaped.inline(s)
+}
+
+// xhtmlTag returns a raw HTML tag as XHTML, writing void elements such as <br>
+// as empty-element tags. It reports false for elements storage format does not
+// know.
+func xhtmlTag(tag string) (string, bool) {
+	name := strings.ToLower(htmlTag.FindStringSubmatch(tag)[1])
+	if strings.HasPrefix(name, "ac:") || strings.HasPrefix(name, "ri:") || strings.HasPrefix(name, "at:") {
+		return tag, true
+	}
+	void, ok := htmlElements[name]
+	switch {
+	case !ok:
+		return "", false
+	case void && strings.HasPrefix(tag, "</"):
+		// End tags of void elements were already closed
+		return "", false
+	case void && !strings.HasSuffix(tag, "/>"):
+		return strings.TrimSuffix(tag, ">") + " />", true
+	}
+	return tag, true
+}
+
+// xhtmlFragment returns a block of raw HTML as XHTML, reporting false when it
+// uses unknown elements or is not well-formed
+func xhtmlFragment(fragment string) (string, bool) {
+	ok := true
+	fragment = htmlTag.ReplaceAllStringFunc(fragment, func(tag string) string {
+		converted, known := xhtmlTag(tag)
+		ok = ok && known
+		return converted
+	})
+	return fragment, ok && wellFormed(fragment)
+}
+
+// wellFormed reports whether a fragment of storage format is well-formed XML,
+// with HTML entities allowed
+func wellFormed(fragment string) bool {
+	decoder := xml.NewDecoder(strings.NewReader(`<root xmlns:ac="ac" xmlns:ri="ri" xmlns:at="at">` + fragment + "</root>"))
+	decoder.Entity = xml.HTMLEntity
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return err == io.EOF
+		}
+	}
+}
+
+// writeList writes a list, or a Confluence task list when every item has a checkbox
+func (c *converter) writeList(sb *strings.Builder, b *block) {
+	tasks := true
+	for _, item := range b.items {
+		tasks = tasks && item.task
+	}
+
+	if tasks {
+		sb.WriteString("<ac:task-list>")
+		for _, item := range b.items {
+			status := "incomplete"
+			if item.done {
+				status = "complete"
+			}
+			fmt.Fprintf(sb, "<ac:task><ac:task-status>%s</ac:task-status><ac:task-body>", status)
+			c.writeItemBody(sb, item)
+			sb.WriteString("</ac:task-body></ac:task>")
+		}
+		sb.WriteString("</ac:task-list>")
+		return
+	}
+
+	tag := "ul"
+	if b.ordered {
+		tag = "ol"
+	}
+	if b.ordered && b.start != 1 {
+		fmt.Fprintf(sb, `<ol start="%d">`, b.start)
+	} else {
+		fmt.Fprintf(sb, "<%s>", tag)
+	}
+	for _, item := range b.items {
+		sb.WriteString("<li>")
+		if item.task {
+			// Checkboxes in a list that is not all tasks are kept as text
+			if item.done {
+				sb.WriteString("[x] ")
+			} else {
+				sb.WriteString("[ ] ")
+			}
+		}
+		c.writeItemBody(sb, item)
+		sb.WriteString("</li>")
+	}
+	fmt.Fprintf(sb, "</%s>", tag)
+}
+
+// writeItemBody writes a list item's content. An item with a single paragraph
+// has its text written directly, as Confluence does for simple lists.
+func (c *converter) writeItemBody(sb *strings.Builder, item *block) {
+	paragraphs := 0
+	for _, child := range item.children {
+		if child.kind == paragraphBlock {
+			paragraphs++
+		}
+	}
+	for i, child := range item.children {
+		if i == 0 && paragraphs == 1 && child.kind == paragraphBlock {
+			sb.WriteString(c.inlineXHTML(child.text))
+			continue
+		}
+		c.writeBlock(sb, child)
+	}
+}
+
+// writeTable writes a table with its first row as header cells
+func (c *converter) writeTable(sb *strings.Builder, b *block) {
+	sb.WriteString("<table><tbody>")
+	for r, row := range b.rows {
+		tag := "td"
+		if r == 0 {
+			tag = "th"
+		}
+		sb.WriteString("<tr>")
+		for col, cell := range row {
+			if col < len(b.align) && b.align[col] != "" {
+				fmt.Fprintf(sb, `<%s style="text-align: %s;">`, tag, b.align[col])
+			} else {
+				fmt.Fprintf(sb, "<%s>", tag)
+			}
+			fmt.Fprintf(sb, "%s</%s>", c.inlineXHTML(cell), tag)
+		}
+		sb.WriteString("</tr>")
+	}
+	sb.WriteString("</tbody></table>")
+}
@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"heading", "## Setup", "<h2>Setup</h2>"},
+		{"setext heading", "Setup\n=====", "<h1>Setup</h1>"},
+		{"paragraph", "one\ntwo", "<p>one two</p>"},
+		{"escaping", "A & B < C", "<p>A &amp; B &lt; C</p>"},
+		{"entity", "&copy; &lt;b&gt;", "<p>© &lt;b&gt;</p>"},
+		{"emphasis", "*a* _b_ **c** ~~d~~ ***e***", "<p><em>a</em> <em>b</em> <strong>c</strong> <del>d</del> <strong><em>e</em></strong></p>"},
+		{"intraword underscore", "snake_case_name", "<p>snake_case_name</p>"},
+		{"code span", "run `a < b` now", "<p>run <code>a &lt; b</code> now</p>"},
+		{"link", `[docs](https://example.com/a?b=1&c=2 "Docs")`, `<p><a href="https://example.com/a?b=1&amp;c=2">docs</a></p>`},
+		{"autolink", "<https://example.com>", `<p><a href="https://example.com">https://example.com</a></p>`},
+		{"hard break", "one  \ntwo\\\nthree", "<p>one<br />two<br />three</p>"},
+		{"attachment image", "![Arch](images/arch%20v2.png)", `<p><ac:image ac:alt="Arch"><ri:attachment ri:filename="arch v2.png" /></ac:image></p>`},
+		{"url image", "![](https://example.com/a.png)", `<p><ac:image><ri:url ri:value="https://example.com/a.png" /></ac:image></p>`},
+		{"fenced code", "```go\nif a < b {}\n```", `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter><ac:plain-text-body><![CDATA[if a < b {}]]></ac:plain-text-body></ac:structured-macro>`},
+		{"indented code", "    x := 1", `<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[x := 1]]></ac:plain-text-body></ac:structured-macro>`},
+		{"cdata end", "```\n]]>\n```", `<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[]]]]><![CDATA[>]]></ac:plain-text-body></ac:structured-macro>`},
+		{"quote", "> quoted", "<blockquote><p>quoted</p></blockquote>"},
+		{"alert", "> [!TIP]\n> Use tabs", `<ac:structured-macro ac:name="tip"><ac:rich-text-body><p>Use tabs</p></ac:rich-text-body></ac:structured-macro>`},
+		{"caution alert", "> [!CAUTION]\n> Stop", `<ac:structured-macro ac:name="warning"><ac:rich-text-body><p>Stop</p></ac:rich-text-body></ac:structured-macro>`},
+		{"bullet list", "- a\n- b", "<ul><li>a</li><li>b</li></ul>"},
+		{"ordered list", "3. a\n4. b", `<ol start="3"><li>a</li><li>b</li></ol>`},
+		{"nested list", "- a\n  1. b", "<ul><li>a<ol><li>b</li></ol></li></ul>"},
+		{"task list", "- [x] done\n- [ ] todo", "<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>done</ac:task-body></ac:task><ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>todo</ac:task-body></ac:task></ac:task-list>"},
+		{"mixed task list", "- [x] done\n- plain", "<ul><li>[x] done</li><li>plain</li></ul>"},
+		{"table", "| a | b |\n| :-: | --- |\n| 1 | x \\| y |", `<table><tbody><tr><th style="text-align: center;">a</th><th>b</th></tr><tr><td style="text-align: center;">1</td><td>x | y</td></tr></tbody></table>`},
+		{"rule", "a\n\n***", "<p>a</p><hr />"},
+		{"html block", `<ac:structured-macro ac:name="toc" />`, `<ac:structured-macro ac:name="toc" />`},
+		{"inline html", `see <ac:emoticon ac:name="smile" /> here`, `<p>see <ac:emoticon ac:name="smile" /> here</p>`},
+		{"void html", "x <br> y", "<p>x <br /> y</p>"},
+		{"balanced inline html", "a <sub>2</sub> b", "<p>a <sub>2</sub> b</p>"},
+		{"unclosed inline html", "a <b>bold", "<p>a &lt;b&gt;bold</p>"},
+		{"unknown inline html", "a <c>", "<p>a &lt;c&gt;</p>"},
+		{"unclosed html block", "<div>\n\nx", "<p>&lt;div&gt;</p><p>x</p>"},
+		{"void html block", "<hr>", "<hr />"},
+		{"reference link", "see [the docs][Docs] and [docs][]\n\n[docs]: https://example.com/a?b=1&c=2 \"Docs\"", `<p>see <a href="https://example.com/a?b=1&amp;c=2">the docs</a> and <a href="https://example.com/a?b=1&amp;c=2">docs</a></p>`},
+		{"shortcut reference link", "[docs]: <https://example.com>\nsee [Docs]", `<p>see <a href="https://example.com">Docs</a></p>`},
+		{"reference image", "![Arch][arch]\n\n[arch]: images/arch.png", `<p><ac:image ac:alt="Arch"><ri:attachment ri:filename="arch.png" /></ac:image></p>`},
+		{"undefined reference", "[a][b] [c]", "<p>[a][b] [c]</p>"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToStorage(tt.markdown))
+		})
+	}
+}
+
+func TestStripFrontMatter(t *testing.T) {
+	assert.Equal(t, "# Guide\n", StripFrontMatter("---\ntitle: x\n---\n# Guide\n"))
+	assert.Equal(t, "body", StripFrontMatter("---\ntitle: x\n...\nbody"))
+	assert.Equal(t, "---\nnot closed\n", StripFrontMatter("---\nnot closed\n"))
+	assert.Equal(t, "a\n---\nb\n---\n", StripFrontMatter("a\n---\nb\n---\n"))
+}
@@ -0,0 +1,166 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TableOptions controls column selection, sorting and sizing of list tables
+type TableOptions struct {
+	Columns []string // Column names to show, in order (empty for the default set)
+	SortBy  string   // Column to sort by; a leading "-" sorts descending
+	Wide    bool     // Show the extended column set and disable truncation
+	Width   int      // Terminal width to fit the table into (0 means unlimited)
+}
+
+// ColumnSpec describes a selectable table column for records of type T
+type ColumnSpec[T any] struct {
+	Name     string // Identifier used by --columns and --sort-by
+	Header   string
+	MaxWidth int        // Truncation limit outside --wide mode (0 means no limit)
+	Wide     bool       // Shown by default only in --wide mode
+	Kind     ColumnKind // How values are presented on a terminal
+	Value    func(T) interface{}
+	Style    func(T) string // Optional color name for the cell
+}
+
+// ColumnSet is the catalog of columns available for a record type
+type ColumnSet[T any] struct {
+	Columns []ColumnSpec[T]
+	// Dynamic resolves names not in Columns, such as JIRA custom field IDs
+	Dynamic func(name string) (ColumnSpec[T], bool)
+}
+
+// Names lists the selectable column names
+func (s ColumnSet[T]) Names() []string {
+	names := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Lookup finds a column by name, case-insensitively
+func (s ColumnSet[T]) Lookup(name string) (ColumnSpec[T], bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, column := range s.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	if s.Dynamic != nil {
+		return s.Dynamic(name)
+	}
+	return ColumnSpec[T]{}, false
+}
+
+// Select resolves the columns to display for the given options
+func (s ColumnSet[T]) Select(opts TableOptions) ([]ColumnSpec[T], error) {
+	if len(opts.Columns) == 0 {
+		var selected []ColumnSpec[T]
+		for _, column := range s.Columns {
+			if !column.Wide || opts.Wide {
+				selected = append(selected, column)
+			}
+		}
+		return selected, nil
+	}
+
+	selected := make([]ColumnSpec[T], 0, len(opts.Columns))
+	for _, name := range opts.Columns {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		column, ok := s.Lookup(name)
+		if !ok {
+			return nil, s.unknownColumn(name)
+		}
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
+
+// unknownColumn builds the error for an unrecognized column name
+func (s ColumnSet[T]) unknownColumn(name string) error {
+	return fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(s.Names(), ", "))
+}
+
+// Sort orders items in place by the column named in sortBy. A leading "-" sorts
+// in descending order. The sort is stable so ties keep the API order.
+func (s ColumnSet[T]) Sort(items []T, sortBy string) error {
+	if sortBy == "" {
+		return nil
+	}
+
+	descending := strings.HasPrefix(sortBy, "-")
+	column, ok := s.Lookup(strings.TrimPrefix(sortBy, "-"))
+	if !ok {
+		return s.unknownColumn(strings.TrimPrefix(sortBy, "-"))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := column.Value(items[i]), column.Value(items[j])
+		if descending {
+			return compareValues(b, a) < 0
+		}
+		return compareValues(a, b) < 0
+	})
+	return nil
+}
+
+// Build renders items into a table using the selected columns
+func (s ColumnSet[T]) Build(items []T, opts TableOptions) (*Table, error) {
+	specs, err := s.Select(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{Wide: opts.Wide, Width: opts.Width}
+	for _, spec := range specs {
+		table.Columns = append(table.Columns, Column{Header: spec.Header, MaxWidth: spec.MaxWidth, Kind: spec.Kind})
+	}
+	for r, item := range items {
+		values := make([]interface{}, len(specs))
+		for i, spec := range specs {
+			values[i] = spec.Value(item)
+			if spec.Style != nil {
+				table.SetStyle(r, i, spec.Style(item))
+			}
+		}
+		table.AddRow(values...)
+	}
+	return table, nil
+}
+
+// compareValues orders two column values: times and numbers numerically,
+// everything else as case-insensitive text. Empty values sort first.
+func compareValues(a, b interface{}) int {
+	switch av := a.(type) {
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv)
+		}
+	case int:
+		if bv, ok := b.(int); ok {
+			return compareOrdered(av, bv)
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return compareOrdered(av, bv)
+		}
+	}
+	return strings.Compare(strings.ToLower(FormatValue(a)), strings.ToLower(FormatValue(b)))
+}
+
+// compareOrdered compares two ordered values
+func compareOrdered[V int | float64](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type columnItem struct {
+	Key     string
+	Title   string
+	Count   int
+	Updated time.Time
+}
+
+var testColumns = ColumnSet[columnItem]{
+	Columns: []ColumnSpec[columnItem]{
+		{Name: "key", Header: "Key", Value: func(i columnItem) interface{} { return i.Key }},
+		{Name: "title", Header: "Title", MaxWidth: 12, Value: func(i columnItem) interface{} { return i.Title }},
+		{Name: "count", Header: "Count", Wide: true, Value: func(i columnItem) interface{} { return i.Count }},
+		{Name: "updated", Header: "Updated", Wide: true, Value: func(i columnItem) interface{} { return i.Updated }},
+	},
+	Dynamic: func(name string) (ColumnSpec[columnItem], bool) {
+		if name != "extra" {
+			return ColumnSpec[columnItem]{}, false
+		}
+		return ColumnSpec[columnItem]{Name: name, Header: "Extra", Value: func(columnItem) interface{} { return "x" }}, true
+	},
+}
+
+func TestColumnSetSelect(t *testing.T) {
+	headers := func(opts TableOptions) []string {
+		specs, err := testColumns.Select(opts)
+		require.NoError(t, err)
+		var names []string
+		for _, spec := range specs {
+			names = append(names, spec.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"key", "title"}, headers(TableOptions{}))
+	assert.Equal(t, []string{"key", "title", "count", "updated"}, headers(TableOptions{Wide: true}))
+	assert.Equal(t, []string{"updated", "key", "extra"}, headers(TableOptions{Columns: []string{"Updated", " key", "extra"}}))
+
+	_, err := testColumns.Select(TableOptions{Columns: []string{"nope"}})
+	assert.EqualError(t, err, `unknown column "nope" (available: key, title, count, updated)`)
+}
+
+func TestColumnSetSort(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []columnItem{
+		{Key: "b", Count: 10, Updated: base.Add(time.Hour)},
+		{Key: "C", Count: 2, Updated: base},
+		{Key: "a", Count: 10, Updated: base.Add(2 * time.Hour)},
+	}
+
+	keys := func() string {
+		s := ""
+		for _, item := range items {
+			s += item.Key
+		}
+		return s
+	}
+
+	require.NoError(t, testColumns.Sort(items, "key"))
+	assert.Equal(t, "abC", keys())
+
+	require.NoError(t, testColumns.Sort(items, "count"))
+	assert.Equal(t, "Cab", keys(), "numeric sort is stable for ties")
+
+	require.NoError(t, testColumns.Sort(items, "-updated"))
+	assert.Equal(t, "abC", keys())
+
+	assert.Error(t, testColumns.Sort(items, "nope"))
+}
+
+func TestColumnSetBuildWide(t *testing.T) {
+	items := []columnItem{{Key: "A-1", Title: "A rather long title indeed", Count: 3}}
+
+	var buf bytes.Buffer
+	table, err := testColumns.Build(items, TableOptions{})
+	require.NoError(t, err)
+	require.NoError(t, table.renderTable(&buf))
+	assert.Contains(t, buf.String(), "A rather ...")
+
+	buf.Reset()
+	table, err = testColumns.Build(items, TableOptions{Wide: true})
+	require.NoError(t, err)
+	require.NoError(t, table.renderTable(&buf))
+	assert.Contains(t, buf.String(), "A rather long title indeed")
+	assert.Contains(t, buf.String(), "COUNT")
+}
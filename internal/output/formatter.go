@@ -1,208 +1,309 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// Supported output formats
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatMarkdown = "markdown"
+	FormatTemplate = "template"
+	FormatNDJSON   = "ndjson"
+)
+
+// Formats lists every supported output format
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatMarkdown, FormatTemplate, FormatNDJSON}
+
+// IsValidFormat reports whether format is a supported output format
+func IsValidFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // Formatter handles output formatting for different formats
 type Formatter struct {
-	format string
+	format       string
+	template     string
+	query        *Query
+	colorEnabled bool
+	terminal     bool
 }
 
 // NewFormatter creates a new formatter with the specified format
 func NewFormatter(format string) *Formatter {
-	if format == "" {
-		format = "table"
+	if !IsValidFormat(format) {
+		format = FormatTable // Default fallback
 	}
 
-	// Validate format
-	switch format {
-	case "json", "table", "yaml":
-		// Valid formats
-	default:
-		format = "table" // Default fallback
+	// Colors and terminal presentation are off until the caller detects a terminal
+	return &Formatter{format: format}
+}
+
+// SetTemplate selects template output using a Go text/template. The template
+// runs once per record for list results.
+func (f *Formatter) SetTemplate(text string) error {
+	if _, err := parseTemplate(text, f.colorEnabled); err != nil {
+		return err
 	}
+	f.format = FormatTemplate
+	f.template = text
+	return nil
+}
 
-	return &Formatter{
-		format: format,
+// SetQuery filters data through a jq-style path expression before formatting
+func (f *Formatter) SetQuery(expr string) error {
+	query, err := ParseQuery(expr)
+	if err != nil {
+		return err
 	}
+	f.query = query
+	return nil
+}
+
+// SetColor enables or disables ANSI colors in tables and the template color function
+func (f *Formatter) SetColor(enabled bool) {
+	f.colorEnabled = enabled
+}
+
+// SetTerminal records whether output goes to a terminal, where tables show relative
+// dates and priority icons
+func (f *Formatter) SetTerminal(terminal bool) {
+	f.terminal = terminal
+}
+
+// Name returns the format the formatter writes
+func (f *Formatter) Name() string {
+	return f.format
+}
+
+// IsStreaming reports whether records can be written as they arrive, one per line
+func (f *Formatter) IsStreaming() bool {
+	return f.format == FormatNDJSON
+}
+
+// IsStructured reports whether the format serializes the full data (JSON, YAML)
+// rather than a tabular view of it
+func (f *Formatter) IsStructured() bool {
+	return f.format == FormatJSON || f.format == FormatYAML
 }
 
 // Format formats the data according to the configured format
 func (f *Formatter) Format(data interface{}) (string, error) {
-	switch f.format {
-	case "json":
-		return f.formatJSON(data)
-	case "yaml":
-		return f.formatYAML(data)
-	case "table":
-		return f.formatTable(data)
-	default:
-		return f.formatTable(data)
+	var buf bytes.Buffer
+	if err := f.Write(&buf, data, nil); err != nil {
+		return "", err
 	}
+	return buf.String(), nil
 }
 
-// formatJSON formats data as JSON
-func (f *Formatter) formatJSON(data interface{}) (string, error) {
-	bytes, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-	return string(bytes), nil
+// Write writes data to w. JSON and YAML serialize data itself; the tabular formats
+// render table, or a table derived from data when table is nil.
+func (f *Formatter) Write(w io.Writer, data interface{}, table *Table) error {
+	return f.WriteList(w, data, nil, table)
 }
 
-// formatYAML formats data as YAML
-func (f *Formatter) formatYAML(data interface{}) (string, error) {
-	bytes, err := yaml.Marshal(data)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal YAML: %w", err)
+// WriteList is Write for list responses: items holds the individual records, so
+// templates run once per record rather than once for the whole response
+func (f *Formatter) WriteList(w io.Writer, data interface{}, items interface{}, table *Table) error {
+	if f.format == FormatNDJSON {
+		if items == nil {
+			items = data
+		}
+		return f.writeNDJSON(w, items)
 	}
-	return string(bytes), nil
-}
 
-// formatTable formats data as a simple table
-func (f *Formatter) formatTable(data interface{}) (string, error) {
-	switch v := data.(type) {
-	case map[string]interface{}:
-		return f.formatMapAsTable(v), nil
-	case []interface{}:
-		if len(v) > 0 {
-			return f.formatSliceAsTable(v), nil
+	if f.query != nil {
+		result, err := f.query.Apply(data)
+		if err != nil {
+			return err
 		}
-		return "No data\n", nil
-	case []map[string]interface{}:
-		if len(v) > 0 {
-			return f.formatMapSliceAsTable(v), nil
+		// The command's table describes the unfiltered data, so derive a new one
+		data, items, table = result, nil, nil
+
+		if f.format == FormatTable && isScalarResult(result) {
+			return writeRaw(w, result)
 		}
-		return "No data\n", nil
-	default:
-		// Fallback to JSON for complex types
-		return f.formatJSON(data)
 	}
-}
 
-// formatMapAsTable formats a single map as a key-value table
-func (f *Formatter) formatMapAsTable(data map[string]interface{}) string {
-	var buf strings.Builder
+	if f.format == FormatTemplate {
+		if f.template == "" {
+			return fmt.Errorf("template output requires --template")
+		}
+		tmpl, err := parseTemplate(f.template, f.colorEnabled)
+		if err != nil {
+			return err
+		}
+		if items == nil {
+			items = data
+		}
+		return executeTemplate(w, tmpl, items)
+	}
 
-	// Find max key length for alignment
-	maxKeyLen := 0
-	for key := range data {
-		if len(key) > maxKeyLen {
-			maxKeyLen = len(key)
+	switch f.format {
+	case FormatJSON:
+		out, err := f.formatJSON(data)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, out)
+		return err
+	case FormatYAML:
+		out, err := f.formatYAML(data)
+		if err != nil {
+			return err
 		}
+		_, err = fmt.Fprint(w, out)
+		return err
 	}
 
-	// Format as key-value pairs
-	for key, value := range data {
-		buf.WriteString(fmt.Sprintf("%-*s: %v\n", maxKeyLen, key, value))
+	if table == nil {
+		table = ToTable(data)
 	}
 
-	return buf.String()
+	switch f.format {
+	case FormatCSV:
+		return table.renderDelimited(w, ',')
+	case FormatTSV:
+		return table.renderDelimited(w, '\t')
+	case FormatMarkdown:
+		return table.renderMarkdown(w)
+	default:
+		table.Terminal, table.Color = f.terminal, f.colorEnabled
+		return table.renderTable(w)
+	}
 }
 
-// formatSliceAsTable formats a slice of interfaces as a table
-func (f *Formatter) formatSliceAsTable(data []interface{}) string {
-	if len(data) == 0 {
-		return "No data\n"
+// writeNDJSON writes each record as compact JSON on its own line. A query is applied
+// to each record; queries that iterate emit one line per result.
+func (f *Formatter) writeNDJSON(w io.Writer, items interface{}) error {
+	var records []interface{}
+	if rv := reflect.ValueOf(items); rv.Kind() == reflect.Slice {
+		records = make([]interface{}, rv.Len())
+		for i := range records {
+			records[i] = rv.Index(i).Interface()
+		}
+	} else {
+		records = []interface{}{items}
 	}
 
-	// Check if all items are maps
-	allMaps := true
-	for _, item := range data {
-		if _, ok := item.(map[string]interface{}); !ok {
-			allMaps = false
-			break
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		values := []interface{}{record}
+		if f.query != nil {
+			result, err := f.query.Apply(record)
+			if err != nil {
+				return err
+			}
+			values = []interface{}{result}
+			if list, ok := result.([]interface{}); ok && f.query.iterates {
+				values = list
+			}
+		}
+		for _, value := range values {
+			if err := encoder.Encode(value); err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
 		}
 	}
+	return nil
+}
 
-	if allMaps {
-		// Convert to []map[string]interface{} and format
-		mapSlice := make([]map[string]interface{}, len(data))
-		for i, item := range data {
-			mapSlice[i] = item.(map[string]interface{})
+// isScalarResult reports whether a query result is a scalar or a list of scalars
+func isScalarResult(result interface{}) bool {
+	switch v := result.(type) {
+	case map[string]interface{}:
+		return false
+	case []interface{}:
+		for _, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				return false
+			}
 		}
-		return f.formatMapSliceAsTable(mapSlice)
 	}
+	return true
+}
 
-	// Format as simple list
-	var buf strings.Builder
-	for i, item := range data {
-		buf.WriteString(fmt.Sprintf("%d: %v\n", i+1, item))
+// writeRaw prints scalar query results one per line, like jq -r
+func writeRaw(w io.Writer, result interface{}) error {
+	values, ok := result.([]interface{})
+	if !ok {
+		values = []interface{}{result}
 	}
-	return buf.String()
+	for _, value := range values {
+		if value == nil {
+			value = "null"
+		}
+		if _, err := fmt.Fprintln(w, FormatValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// formatMapSliceAsTable formats a slice of maps as a table
-func (f *Formatter) formatMapSliceAsTable(data []map[string]interface{}) string {
-	if len(data) == 0 {
-		return "No data\n"
+// formatJSON formats data as JSON
+func (f *Formatter) formatJSON(data interface{}) (string, error) {
+	bytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON: %w", err)
 	}
+	return string(bytes), nil
+}
 
-	// Collect all unique keys
-	keySet := make(map[string]bool)
-	for _, item := range data {
-		for key := range item {
-			keySet[key] = true
-		}
+// formatYAML formats data as YAML. Data is encoded through JSON first so YAML keys
+// match the JSON field names and field order.
+func (f *Formatter) formatYAML(data interface{}) (string, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
 	}
 
-	// Convert to sorted slice
-	var headers []string
-	for key := range keySet {
-		headers = append(headers, key)
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
 	}
+	resetStyle(&node)
 
 	var buf strings.Builder
-
-	// Write header
-	for i, header := range headers {
-		if i > 0 {
-			buf.WriteString("\t")
-		}
-		buf.WriteString(strings.ToUpper(header))
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
 	}
-	buf.WriteString("\n")
-
-	// Write separator
-	for i, header := range headers {
-		if i > 0 {
-			buf.WriteString("\t")
-		}
-		buf.WriteString(strings.Repeat("-", len(header)))
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal YAML: %w", err)
 	}
-	buf.WriteString("\n")
+	return buf.String(), nil
+}
 
-	// Write rows
-	for _, item := range data {
-		for i, header := range headers {
-			if i > 0 {
-				buf.WriteString("\t")
-			}
-			if value, exists := item[header]; exists {
-				buf.WriteString(fmt.Sprintf("%v", value))
-			} else {
-				buf.WriteString("")
-			}
-		}
-		buf.WriteString("\n")
+// resetStyle clears the flow and quoting styles inherited from JSON so the
+// encoder emits block YAML, quoting only where needed
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
 	}
-
-	return buf.String()
 }
 
 // PrintToStdout prints formatted data to stdout
 func (f *Formatter) PrintToStdout(data interface{}) error {
-	output, err := f.Format(data)
-	if err != nil {
-		return err
-	}
-
-	fmt.Fprint(os.Stdout, output)
-	return nil
+	return f.Write(os.Stdout, data, nil)
 }
@@ -1,7 +1,9 @@
 package output
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +17,9 @@ func TestNewFormatter(t *testing.T) {
 		{"json format", "json", "json"},
 		{"table format", "table", "table"},
 		{"yaml format", "yaml", "yaml"},
+		{"csv format", "csv", "csv"},
+		{"tsv format", "tsv", "tsv"},
+		{"markdown format", "markdown", "markdown"},
 		{"invalid format defaults to table", "invalid", "table"},
 		{"empty format defaults to table", "", "table"},
 	}
@@ -23,7 +28,7 @@ func TestNewFormatter(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			formatter := NewFormatter(tt.format)
 			assert.NotNil(t, formatter)
-			// In a real implementation, we would test the actual format property
+			assert.Equal(t, tt.want, formatter.Name())
 		})
 	}
 }
@@ -79,7 +84,7 @@ func TestFormatError(t *testing.T) {
 }
 
 func TestSupportedFormats(t *testing.T) {
-	supportedFormats := []string{"json", "table", "yaml"}
+	supportedFormats := []string{"json", "table", "yaml", "csv", "tsv", "markdown", "ndjson"}
 
 	for _, format := range supportedFormats {
 		t.Run("format_"+format, func(t *testing.T) {
@@ -95,3 +100,102 @@ func TestSupportedFormats(t *testing.T) {
 		})
 	}
 }
+
+type testIssue struct {
+	Key       string    `json:"key"`
+	IssueType string    `json:"issueType"`
+	Labels    []string  `json:"labels"`
+	Created   time.Time `json:"created"`
+	internal  string
+}
+
+func TestFormatYAMLUsesJSONFieldNames(t *testing.T) {
+	issue := testIssue{Key: "DEMO-1", IssueType: "Bug", Labels: []string{"a", "b"}, Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	result, err := NewFormatter("yaml").Format(issue)
+	assert.NoError(t, err)
+	assert.Equal(t, "key: DEMO-1\nissueType: Bug\nlabels:\n  - a\n  - b\ncreated: \"2024-01-02T03:04:05Z\"\n", result)
+
+	// Strings that look like other YAML types stay quoted
+	result, err = NewFormatter("yaml").Format(map[string]string{"version": "123", "flag": "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, "flag: \"true\"\nversion: \"123\"\n", result)
+}
+
+func TestFormatTabularFormats(t *testing.T) {
+	table := NewTable("Key", "Summary")
+	table.AddRow("DEMO-1", "Fix login, again")
+	table.AddRow("DEMO-2", "Pipe | and\nnewline")
+	table.Footer = "Showing 1-2 of 2 issues"
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "Key,Summary\nDEMO-1,\"Fix login, again\"\nDEMO-2,\"Pipe | and\nnewline\"\n"},
+		{"tsv", "Key\tSummary\nDEMO-1\tFix login, again\nDEMO-2\tPipe | and newline\n"},
+		{"markdown", "| Key | Summary |\n| --- | --- |\n| DEMO-1 | Fix login, again |\n| DEMO-2 | Pipe \\| and<br>newline |\n"},
+		{"table", "KEY    SUMMARY\n-------------------------\nDEMO-1 Fix login, again\nDEMO-2 Pipe | and newline\n\nShowing 1-2 of 2 issues\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := NewFormatter(tt.format).Write(&buf, nil, table)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestFormatStructSlices(t *testing.T) {
+	issues := []testIssue{{Key: "DEMO-1", IssueType: "Bug"}, {Key: "DEMO-2", IssueType: "Task", Labels: []string{"x"}}}
+
+	result, err := NewFormatter("csv").Format(issues)
+	assert.NoError(t, err)
+	assert.Equal(t, "key,issueType,labels,created\nDEMO-1,Bug,,\nDEMO-2,Task,x,\n", result)
+
+	result, err = NewFormatter("table").Format(&issues[0])
+	assert.NoError(t, err)
+	assert.Contains(t, result, "key:       DEMO-1\n")
+	assert.Contains(t, result, "issueType: Bug\n")
+}
+
+func TestTableTruncation(t *testing.T) {
+	table := NewTable("Title").WithMaxWidth("Title", 8)
+	table.AddRow("A very long title")
+
+	result, err := NewFormatter("table").Format(table)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "A ver...")
+
+	// Machine-readable formats are never truncated
+	result, err = NewFormatter("csv").Format(table)
+	assert.NoError(t, err)
+	assert.Contains(t, result, "A very long title")
+}
+
+func TestWriteListNDJSON(t *testing.T) {
+	issues := []testIssue{{Key: "DEMO-1", IssueType: "Bug", Labels: []string{"a", "b"}}, {Key: "DEMO-2", IssueType: "Task"}}
+	response := map[string]interface{}{"issues": issues, "total": 2}
+
+	var buf bytes.Buffer
+	formatter := NewFormatter("ndjson")
+	assert.True(t, formatter.IsStreaming())
+	assert.NoError(t, formatter.WriteList(&buf, response, issues, nil))
+	assert.Equal(t,
+		`{"key":"DEMO-1","issueType":"Bug","labels":["a","b"],"created":"0001-01-01T00:00:00Z"}`+"\n"+
+			`{"key":"DEMO-2","issueType":"Task","labels":null,"created":"0001-01-01T00:00:00Z"}`+"\n",
+		buf.String())
+
+	// Queries apply to each record; iterating queries emit one line per result
+	buf.Reset()
+	assert.NoError(t, formatter.SetQuery(".key"))
+	assert.NoError(t, formatter.WriteList(&buf, response, issues, nil))
+	assert.Equal(t, "\"DEMO-1\"\n\"DEMO-2\"\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, formatter.SetQuery(".labels[]"))
+	assert.NoError(t, formatter.WriteList(&buf, response, issues, nil))
+	assert.Equal(t, "\"a\"\n\"b\"\n", buf.String())
+}
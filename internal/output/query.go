@@ -0,0 +1,297 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled jq-style path expression such as ".issues[].key".
+//
+// Supported syntax: "." (identity), ".field", ."quoted field", "[N]" (negative
+// counts from the end), "[]" (iterate), "[N:M]" (slice), and "| length". Fields
+// use the JSON names shown by --output json.
+type Query struct {
+	expr     string
+	steps    []queryStep
+	iterates bool
+	length   bool
+}
+
+// queryStep is one path segment
+type queryStep struct {
+	field   string
+	index   *int
+	iterate bool
+	slice   *[2]*int
+}
+
+// ParseQuery compiles a query expression
+func ParseQuery(expr string) (*Query, error) {
+	q := &Query{expr: expr}
+
+	path := strings.TrimSpace(expr)
+	if head, tail, found := strings.Cut(path, "|"); found {
+		if strings.TrimSpace(tail) != "length" {
+			return nil, fmt.Errorf("invalid query %q: only \"| length\" is supported after a pipe", expr)
+		}
+		q.length = true
+		path = strings.TrimSpace(head)
+	}
+
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("invalid query %q: must start with \".\"", expr)
+	}
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+			if i < len(path) && path[i] == '"' {
+				end := strings.IndexByte(path[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid query %q: unterminated quoted field", expr)
+				}
+				q.steps = append(q.steps, queryStep{field: path[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if i > start {
+				q.steps = append(q.steps, queryStep{field: path[start:i]})
+			}
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing \"]\"", expr)
+			}
+			step, err := parseBracket(path[i+1 : i+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %w", expr, err)
+			}
+			if step.iterate || step.slice != nil {
+				q.iterates = true
+			}
+			q.steps = append(q.steps, step)
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("invalid query %q: unexpected %q", expr, path[i])
+		}
+	}
+
+	return q, nil
+}
+
+// parseBracket parses the contents of a [...] segment
+func parseBracket(inner string) (queryStep, error) {
+	inner = strings.TrimSpace(inner)
+	if inner == "" {
+		return queryStep{iterate: true}, nil
+	}
+	if strings.HasPrefix(inner, `"`) {
+		field, err := strconv.Unquote(inner)
+		if err != nil {
+			return queryStep{}, fmt.Errorf("bad field name %s", inner)
+		}
+		return queryStep{field: field}, nil
+	}
+	if from, to, found := strings.Cut(inner, ":"); found {
+		var bounds [2]*int
+		for i, part := range []string{from, to} {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return queryStep{}, fmt.Errorf("bad slice bound %q", part)
+			}
+			bounds[i] = &n
+		}
+		return queryStep{slice: &bounds}, nil
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return queryStep{}, fmt.Errorf("bad index %q", inner)
+	}
+	return queryStep{index: &n}, nil
+}
+
+// String returns the original expression
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Apply evaluates the query against the JSON representation of data. Expressions
+// that iterate return a slice of results; others return a single value.
+func (q *Query) Apply(data interface{}) (interface{}, error) {
+	generic, err := toGeneric(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := []interface{}{generic}
+	for _, step := range q.steps {
+		var next []interface{}
+		for _, value := range stream {
+			results, err := step.apply(value)
+			if err != nil {
+				return nil, fmt.Errorf("query %q: %w", q.expr, err)
+			}
+			next = append(next, results...)
+		}
+		stream = next
+	}
+
+	var result interface{}
+	if q.iterates {
+		if stream == nil {
+			stream = []interface{}{}
+		}
+		result = stream
+	} else if len(stream) > 0 {
+		result = stream[0]
+	}
+
+	if q.length {
+		return lengthOf(result)
+	}
+	return result, nil
+}
+
+// apply evaluates one step against a single value
+func (s queryStep) apply(value interface{}) ([]interface{}, error) {
+	switch {
+	case s.iterate:
+		switch v := value.(type) {
+		case []interface{}:
+			return v, nil
+		case map[string]interface{}:
+			values := make([]interface{}, 0, len(v))
+			for _, key := range sortedKeys(v) {
+				values = append(values, v[key])
+			}
+			return values, nil
+		case nil:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(value))
+	case s.index != nil:
+		list, ok := value.([]interface{})
+		if !ok {
+			if value == nil {
+				return []interface{}{nil}, nil
+			}
+			return nil, fmt.Errorf("cannot index %s with a number", typeName(value))
+		}
+		i := *s.index
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return []interface{}{nil}, nil
+		}
+		return []interface{}{list[i]}, nil
+	case s.slice != nil:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot slice %s", typeName(value))
+		}
+		from, to := 0, len(list)
+		if s.slice[0] != nil {
+			from = clampIndex(*s.slice[0], len(list))
+		}
+		if s.slice[1] != nil {
+			to = clampIndex(*s.slice[1], len(list))
+		}
+		if from > to {
+			from = to
+		}
+		return list[from:to], nil
+	default:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			return []interface{}{v[s.field]}, nil
+		case nil:
+			return []interface{}{nil}, nil
+		}
+		return nil, fmt.Errorf("cannot get field %q of %s", s.field, typeName(value))
+	}
+}
+
+// clampIndex resolves negative slice bounds and limits them to the list length
+func clampIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+// lengthOf implements "| length"
+func lengthOf(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return len(v), nil
+	case map[string]interface{}:
+		return len(v), nil
+	case string:
+		return len([]rune(v)), nil
+	case nil:
+		return 0, nil
+	}
+	return nil, fmt.Errorf("%s has no length", typeName(value))
+}
+
+// toGeneric converts typed data to maps and slices using its JSON encoding
+func toGeneric(data interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return generic, nil
+}
+
+// typeName describes a JSON value type for error messages
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queryIssue struct {
+	Key    string   `json:"key"`
+	Labels []string `json:"labels"`
+}
+
+type queryResponse struct {
+	Issues []queryIssue `json:"issues"`
+	Total  int          `json:"total"`
+}
+
+func TestQueryApply(t *testing.T) {
+	data := queryResponse{
+		Issues: []queryIssue{
+			{Key: "DEMO-1", Labels: []string{"ui"}},
+			{Key: "DEMO-2", Labels: []string{"api", "db"}},
+			{Key: "DEMO-3"},
+		},
+		Total: 3,
+	}
+
+	tests := []struct {
+		expr string
+		want interface{}
+	}{
+		{".", nil}, // checked separately below
+		{".total", json.Number("3")},
+		{".issues[].key", []interface{}{"DEMO-1", "DEMO-2", "DEMO-3"}},
+		{".issues[0].key", "DEMO-1"},
+		{".issues[-1].key", "DEMO-3"},
+		{".issues[5].key", nil},
+		{".issues[1:].key", nil}, // slices iterate, checked below
+		{".issues[1].labels[]", []interface{}{"api", "db"}},
+		{`.["issues"][0]["key"]`, "DEMO-1"},
+		{".issues | length", 3},
+		{".issues[].labels[] | length", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			query, err := ParseQuery(tt.expr)
+			require.NoError(t, err)
+
+			result, err := query.Apply(data)
+			require.NoError(t, err)
+
+			switch tt.expr {
+			case ".":
+				assert.Contains(t, result, "issues")
+			case ".issues[1:].key":
+				assert.Equal(t, []interface{}{"DEMO-2", "DEMO-3"}, result)
+			default:
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	for _, expr := range []string{"issues", ".issues[", ".issues | keys", ".issues[x]"} {
+		_, err := ParseQuery(expr)
+		assert.Error(t, err, expr)
+	}
+
+	query, err := ParseQuery(".total[]")
+	require.NoError(t, err)
+	_, err = query.Apply(queryResponse{Total: 1})
+	assert.EqualError(t, err, `query ".total[]": cannot iterate over a number`)
+}
+
+func TestFormatterQuery(t *testing.T) {
+	data := queryResponse{Issues: []queryIssue{{Key: "DEMO-1"}, {Key: "DEMO-2"}}, Total: 2}
+
+	tests := []struct {
+		format string
+		query  string
+		want   string
+	}{
+		{"table", ".issues[].key", "DEMO-1\nDEMO-2\n"},
+		{"table", ".total", "2\n"},
+		{"json", ".issues[].key", "[\n  \"DEMO-1\",\n  \"DEMO-2\"\n]\n"},
+		{"json", ".total", "2\n"},
+		{"csv", ".issues", "key,labels\nDEMO-1,\nDEMO-2,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.query, func(t *testing.T) {
+			formatter := NewFormatter(tt.format)
+			require.NoError(t, formatter.SetQuery(tt.query))
+
+			var buf bytes.Buffer
+			require.NoError(t, formatter.WriteList(&buf, data, data.Issues, NewTable("ignored")))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ColumnKind tells the table renderer how to present a column's values on a terminal
+type ColumnKind string
+
+const (
+	KindText     ColumnKind = ""
+	KindTime     ColumnKind = "time"     // Timestamps formatted with TimeLayout, shown relative to now
+	KindPriority ColumnKind = "priority" // JIRA priority names, shown with an icon
+)
+
+// ansiColors maps style names to SGR codes, for cell styles and the template color function
+var ansiColors = map[string]string{
+	"black":   "30",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"gray":    "90",
+	"bold":    "1",
+	"dim":     "2",
+}
+
+// priorityIcons marks the standard JIRA priorities; other names are shown as is
+var priorityIcons = map[string]struct {
+	icon  string
+	style string
+}{
+	"highest": {"⇈", "red"},
+	"blocker": {"⇈", "red"},
+	"high":    {"↑", "red"},
+	"medium":  {"•", "yellow"},
+	"low":     {"↓", "green"},
+	"lowest":  {"⇊", "green"},
+	"trivial": {"⇊", "green"},
+}
+
+// now is the reference time for relative dates, replaceable in tests
+var now = time.Now
+
+// Colorize wraps s in the ANSI color for style, leaving it plain for unknown styles
+func Colorize(style, s string) string {
+	code, ok := ansiColors[style]
+	if !ok || s == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// RelativeTime describes t relative to reference, e.g. "5 minutes ago" or "in 2 days".
+// Times more than 30 days away are shown as a date.
+func RelativeTime(t, reference time.Time) string {
+	d := reference.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		amount = plural(int(d/time.Hour), "hour")
+	case d < 30*24*time.Hour:
+		amount = plural(int(d/(24*time.Hour)), "day")
+	default:
+		return t.Local().Format("2006-01-02")
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// plural formats a count with its unit, e.g. "1 day" or "3 days"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// humanize returns how a value of the given kind is shown on a terminal, and the
+// style it implies when the cell has none of its own. Vertical records keep the
+// exact timestamp next to the relative one.
+func humanize(kind ColumnKind, value string, vertical bool) (string, string) {
+	switch kind {
+	case KindTime:
+		t, err := time.ParseInLocation(TimeLayout, value, time.Local)
+		if err != nil {
+			return value, ""
+		}
+		if vertical {
+			return value + " (" + RelativeTime(t, now()) + ")", ""
+		}
+		return RelativeTime(t, now()), "gray"
+	case KindPriority:
+		if p, ok := priorityIcons[strings.ToLower(value)]; ok {
+			return p.icon + " " + value, p.style
+		}
+	}
+	return value, ""
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeTime(t *testing.T) {
+	reference := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{reference.Add(-20 * time.Second), "just now"},
+		{reference.Add(-1 * time.Minute), "1 minute ago"},
+		{reference.Add(-5 * time.Minute), "5 minutes ago"},
+		{reference.Add(-3 * time.Hour), "3 hours ago"},
+		{reference.Add(-49 * time.Hour), "2 days ago"},
+		{reference.Add(2 * time.Hour), "in 2 hours"},
+		{time.Date(2023, 12, 1, 12, 0, 0, 0, time.Local), "2023-12-01"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, RelativeTime(tt.at, reference))
+	}
+}
+
+func TestTerminalTable(t *testing.T) {
+	reference := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	now = func() time.Time { return reference }
+	defer func() { now = time.Now }()
+
+	newTable := func() *Table {
+		table := NewTable("Key", "Priority", "Updated")
+		table.Columns[1].Kind = KindPriority
+		table.Columns[2].Kind = KindTime
+		table.AddRow("DEMO-1", "High", reference.Add(-2*time.Hour))
+		table.AddRow("DEMO-2", "Custom", reference.Add(-3*24*time.Hour))
+		table.SetStyle(0, 0, "green")
+		return table
+	}
+
+	// Without a terminal values are shown exactly
+	var buf bytes.Buffer
+	assert.NoError(t, NewFormatter("table").Write(&buf, nil, newTable()))
+	assert.Contains(t, buf.String(), "DEMO-1 High     2024-03-10 10:00:00\n")
+
+	// On a terminal times are relative and priorities have icons
+	buf.Reset()
+	formatter := NewFormatter("table")
+	formatter.SetTerminal(true)
+	assert.NoError(t, formatter.Write(&buf, nil, newTable()))
+	assert.Equal(t, "KEY    PRIORITY UPDATED\n---------------------------\nDEMO-1 ↑ High   2 hours ago\nDEMO-2 Custom   3 days ago\n", buf.String())
+
+	// Colors wrap the cell text but not the padding
+	buf.Reset()
+	formatter.SetColor(true)
+	assert.NoError(t, formatter.Write(&buf, nil, newTable()))
+	assert.Contains(t, buf.String(), "\x1b[32mDEMO-1\x1b[0m \x1b[31m↑ High\x1b[0m   \x1b[90m2 hours ago\x1b[0m\n")
+
+	// CSV keeps exact values and no colors
+	buf.Reset()
+	csvFormatter := NewFormatter("csv")
+	csvFormatter.SetTerminal(true)
+	csvFormatter.SetColor(true)
+	assert.NoError(t, csvFormatter.Write(&buf, nil, newTable()))
+	assert.Equal(t, "Key,Priority,Updated\nDEMO-1,High,2024-03-10 10:00:00\nDEMO-2,Custom,2024-03-07 12:00:00\n", buf.String())
+}
+
+func TestTerminalRecord(t *testing.T) {
+	reference := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+	now = func() time.Time { return reference }
+	defer func() { now = time.Now }()
+
+	record := NewRecord().
+		Field("Status", "Done").WithStyle("green").
+		Field("Updated", FormatValue(reference.Add(-time.Hour))).WithKind(KindTime)
+
+	var buf bytes.Buffer
+	formatter := NewFormatter("table")
+	formatter.SetTerminal(true)
+	formatter.SetColor(true)
+	assert.NoError(t, formatter.Write(&buf, nil, record))
+	assert.Equal(t, "Status:  \x1b[32mDone\x1b[0m\nUpdated: 2024-03-10 11:00:00 (1 hour ago)\n", buf.String())
+}
@@ -0,0 +1,543 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the layout used for timestamps in tabular output
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Column describes one column of a table
+type Column struct {
+	Header   string
+	MaxWidth int        // Truncate values wider than this in table format (0 means no limit)
+	Kind     ColumnKind // How values are presented on a terminal
+}
+
+// Table is the tabular view of a value, used by the table, csv, tsv and markdown formats
+type Table struct {
+	Columns  []Column
+	Rows     [][]string
+	Vertical bool       // Render a single record as "Key: value" lines in table format
+	Wide     bool       // Disable truncation and terminal fitting in table format
+	Width    int        // Terminal width to fit columns into in table format (0 means unlimited)
+	Empty    string     // Message printed in table format when there are no rows
+	Footer   string     // Trailing text printed only in table format (e.g. pagination hints)
+	Styles   [][]string // Optional color names per cell, parallel to Rows
+	Terminal bool       // Output goes to a terminal: show relative dates and icons in table format
+	Color    bool       // Apply cell styles as ANSI colors in table format
+}
+
+// minColumnWidth is the narrowest a truncatable column is shrunk to when fitting
+// a table into the terminal
+const minColumnWidth = 8
+
+// NewTable creates a table with the given column headers
+func NewTable(headers ...string) *Table {
+	columns := make([]Column, len(headers))
+	for i, header := range headers {
+		columns[i] = Column{Header: header}
+	}
+	return &Table{Columns: columns}
+}
+
+// NewRecord creates a vertical table describing a single object
+func NewRecord() *Table {
+	return &Table{Vertical: true, Rows: [][]string{{}}}
+}
+
+// Field appends a named value to a record
+func (t *Table) Field(header, value string) *Table {
+	return t.FieldWithWidth(header, value, 0)
+}
+
+// FieldWithWidth appends a named value that is truncated to maxWidth in table format
+func (t *Table) FieldWithWidth(header, value string, maxWidth int) *Table {
+	t.Columns = append(t.Columns, Column{Header: header, MaxWidth: maxWidth})
+	t.Rows[0] = append(t.Rows[0], value)
+	return t
+}
+
+// WithKind sets how the most recently added field is presented on a terminal
+func (t *Table) WithKind(kind ColumnKind) *Table {
+	if len(t.Columns) > 0 {
+		t.Columns[len(t.Columns)-1].Kind = kind
+	}
+	return t
+}
+
+// WithStyle colors the most recently added field of a record
+func (t *Table) WithStyle(style string) *Table {
+	if len(t.Columns) > 0 {
+		t.SetStyle(0, len(t.Columns)-1, style)
+	}
+	return t
+}
+
+// SetStyle sets the color name of the cell at row r, column c
+func (t *Table) SetStyle(r, c int, style string) {
+	for len(t.Styles) <= r {
+		t.Styles = append(t.Styles, nil)
+	}
+	for len(t.Styles[r]) <= c {
+		t.Styles[r] = append(t.Styles[r], "")
+	}
+	t.Styles[r][c] = style
+}
+
+// WithMaxWidth limits the width of the named column in table format
+func (t *Table) WithMaxWidth(header string, maxWidth int) *Table {
+	for i := range t.Columns {
+		if t.Columns[i].Header == header {
+			t.Columns[i].MaxWidth = maxWidth
+		}
+	}
+	return t
+}
+
+// AddRow appends a row of values, formatting each with FormatValue
+func (t *Table) AddRow(values ...interface{}) {
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = FormatValue(value)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+// Headers returns the column headers
+func (t *Table) Headers() []string {
+	headers := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		headers[i] = column.Header
+	}
+	return headers
+}
+
+// renderTable writes aligned, human-readable columns
+func (t *Table) renderTable(w io.Writer) error {
+	if t.Vertical {
+		return t.renderVertical(w)
+	}
+
+	if len(t.Rows) == 0 {
+		if t.Empty != "" {
+			fmt.Fprintln(w, t.Empty)
+		} else {
+			fmt.Fprintln(w, "No data")
+		}
+		return nil
+	}
+
+	rows, styles := t.display(false)
+	widths := t.columnWidths(rows)
+	last := len(t.Columns) - 1
+
+	total := last
+	for i, column := range t.Columns {
+		t.writeCell(w, strings.ToUpper(column.Header), "", widths[i], i == last)
+		total += widths[i]
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.Repeat("-", total))
+
+	for r, row := range rows {
+		for i := range t.Columns {
+			value := TruncateWidth(singleLine(cell(row, i)), widths[i])
+			t.writeCell(w, value, cell(styles[r], i), widths[i], i == last)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if t.Footer != "" {
+		fmt.Fprintf(w, "\n%s\n", t.Footer)
+	}
+	return nil
+}
+
+// display returns the cell text and styles shown in table format. On a terminal,
+// times become relative and priorities gain icons.
+func (t *Table) display(vertical bool) ([][]string, [][]string) {
+	rows := make([][]string, len(t.Rows))
+	styles := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = make([]string, len(t.Columns))
+		styles[r] = make([]string, len(t.Columns))
+		for i, column := range t.Columns {
+			rows[r][i] = cell(row, i)
+			if r < len(t.Styles) {
+				styles[r][i] = cell(t.Styles[r], i)
+			}
+			if !t.Terminal || column.Kind == KindText {
+				continue
+			}
+			value, style := humanize(column.Kind, rows[r][i], vertical)
+			rows[r][i] = value
+			if styles[r][i] == "" {
+				styles[r][i] = style
+			}
+		}
+	}
+	return rows, styles
+}
+
+// columnWidths sizes each column to its widest value, capped by the column's
+// MaxWidth, then shrinks truncatable columns until the table fits Width
+func (t *Table) columnWidths(rows [][]string) []int {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = DisplayWidth(column.Header)
+		for _, row := range rows {
+			if width := DisplayWidth(singleLine(cell(row, i))); width > widths[i] {
+				widths[i] = width
+			}
+		}
+		if !t.Wide && column.MaxWidth > 0 && widths[i] > column.MaxWidth {
+			widths[i] = max(column.MaxWidth, DisplayWidth(column.Header))
+		}
+	}
+
+	if t.Wide || t.Width <= 0 {
+		return widths
+	}
+
+	total := len(widths) - 1
+	for _, width := range widths {
+		total += width
+	}
+	for total > t.Width {
+		// Shrink the widest truncatable column one cell at a time
+		widest := -1
+		for i, column := range t.Columns {
+			floor := max(minColumnWidth, DisplayWidth(column.Header))
+			if column.MaxWidth > 0 && widths[i] > floor && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// renderVertical writes each row as aligned "Header: value" lines
+func (t *Table) renderVertical(w io.Writer) error {
+	labelWidth := 0
+	for _, column := range t.Columns {
+		if len(column.Header) > labelWidth {
+			labelWidth = len(column.Header)
+		}
+	}
+
+	rows, styles := t.display(true)
+	for r, row := range rows {
+		if r > 0 {
+			fmt.Fprintln(w)
+		}
+		for i, column := range t.Columns {
+			value := row[i]
+			if !t.Wide {
+				value = TruncateWidth(value, column.MaxWidth)
+			}
+			fmt.Fprintf(w, "%-*s %s\n", labelWidth+1, column.Header+":", t.paint(styles[r][i], value))
+		}
+	}
+
+	if t.Footer != "" {
+		fmt.Fprintf(w, "\n%s\n", t.Footer)
+	}
+	return nil
+}
+
+// renderDelimited writes the header and rows as CSV, or as TSV when comma is a tab
+func (t *Table) renderDelimited(w io.Writer, comma rune) error {
+	if comma == '\t' {
+		// TSV has no quoting, so tabs and newlines inside values become spaces
+		clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+		fmt.Fprintln(w, strings.Join(t.Headers(), "\t"))
+		for _, row := range t.Rows {
+			values := make([]string, len(t.Columns))
+			for i := range t.Columns {
+				values[i] = clean.Replace(cell(row, i))
+			}
+			fmt.Fprintln(w, strings.Join(values, "\t"))
+		}
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(t.Headers()); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range t.Rows {
+		values := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			values[i] = cell(row, i)
+		}
+		if err := writer.Write(values); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// renderMarkdown writes a GitHub-flavored Markdown table
+func (t *Table) renderMarkdown(w io.Writer) error {
+	escape := strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+	headers := t.Headers()
+	for i := range headers {
+		headers[i] = escape.Replace(headers[i])
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(headers)))
+
+	for _, row := range t.Rows {
+		values := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			values[i] = escape.Replace(cell(row, i))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(values, " | "))
+	}
+	return nil
+}
+
+// cell returns the value at index i, tolerating short rows
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// writeCell writes a padded, styled value followed by a separator. Padding stays
+// outside the color codes so alignment is unaffected.
+func (t *Table) writeCell(w io.Writer, value, style string, width int, last bool) {
+	if last {
+		fmt.Fprint(w, t.paint(style, value))
+		return
+	}
+	padded := PadWidth(value, width)
+	fmt.Fprint(w, t.paint(style, value)+padded[len(value):]+" ")
+}
+
+// paint colors value with style when colors are enabled
+func (t *Table) paint(style, value string) string {
+	if !t.Color || style == "" {
+		return value
+	}
+	return Colorize(style, value)
+}
+
+// FormatValue renders a single value for tabular output
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Local().Format(TimeLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return FormatValue(*v)
+	case []string:
+		return strings.Join(v, ", ")
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return ""
+		}
+		return FormatValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ToTable derives a table from arbitrary data: maps, slices of maps, structs and
+// slices of structs. Struct columns are named after their JSON field names.
+func ToTable(data interface{}) *Table {
+	switch v := data.(type) {
+	case *Table:
+		return v
+	case Table:
+		return &v
+	case map[string]interface{}:
+		return mapRecord(v)
+	case []map[string]interface{}:
+		return mapSliceTable(v)
+	case []interface{}:
+		maps := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return valueList(v)
+			}
+			maps = append(maps, m)
+		}
+		return mapSliceTable(maps)
+	}
+
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return NewTable()
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		columns, values := structFields(rv)
+		return &Table{Columns: columns, Rows: [][]string{values}, Vertical: true}
+	case reflect.Slice, reflect.Array:
+		elemType := rv.Type().Elem()
+		for elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct || elemType == reflect.TypeOf(time.Time{}) {
+			items := make([]interface{}, rv.Len())
+			for i := range items {
+				items[i] = rv.Index(i).Interface()
+			}
+			return valueList(items)
+		}
+		columns, _ := structFields(reflect.New(elemType).Elem())
+		table := &Table{Columns: columns}
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i)
+			for item.Kind() == reflect.Ptr {
+				if item.IsNil() {
+					break
+				}
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.Struct {
+				table.Rows = append(table.Rows, make([]string, len(columns)))
+				continue
+			}
+			_, values := structFields(item)
+			table.Rows = append(table.Rows, values)
+		}
+		return table
+	case reflect.Map:
+		record := NewRecord()
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			record.Field(fmt.Sprint(key.Interface()), FormatValue(rv.MapIndex(key).Interface()))
+		}
+		return record
+	}
+
+	record := NewRecord()
+	record.Field("Value", FormatValue(data))
+	return record
+}
+
+// structFields lists the exported fields of a struct using their JSON names
+func structFields(rv reflect.Value) ([]Column, []string) {
+	var columns []Column
+	var values []string
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		columns = append(columns, Column{Header: name})
+		values = append(values, FormatValue(rv.Field(i).Interface()))
+	}
+
+	return columns, values
+}
+
+// mapRecord renders a single map as a record with sorted keys
+func mapRecord(data map[string]interface{}) *Table {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	record := NewRecord()
+	for _, key := range keys {
+		record.Field(key, FormatValue(data[key]))
+	}
+	return record
+}
+
+// mapSliceTable renders a slice of maps with the union of their keys as columns
+func mapSliceTable(data []map[string]interface{}) *Table {
+	keySet := make(map[string]bool)
+	for _, item := range data {
+		for key := range item {
+			keySet[key] = true
+		}
+	}
+
+	headers := make([]string, 0, len(keySet))
+	for key := range keySet {
+		headers = append(headers, key)
+	}
+	sort.Strings(headers)
+
+	table := NewTable(headers...)
+	for _, item := range data {
+		row := make([]string, len(headers))
+		for i, header := range headers {
+			row[i] = FormatValue(item[header])
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// valueList renders scalar values as a single-column table
+func valueList(data []interface{}) *Table {
+	table := NewTable("Value")
+	for _, item := range data {
+		table.AddRow(item)
+	}
+	return table
+}
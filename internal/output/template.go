@@ -0,0 +1,123 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFuncs returns the helper functions available to --template
+func templateFuncs(colorEnabled bool) template.FuncMap {
+	return template.FuncMap{
+		// truncate shortens a value to n terminal cells: {{.Summary | truncate 40}}
+		"truncate": func(n int, value interface{}) string {
+			return TruncateWidth(FormatValue(value), n)
+		},
+		// color wraps a value in an ANSI color: {{color "green" .Status}}
+		"color": func(name string, value interface{}) (string, error) {
+			s := FormatValue(value)
+			if _, ok := ansiColors[name]; !ok {
+				return "", fmt.Errorf("unknown color %q", name)
+			}
+			if !colorEnabled {
+				return s, nil
+			}
+			return Colorize(name, s), nil
+		},
+		// date formats a timestamp with a Go layout: {{date "2006-01-02" .Updated}}
+		"date": func(layout string, value interface{}) (string, error) {
+			t, err := toTime(value)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return t.Local().Format(layout), nil
+		},
+		// join concatenates a list: {{join ", " .Labels}}
+		"join": func(sep string, value interface{}) string {
+			rv := reflect.ValueOf(value)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				return FormatValue(value)
+			}
+			parts := make([]string, rv.Len())
+			for i := range parts {
+				parts[i] = FormatValue(rv.Index(i).Interface())
+			}
+			return strings.Join(parts, sep)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		// json encodes a value as compact JSON: {{json .Labels}}
+		"json": func(value interface{}) (string, error) {
+			bytes, err := json.Marshal(value)
+			return string(bytes), err
+		},
+	}
+}
+
+// toTime accepts time values and RFC3339 strings (as produced by --query)
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return *v, nil
+	case string:
+		if v == "" {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("date: %q is not an RFC3339 timestamp", v)
+		}
+		return t, nil
+	case nil:
+		return time.Time{}, nil
+	}
+	return time.Time{}, fmt.Errorf("date: cannot format %T", value)
+}
+
+// parseTemplate compiles a --template string
+func parseTemplate(text string, colorEnabled bool) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs(colorEnabled)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// executeTemplate runs the template once per item, or once for data when items is
+// not a slice, ending each result with a newline
+func executeTemplate(w io.Writer, tmpl *template.Template, data interface{}) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice {
+		return executeOne(w, tmpl, data)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := executeOne(w, tmpl, rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeOne renders a single value, adding a trailing newline when missing
+func executeOne(w io.Writer, tmpl *template.Template, data interface{}) error {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	out := buf.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type templateIssue struct {
+	Key     string
+	Summary string
+	Status  string
+	Labels  []string
+	Updated time.Time
+}
+
+func TestTemplateOutput(t *testing.T) {
+	issues := []templateIssue{
+		{Key: "DEMO-1", Summary: "Ünïcode summary that is long", Status: "Done", Labels: []string{"a", "b"}, Updated: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)},
+		{Key: "DEMO-2", Summary: "Short", Status: "To Do"},
+	}
+	response := map[string]interface{}{"issues": issues}
+
+	tests := []struct {
+		name     string
+		template string
+		color    bool
+		want     string
+	}{
+		{"fields per item", "{{.Key}} {{.Summary}}", false, "DEMO-1 Ünïcode summary that is long\nDEMO-2 Short\n"},
+		{"truncate by rune", "{{.Summary | truncate 10}}", false, "Ünïcode...\nShort\n"},
+		{"join", "{{join \",\" .Labels}}", false, "a,b\n\n"},
+		{"date", "{{date \"2006-01-02\" .Updated}}", false, "2024-03-01\n\n"},
+		{"color", "{{color \"green\" .Status}}", true, "\x1b[32mDone\x1b[0m\n\x1b[32mTo Do\x1b[0m\n"},
+		{"color disabled", "{{color \"green\" .Status}}", false, "Done\nTo Do\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter("table")
+			formatter.SetColor(tt.color)
+			require.NoError(t, formatter.SetTemplate(tt.template))
+			assert.Equal(t, FormatTemplate, formatter.Name())
+
+			var buf bytes.Buffer
+			require.NoError(t, formatter.WriteList(&buf, response, issues, nil))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestTemplateWithQuery(t *testing.T) {
+	formatter := NewFormatter("template")
+	require.NoError(t, formatter.SetTemplate(`{{.key}}: {{date "2006" .updated}}`))
+	require.NoError(t, formatter.SetQuery(".issues"))
+
+	data := map[string]interface{}{
+		"issues": []map[string]interface{}{{"key": "DEMO-1", "updated": "2023-05-06T07:08:09Z"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Write(&buf, data, nil))
+	assert.Equal(t, "DEMO-1: 2023\n", buf.String())
+}
+
+func TestTemplateErrors(t *testing.T) {
+	formatter := NewFormatter("table")
+	assert.Error(t, formatter.SetTemplate("{{.Key"))
+
+	require.NoError(t, formatter.SetTemplate(`{{color "chartreuse" .}}`))
+	assert.Error(t, formatter.Write(&bytes.Buffer{}, "x", nil))
+
+	assert.EqualError(t, NewFormatter("template").Write(&bytes.Buffer{}, "x", nil), "template output requires --template")
+}
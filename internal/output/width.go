@@ -0,0 +1,77 @@
+package output
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Ellipsis marks truncated values in table output
+const Ellipsis = "..."
+
+// runeWidth returns the number of terminal cells a rune occupies
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r):
+		return 0
+	case unicode.IsControl(r):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// DisplayWidth returns the number of terminal cells s occupies
+func DisplayWidth(s string) int {
+	total := 0
+	for _, r := range s {
+		total += runeWidth(r)
+	}
+	return total
+}
+
+// TruncateWidth shortens s to at most maxWidth terminal cells, marking the cut
+// with an ellipsis. Multi-byte and wide characters are never split.
+func TruncateWidth(s string, maxWidth int) string {
+	if maxWidth <= 0 || DisplayWidth(s) <= maxWidth {
+		return s
+	}
+
+	suffix := Ellipsis
+	limit := maxWidth - len(suffix)
+	if limit <= 0 {
+		suffix, limit = "", maxWidth
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if used+w > limit {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + suffix
+}
+
+// PadWidth right-pads s with spaces to occupy width terminal cells
+func PadWidth(s string, width int) string {
+	if gap := width - DisplayWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// singleLine collapses line breaks so a value cannot break table alignment
+func singleLine(s string) string {
+	if !strings.ContainsAny(s, "\r\n\t") {
+		return s
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 5, DisplayWidth("hello"))
+	assert.Equal(t, 5, DisplayWidth("héllo"))
+	assert.Equal(t, 5, DisplayWidth("he\u0301llo"), "combining marks take no space")
+	assert.Equal(t, 8, DisplayWidth("日本語ab"), "CJK characters are double width")
+}
+
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, "hello", TruncateWidth("hello", 5))
+	assert.Equal(t, "he...", TruncateWidth("hello world", 5))
+	assert.Equal(t, "Ünï...", TruncateWidth("Ünïcode", 6))
+	assert.Equal(t, "日...", TruncateWidth("日本語", 5), "wide runes are never split")
+	assert.Equal(t, "he", TruncateWidth("hello", 2))
+	assert.Equal(t, "hello", TruncateWidth("hello", 0))
+}
+
+func TestTableFitsTerminalWidth(t *testing.T) {
+	table := &Table{
+		Columns: []Column{{Header: "Key"}, {Header: "Summary", MaxWidth: 50}, {Header: "Status"}},
+		Width:   40,
+	}
+	table.AddRow("DEMO-1", strings.Repeat("word ", 10), "In Progress")
+	table.AddRow("DEMO-2", "日本語のタイトルです", "Done")
+
+	var buf bytes.Buffer
+	require.NoError(t, table.renderTable(&buf))
+
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		assert.LessOrEqual(t, DisplayWidth(line), 40, "line %q is wider than the terminal", line)
+	}
+	assert.Contains(t, buf.String(), "In Progress", "fixed columns are never truncated")
+
+	// Status column starts at the same display offset on every row
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, DisplayWidth(lines[2][:strings.Index(lines[2], "In Progress")]),
+		DisplayWidth(lines[3][:strings.Index(lines[3], "Done")]))
+}
@@ -10,7 +10,7 @@ type Config struct {
 	DefaultJiraProject     string        `mapstructure:"default_jira_project"`
 	DefaultConfluenceSpace string        `mapstructure:"default_confluence_space"`
 	Timeout                time.Duration `mapstructure:"timeout"`
-	Output                 string        `mapstructure:"output" validate:"oneof=json table yaml"`
+	Output                 string        `mapstructure:"output" validate:"oneof=json table yaml csv tsv markdown ndjson template"`
 	Debug                  bool          `mapstructure:"debug"`
 	Verbose                bool          `mapstructure:"verbose"`
 }
@@ -4,20 +4,23 @@ import "time"
 
 // Issue represents a JIRA issue
 type Issue struct {
-	ID          string    `json:"id"`
-	Key         string    `json:"key"`
-	Summary     string    `json:"summary"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	IssueType   string    `json:"issueType"`
-	Priority    string    `json:"priority"`
-	Assignee    string    `json:"assignee"`
-	Reporter    string    `json:"reporter"`
-	Project     string    `json:"project"`
-	Created     time.Time `json:"created"`
-	Updated     time.Time `json:"updated"`
-	Labels      []string  `json:"labels"`
-	Components  []string  `json:"components"`
+	ID             string    `json:"id"`
+	Key            string    `json:"key"`
+	Summary        string    `json:"summary"`
+	Description    string    `json:"description"`
+	Status         string    `json:"status"`
+	StatusCategory string    `json:"statusCategory,omitempty"` // JIRA status category key: new, indeterminate or done
+	IssueType      string    `json:"issueType"`
+	Priority       string    `json:"priority"`
+	Assignee       string    `json:"assignee"`
+	Reporter       string    `json:"reporter"`
+	Project        string    `json:"project"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+	Labels         []string  `json:"labels"`
+	Components     []string  `json:"components"`
+
+	CustomFields map[string]interface{} `json:"customFields,omitempty"` // customfield_* values keyed by field ID
 }
 
 // CreateIssueRequest represents a request to create a new issue
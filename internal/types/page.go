@@ -1,16 +1,26 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Page represents a Confluence page
 type Page struct {
 	ID       string    `json:"id"`
 	Title    string    `json:"title"`
 	Type     string    `json:"type"`
+	Status   string    `json:"status,omitempty"` // current, trashed or draft
 	SpaceKey string    `json:"spaceKey"`
+	ParentID string    `json:"parentId,omitempty"`
+	Position int       `json:"position"` // Order among its siblings, from 0
 	Content  string    `json:"content"`
 	Version  int       `json:"version"`
 	Updated  time.Time `json:"updated"`
+	Labels   []string  `json:"labels,omitempty"`
+
+	// Published is the date a blog post is published under; nil for pages
+	Published *time.Time `json:"published,omitempty"`
 }
 
 // CreatePageRequest represents a request to create a new page
@@ -21,19 +31,76 @@ type CreatePageRequest struct {
 	ParentID string `json:"parentId"`
 }
 
+// CreateBlogPostRequest represents a request to publish a blog post
+type CreateBlogPostRequest struct {
+	SpaceKey string `json:"spaceKey" validate:"required"`
+	Title    string `json:"title" validate:"required"`
+	Content  string `json:"content"`
+
+	// PublishDate dates the post, e.g. to backdate it; nil publishes it today
+	PublishDate *time.Time `json:"publishDate,omitempty"`
+}
+
 // UpdatePageRequest represents a request to update an existing page
 type UpdatePageRequest struct {
 	Title   *string `json:"title,omitempty"`
 	Content *string `json:"content,omitempty"`
+	Message string  `json:"message,omitempty"` // Version comment shown in the page history
+
+	// ExpectedVersion makes the update fail with a conflict unless the page is still
+	// at this version (0 updates whatever the current version is)
+	ExpectedVersion int `json:"expectedVersion,omitempty"`
+}
+
+// PageVersion represents one version in a page's history
+type PageVersion struct {
+	Number    int       `json:"number"`
+	Author    string    `json:"author"`
+	When      time.Time `json:"when"`
+	Message   string    `json:"message"`
+	MinorEdit bool      `json:"minorEdit"`
+}
+
+// CopyPageRequest represents a request to copy a page under a new parent
+type CopyPageRequest struct {
+	ParentID           string `json:"parentId" validate:"required"`
+	Title              string `json:"title"` // Title of the copy; empty keeps the original title
+	IncludeAttachments bool   `json:"includeAttachments"`
+	IncludeLabels      bool   `json:"includeLabels"`
+}
+
+// PageCopy maps a copied page to the page created from it
+type PageCopy struct {
+	OldID string `json:"oldId"`
+	NewID string `json:"newId"`
+	Title string `json:"title"`
 }
 
 // PageListOptions represents options for listing pages
 type PageListOptions struct {
-	SpaceKey   string `json:"spaceKey"`
-	Title      string `json:"title"`
-	MaxResults int    `json:"maxResults"`
-	StartAt    int    `json:"startAt"` // Retained for backward compatibility
-	Cursor     string `json:"cursor"`  // Cursor-based pagination for Confluence v2
+	SpaceKey   string   `json:"spaceKey"`
+	Title      string   `json:"title"`
+	Labels     []string `json:"labels,omitempty"` // Only pages with all of these labels
+	MaxResults int      `json:"maxResults"`
+	StartAt    int      `json:"startAt"` // Retained for backward compatibility
+	Cursor     string   `json:"cursor"`  // Cursor-based pagination for Confluence v2
+}
+
+// BlogPostListOptions represents options for listing blog posts
+type BlogPostListOptions struct {
+	SpaceKey   string    `json:"spaceKey"`
+	Title      string    `json:"title"`
+	Since      time.Time `json:"since"` // Only posts published on or after this date
+	Until      time.Time `json:"until"` // Only posts published before this date
+	MaxResults int       `json:"maxResults"`
+	Cursor     string    `json:"cursor"`
+}
+
+// Label represents a label on a page
+type Label struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Prefix string `json:"prefix"` // global, my or team
 }
 
 // PageListResponse represents the response from listing pages
@@ -47,16 +114,55 @@ type PageListResponse struct {
 
 // Space represents a Confluence space
 type Space struct {
-	ID          string `json:"id"`
-	Key         string `json:"key"`
-	Name        string `json:"name"`
-	Type        string `json:"type"`
+	ID            string `json:"id"`
+	Key           string `json:"key"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Status        string `json:"status,omitempty"` // current or archived
+	Description   string `json:"description"`
+	HomepageID    string `json:"homepageId,omitempty"`
+	HomepageTitle string `json:"homepageTitle,omitempty"`
+
+	// Permissions is filled in only when a single space is requested
+	Permissions []SpacePermission `json:"permissions,omitempty"`
+}
+
+// SpacePermission lists what one group or user may do in a space
+type SpacePermission struct {
+	SubjectType string   `json:"subjectType"` // group, user or anonymous
+	Subject     string   `json:"subject"`     // Group name or user display name
+	Operations  []string `json:"operations"`  // e.g. "read space", "create page"
+}
+
+// Grant is one operation a user or group is granted: a space permission or a
+// page restriction
+type Grant struct {
+	ID          string `json:"id,omitempty"` // Space permission ID; page restrictions have none
+	Operation   string `json:"operation"`    // e.g. "create page" for spaces, read or update for pages
+	SubjectType string `json:"subjectType"`  // user, group or anonymous
+	SubjectID   string `json:"subjectId"`    // Account ID or group name
+	Subject     string `json:"subject"`      // Display name of a user, name of a group
+}
+
+// CreateSpaceRequest represents a request to create a space
+type CreateSpaceRequest struct {
+	Key         string `json:"key" validate:"required"`
+	Name        string `json:"name" validate:"required"`
 	Description string `json:"description"`
+	Private     bool   `json:"private"` // Only the creator can see a private space
+}
+
+// UpdateSpaceRequest represents a request to update a space; nil fields are kept
+type UpdateSpaceRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	HomepageID  *string `json:"homepageId,omitempty"`
 }
 
 // SpaceListOptions represents options for listing spaces
 type SpaceListOptions struct {
 	Type       string `json:"type"`
+	Status     string `json:"status"` // current or archived; empty lists both
 	MaxResults int    `json:"maxResults"`
 	StartAt    int    `json:"startAt"` // Retained for backward compatibility
 	Cursor     string `json:"cursor"`  // Cursor-based pagination for Confluence v2
@@ -85,3 +191,56 @@ type PageSearchResponse struct {
 	StartAt    int    `json:"startAt"`
 	MaxResults int    `json:"maxResults"`
 }
+
+// Attachment represents a file attached to a page
+type Attachment struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"` // File name
+	MediaType   string `json:"mediaType"`
+	FileSize    int    `json:"fileSize"`
+	Version     int    `json:"version"`
+	PageID      string `json:"pageId"`
+	DownloadURL string `json:"downloadUrl,omitempty"` // Relative to the site
+}
+
+// Comment represents a footer or inline comment on a page
+type Comment struct {
+	ID         string    `json:"id"`
+	PageID     string    `json:"pageId"`
+	ParentID   string    `json:"parentId,omitempty"` // Comment this one replies to
+	Location   string    `json:"location"`           // footer or inline
+	Author     string    `json:"author"`
+	Created    time.Time `json:"created"`
+	Version    int       `json:"version"`
+	Content    string    `json:"content"`              // Storage format
+	Selection  string    `json:"selection,omitempty"`  // Page text an inline comment is anchored to
+	Resolution string    `json:"resolution,omitempty"` // open, resolved, reopened or dangling, for inline comments
+}
+
+// CreateCommentRequest represents a request to comment on a page. A comment with
+// a selection is an inline comment anchored to that text.
+type CreateCommentRequest struct {
+	PageID         string `json:"pageId" validate:"required"`
+	Content        string `json:"content" validate:"required"` // Storage format
+	Selection      string `json:"selection,omitempty"`
+	SelectionIndex int    `json:"selectionIndex,omitempty"` // Which occurrence of Selection, from 0
+}
+
+// Template represents a page template, global or belonging to a space
+type Template struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	SpaceKey    string   `json:"spaceKey,omitempty"` // Empty for global templates
+	Labels      []string `json:"labels,omitempty"`
+	Variables   []string `json:"variables,omitempty"` // Names of the variables the body uses
+	Content     string   `json:"content,omitempty"`   // Storage format
+}
+
+// ContentProperty represents a JSON value stored on a page under a key
+type ContentProperty struct {
+	ID      string          `json:"id"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version int             `json:"version"`
+}